@@ -0,0 +1,57 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cfg "sigs.k8s.io/controller-runtime/pkg/config/v1alpha1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+// OperatorFeatures toggle the optional integrations of the operator.
+type OperatorFeatures struct {
+	// Istio enables support for the Istio sidecar container.
+	Istio bool `json:"istio,omitempty"`
+
+	// KEDA enables support for the KEDA autoscaling backend.
+	KEDA bool `json:"keda,omitempty"`
+
+	// Queueing holds new clusters in a queue until there is enough resource
+	// capacity to run them.
+	Queueing bool `json:"queueing,omitempty"`
+
+	// ServerSideApply manages the resources owned by clusters with
+	// server-side apply.
+	ServerSideApply bool `json:"serverSideApply,omitempty"`
+
+	// PodSecurityBackend governs cluster pod security: psp,
+	// pod-security-admission or scc.
+	PodSecurityBackend string `json:"podSecurityBackend,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OperatorConfig is the Schema for the operator configuration file.
+//
+// Manager options and features are read on startup and command-line flags
+// take precedence over them. The leader election resource name is derived
+// from the controller class. Cluster defaults are reloaded when the file
+// changes, while changes to any other field only take effect after the
+// operator restarts and are logged as a warning until then.
+type OperatorConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ControllerManagerConfigurationSpec returns the configuration of the
+	// controller manager.
+	cfg.ControllerManagerConfigurationSpec `json:",inline"`
+
+	// Features toggle the optional integrations of the operator.
+	Features OperatorFeatures `json:"features,omitempty"`
+
+	// Defaults applied to clusters that do not set the corresponding fields.
+	// Empty fields use the built-in defaults.
+	Defaults dcv1alpha1.ClusterDefaults `json:"defaults,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OperatorConfig{})
+}
@@ -0,0 +1,240 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// RayClusterDefaults are the operator defaults applied to ray clusters that
+// do not set the corresponding fields.
+type RayClusterDefaults struct {
+	// Image used to launch head and worker nodes.
+	Image *OCIImageDefinition `json:"image,omitempty"`
+
+	// Port is the redis port of the head node.
+	Port int32 `json:"port,omitempty"`
+
+	// RedisShardPorts used by redis shards on the head node.
+	RedisShardPorts []int32 `json:"redisShardPorts,omitempty"`
+
+	// ClientServerPort used by ray clients.
+	ClientServerPort int32 `json:"clientServerPort,omitempty"`
+
+	// ObjectManagerPort used by the object manager.
+	ObjectManagerPort int32 `json:"objectManagerPort,omitempty"`
+
+	// NodeManagerPort used by the node manager.
+	NodeManagerPort int32 `json:"nodeManagerPort,omitempty"`
+
+	// GCSServerPort used by the gcs server.
+	GCSServerPort int32 `json:"gcsServerPort,omitempty"`
+
+	// DashboardPort used by the dashboard ui.
+	DashboardPort int32 `json:"dashboardPort,omitempty"`
+
+	// MetricsPort used to export metrics when monitoring is configured.
+	MetricsPort int32 `json:"metricsPort,omitempty"`
+
+	// EnableDashboard launches the dashboard ui.
+	EnableDashboard *bool `json:"enableDashboard,omitempty"`
+
+	// EnableNetworkPolicy creates network policies that restrict cluster
+	// traffic.
+	EnableNetworkPolicy *bool `json:"enableNetworkPolicy,omitempty"`
+
+	// NetworkPolicyLabels select the pods that may access the client server
+	// and dashboard ports.
+	NetworkPolicyLabels map[string]string `json:"networkPolicyLabels,omitempty"`
+
+	// WorkerReplicas is the number of worker nodes.
+	WorkerReplicas *int32 `json:"workerReplicas,omitempty"`
+
+	// SecurityProfile applied to cluster pods.
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// RunAsUser is the non-root user of the image. It is used by the
+	// restricted security profiles.
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+}
+
+// SetDefaults sets the fields that are empty to the built-in defaults.
+func (d *RayClusterDefaults) SetDefaults() {
+	if d.Image == nil {
+		d.Image = &OCIImageDefinition{
+			Repository: "rayproject/ray",
+			Tag:        "1.3.0-cpu",
+		}
+	}
+	if d.Port == 0 {
+		d.Port = 6379
+	}
+	if d.RedisShardPorts == nil {
+		d.RedisShardPorts = []int32{6380, 6381}
+	}
+	if d.ClientServerPort == 0 {
+		d.ClientServerPort = 10001
+	}
+	if d.ObjectManagerPort == 0 {
+		d.ObjectManagerPort = 2384
+	}
+	if d.NodeManagerPort == 0 {
+		d.NodeManagerPort = 2385
+	}
+	if d.GCSServerPort == 0 {
+		d.GCSServerPort = 2386
+	}
+	if d.DashboardPort == 0 {
+		d.DashboardPort = 8265
+	}
+	if d.MetricsPort == 0 {
+		d.MetricsPort = 8080
+	}
+	if d.EnableDashboard == nil {
+		d.EnableDashboard = pointer.BoolPtr(true)
+	}
+	if d.EnableNetworkPolicy == nil {
+		d.EnableNetworkPolicy = pointer.BoolPtr(true)
+	}
+	if d.NetworkPolicyLabels == nil {
+		d.NetworkPolicyLabels = map[string]string{"ray-client": "true"}
+	}
+	if d.WorkerReplicas == nil {
+		d.WorkerReplicas = pointer.Int32Ptr(1)
+	}
+	if d.SecurityProfile == "" {
+		d.SecurityProfile = SecurityProfileNone
+	}
+	if d.RunAsUser == nil {
+		d.RunAsUser = pointer.Int64Ptr(1000)
+	}
+}
+
+// SparkClusterDefaults are the operator defaults applied to spark clusters
+// that do not set the corresponding fields.
+type SparkClusterDefaults struct {
+	// Image used to launch master and worker nodes.
+	Image *OCIImageDefinition `json:"image,omitempty"`
+
+	// ClusterPort used by the master node.
+	ClusterPort int32 `json:"clusterPort,omitempty"`
+
+	// DashboardPort used by the dashboard ui.
+	DashboardPort int32 `json:"dashboardPort,omitempty"`
+
+	// EnableDashboard launches the dashboard ui.
+	EnableDashboard *bool `json:"enableDashboard,omitempty"`
+
+	// EnableNetworkPolicy creates network policies that restrict cluster
+	// traffic.
+	EnableNetworkPolicy *bool `json:"enableNetworkPolicy,omitempty"`
+
+	// NetworkPolicyLabels select the pods that may access the cluster and
+	// dashboard ports.
+	NetworkPolicyLabels map[string]string `json:"networkPolicyLabels,omitempty"`
+
+	// WorkerReplicas is the number of worker nodes.
+	WorkerReplicas *int32 `json:"workerReplicas,omitempty"`
+
+	// SecurityProfile applied to cluster pods.
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// RunAsUser is the non-root user of the image. It is used by the pod
+	// security context of clusters that do not provide one and by the
+	// restricted security profiles.
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+
+	// FSGroup is the group of the image that owns its writable directories.
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+}
+
+// SetDefaults sets the fields that are empty to the built-in defaults.
+func (d *SparkClusterDefaults) SetDefaults() {
+	if d.Image == nil {
+		d.Image = &OCIImageDefinition{
+			Repository: "bitnami/spark",
+			Tag:        "3.0.2-debian-10-r0",
+		}
+	}
+	if d.ClusterPort == 0 {
+		d.ClusterPort = 7077
+	}
+	if d.DashboardPort == 0 {
+		d.DashboardPort = 8265
+	}
+	if d.EnableDashboard == nil {
+		d.EnableDashboard = pointer.BoolPtr(true)
+	}
+	if d.EnableNetworkPolicy == nil {
+		d.EnableNetworkPolicy = pointer.BoolPtr(true)
+	}
+	if d.NetworkPolicyLabels == nil {
+		d.NetworkPolicyLabels = map[string]string{"spark-client": "true"}
+	}
+	if d.WorkerReplicas == nil {
+		d.WorkerReplicas = pointer.Int32Ptr(1)
+	}
+	if d.SecurityProfile == "" {
+		d.SecurityProfile = SecurityProfileNone
+	}
+	if d.RunAsUser == nil {
+		d.RunAsUser = pointer.Int64Ptr(1001)
+	}
+	if d.FSGroup == nil {
+		d.FSGroup = pointer.Int64Ptr(1001)
+	}
+}
+
+// ClusterDefaults are the operator defaults of every cluster kind.
+type ClusterDefaults struct {
+	// Ray cluster defaults.
+	Ray RayClusterDefaults `json:"ray,omitempty"`
+
+	// Spark cluster defaults.
+	Spark SparkClusterDefaults `json:"spark,omitempty"`
+}
+
+// NewClusterDefaults returns the built-in defaults that are used when the
+// operator is not configured otherwise.
+func NewClusterDefaults() ClusterDefaults {
+	var d ClusterDefaults
+	d.SetDefaults()
+
+	return d
+}
+
+// SetDefaults sets the fields of every kind that are empty to the built-in
+// defaults.
+func (d *ClusterDefaults) SetDefaults() {
+	d.Ray.SetDefaults()
+	d.Spark.SetDefaults()
+}
+
+// Validate checks that clusters which only use the defaults are valid.
+func (d ClusterDefaults) Validate() error {
+	meta := metav1.ObjectMeta{Name: "defaults"}
+
+	rc := &RayCluster{ObjectMeta: meta}
+	rc.applyClusterDefaults(d)
+	if err := rc.validateRayCluster(nil); err != nil {
+		return fmt.Errorf("invalid ray defaults: %w", err)
+	}
+
+	sc := &SparkCluster{ObjectMeta: meta}
+	sc.applyClusterDefaults(d)
+	if err := sc.validateSparkCluster(nil); err != nil {
+		return fmt.Errorf("invalid spark defaults: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:object:generate=false
+
+// ClusterDefaultsSource supplies the operator defaults. Implementations must
+// be safe for concurrent use and may return different defaults over time,
+// e.g. when the operator configuration is reloaded.
+type ClusterDefaultsSource interface {
+	ClusterDefaults() ClusterDefaults
+}
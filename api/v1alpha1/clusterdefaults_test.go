@@ -0,0 +1,35 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyClusterDefaults(t *testing.T) {
+	defaults := NewClusterDefaults()
+	defaults.Ray.Image = &OCIImageDefinition{Repository: "rayproject/ray", Tag: "1.5.0"}
+	defaults.Spark.Image = &OCIImageDefinition{Repository: "bitnami/spark", Tag: "3.1.1"}
+
+	t.Run("ray", func(t *testing.T) {
+		rc := &RayCluster{}
+		rc.applyClusterDefaults(defaults)
+		assert.Equal(t, defaults.Ray.Image, rc.Spec.Image)
+
+		provided := &OCIImageDefinition{Repository: "custom/ray", Tag: "latest"}
+		rc = &RayCluster{Spec: RayClusterSpec{Image: provided}}
+		rc.applyClusterDefaults(defaults)
+		assert.Equal(t, provided, rc.Spec.Image)
+	})
+
+	t.Run("spark", func(t *testing.T) {
+		sc := &SparkCluster{}
+		sc.applyClusterDefaults(defaults)
+		assert.Equal(t, defaults.Spark.Image, sc.Spec.Image)
+
+		provided := &OCIImageDefinition{Repository: "custom/spark", Tag: "latest"}
+		sc = &SparkCluster{Spec: SparkClusterSpec{Image: provided}}
+		sc.applyClusterDefaults(defaults)
+		assert.Equal(t, provided, sc.Spec.Image)
+	})
+}
@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterQueueSpec defines the desired state of a ClusterQueue resource.
+type ClusterQueueSpec struct {
+	// Capacity is the total amount of resources that may be requested by all
+	// of the clusters admitted through this queue. Keys use the same format as
+	// resource quotas, e.g. "requests.cpu", "limits.memory" and "pods".
+	// Resources that are not listed are unlimited.
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=cq
+
+// ClusterQueue is the Schema for the clusterqueues API. Clusters that
+// reference a queue are held in the "Queued" phase until its remaining
+// capacity can satisfy their resource requests.
+type ClusterQueue struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterQueueSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterQueueList contains a list of ClusterQueue resources.
+type ClusterQueueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterQueue `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterQueue{}, &ClusterQueueList{})
+}
@@ -1,11 +1,33 @@
 package v1alpha1
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// AutoscalingBackend selects the implementation used to scale workloads.
+type AutoscalingBackend string
+
+const (
+	// AutoscalingBackendHPA scales workloads with a horizontal pod autoscaler.
+	AutoscalingBackendHPA AutoscalingBackend = "hpa"
+	// AutoscalingBackendKEDA scales workloads with a KEDA scaled object.
+	AutoscalingBackendKEDA AutoscalingBackend = "keda"
+	// AutoscalingBackendSparkMaster scales Spark workers using application
+	// demand reported by the Spark master.
+	AutoscalingBackendSparkMaster AutoscalingBackend = "spark-master"
+)
 
 // Autoscaling configuration for scalable workloads.
 type Autoscaling struct {
+	// Backend used to scale workloads. The "hpa" backend is used when this
+	// field is blank.
+	Backend AutoscalingBackend `json:"backend,omitempty"`
+
 	// MinReplicas is the lower limit for the number of replicas to which the autoscaler can scale down.
-	// This value must be greater than zero and less than the MaxReplicas.
+	// This value must be greater than zero and less than the MaxReplicas. A
+	// value of zero is only permitted when using the "keda" backend.
 	MinReplicas *int32 `json:"minReplicas,omitempty"`
 
 	// MaxReplicas is the upper limit for the number of replicas to which the autoscaler can scale up.
@@ -20,6 +42,320 @@ type Autoscaling struct {
 	// when scaling down. A shorter window will trigger scale down events quicker, but too short a window may cause
 	// replica flapping when metrics used for scaling keep fluctuating.
 	ScaleDownStabilizationWindowSeconds *int32 `json:"scaleDownStabilizationWindowSeconds,omitempty"`
+
+	// ScaleUpStabilizationWindowSeconds is the number of seconds for which past recommendations should be considered
+	// when scaling up. A longer window will delay scale up events until increased demand has been sustained.
+	ScaleUpStabilizationWindowSeconds *int32 `json:"scaleUpStabilizationWindowSeconds,omitempty"`
+
+	// KEDA parameters used when the "keda" backend is selected.
+	KEDA *KEDAConfig `json:"keda,omitempty"`
+}
+
+// KEDAConfig defines the event-driven scaling parameters passed to a KEDA
+// scaled object.
+type KEDAConfig struct {
+	// PollingInterval is the number of seconds between trigger checks.
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+
+	// CooldownPeriod is the number of seconds to wait after the last active
+	// trigger before scaling down to zero.
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+
+	// Triggers that activate scaling. Spark clusters will default to a trigger
+	// that polls the master metrics endpoint for active applications when
+	// none are provided.
+	Triggers []KEDATrigger `json:"triggers,omitempty"`
+}
+
+// KEDATrigger describes a single KEDA scaler (e.g. prometheus, metrics-api).
+type KEDATrigger struct {
+	// Type of KEDA scaler.
+	Type string `json:"type"`
+
+	// Name is an optional identifier for the trigger.
+	Name string `json:"name,omitempty"`
+
+	// Metadata contains the scaler-specific configuration parameters.
+	Metadata map[string]string `json:"metadata"`
+
+	// AuthenticationRef is the name of a TriggerAuthentication object in the
+	// cluster namespace.
+	AuthenticationRef string `json:"authenticationRef,omitempty"`
+}
+
+// PodDisruptionBudgetConfig defines the disruption budgets applied to cluster
+// pods during voluntary evictions such as node drains.
+type PodDisruptionBudgetConfig struct {
+	// Enabled controls the creation of disruption budgets. The head pod budget
+	// will not allow any voluntary evictions.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MinAvailable is the number or percentage of worker pods that must remain
+	// available during an eviction. This cannot be combined with
+	// MaxUnavailable.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of worker pods that can be
+	// unavailable during an eviction. A value of 1 is used when neither this
+	// field nor MinAvailable are provided.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// NetworkPolicyEgress defines the outbound traffic permitted from cluster
+// nodes. Traffic between the nodes of a single cluster is always permitted.
+type NetworkPolicyEgress struct {
+	// Enabled restricts egress traffic from cluster nodes to other cluster
+	// nodes and the destinations configured below.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// AllowDNS permits DNS lookups against any destination on port 53. This
+	// is allowed unless explicitly set to false.
+	AllowDNS *bool `json:"allowDNS,omitempty"`
+
+	// CIDRs that cluster nodes can reach on any port (e.g. object storage
+	// endpoints).
+	CIDRs []string `json:"cidrs,omitempty"`
+
+	// Rules are additional egress rules applied to all cluster nodes.
+	Rules []networkingv1.NetworkPolicyEgressRule `json:"rules,omitempty"`
+}
+
+// PodGroupFlavor selects the gang scheduler implementation used to schedule
+// cluster pods as a group.
+type PodGroupFlavor string
+
+const (
+	// PodGroupFlavorSchedulerPlugins uses the coscheduling plugin provided by
+	// kubernetes-sigs/scheduler-plugins.
+	PodGroupFlavorSchedulerPlugins PodGroupFlavor = "scheduler-plugins"
+	// PodGroupFlavorVolcano uses the volcano batch scheduler.
+	PodGroupFlavorVolcano PodGroupFlavor = "volcano"
+)
+
+// SchedulingConfig defines how cluster pods are dispatched to nodes.
+type SchedulingConfig struct {
+	// SchedulerName is the scheduler used to dispatch cluster pods. The
+	// "volcano" scheduler is used when this is blank and gang scheduling is
+	// enabled with the "volcano" flavor.
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// PriorityClassName indicates the importance of cluster pods relative to
+	// other pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Gang scheduling parameters used to ensure the head and minimum number of
+	// workers are scheduled together.
+	Gang *GangSchedulingConfig `json:"gang,omitempty"`
+}
+
+// GangSchedulingConfig defines the pod group created for a cluster.
+type GangSchedulingConfig struct {
+	// Enabled controls the creation of a pod group that requires the head and
+	// the minimum number of workers to be scheduled at the same time.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Flavor of pod group to create. The "scheduler-plugins" flavor is used
+	// when this field is blank.
+	Flavor PodGroupFlavor `json:"flavor,omitempty"`
+
+	// ScheduleTimeoutSeconds is the maximum time a pod group will wait to be
+	// scheduled. Only used with the "scheduler-plugins" flavor.
+	ScheduleTimeoutSeconds *int32 `json:"scheduleTimeoutSeconds,omitempty"`
+
+	// Queue to which the pod group is submitted. Only used with the "volcano"
+	// flavor.
+	Queue string `json:"queue,omitempty"`
+}
+
+// ClusterPhase describes where a cluster is in its lifecycle.
+type ClusterPhase string
+
+const (
+	// ClusterPhaseQueued indicates the cluster is waiting for enough resource
+	// capacity to become available before its resources are created.
+	ClusterPhaseQueued ClusterPhase = "Queued"
+	// ClusterPhaseAdmitted indicates the cluster has been granted capacity and
+	// its resources are being reconciled.
+	ClusterPhaseAdmitted ClusterPhase = "Admitted"
+)
+
+// SecurityProfile selects the hardening applied to the security context of
+// every generated container.
+type SecurityProfile string
+
+const (
+	// SecurityProfileNone leaves container security contexts unmodified.
+	SecurityProfileNone SecurityProfile = "none"
+	// SecurityProfileBaseline prevents privilege escalation, drops all
+	// capabilities and applies the runtime default seccomp profile.
+	SecurityProfileBaseline SecurityProfile = "baseline"
+	// SecurityProfileRestricted extends the baseline profile by requiring a
+	// non-root user and a read-only root filesystem. Writable empty dir
+	// volumes are mounted over the paths that the application writes to.
+	SecurityProfileRestricted SecurityProfile = "restricted"
+)
+
+// ExposeType selects how cluster endpoints are reached from outside of the
+// Kubernetes cluster.
+type ExposeType string
+
+const (
+	// ExposeTypeIngress routes traffic through a networking.k8s.io Ingress.
+	ExposeTypeIngress ExposeType = "Ingress"
+	// ExposeTypeIstioGateway routes traffic through an Istio Gateway and
+	// VirtualService. Istio support must be enabled in the operator.
+	ExposeTypeIstioGateway ExposeType = "IstioGateway"
+	// ExposeTypeLoadBalancer changes the head service type to LoadBalancer.
+	ExposeTypeLoadBalancer ExposeType = "LoadBalancer"
+	// ExposeTypeNodePort changes the head service type to NodePort. URLs use
+	// the address of the node running the head pod.
+	ExposeTypeNodePort ExposeType = "NodePort"
+)
+
+// ExposeEndpoint defines how traffic is routed to a single cluster endpoint.
+type ExposeEndpoint struct {
+	// Host is the external host name routed to the endpoint. Requests for
+	// any host are routed when this is blank.
+	Host string `json:"host,omitempty"`
+
+	// PathPrefix routes requests whose path starts with this value to the
+	// endpoint. "/" is used when this is blank.
+	PathPrefix string `json:"pathPrefix,omitempty"`
+}
+
+// ExposeConfig defines how cluster endpoints are made reachable from outside
+// of the Kubernetes cluster.
+type ExposeConfig struct {
+	// Type of exposure used for cluster endpoints.
+	Type ExposeType `json:"type,omitempty"`
+
+	// Dashboard routing used by the "Ingress" and "IstioGateway" types. The
+	// dashboard must be enabled.
+	Dashboard *ExposeEndpoint `json:"dashboard,omitempty"`
+
+	// Client routing used by the "Ingress" and "IstioGateway" types.
+	Client *ExposeEndpoint `json:"client,omitempty"`
+
+	// TLSSecretName references a secret that contains the certificate used to
+	// terminate HTTPS traffic. Istio gateways require the secret to reside in
+	// the namespace of the gateway pods.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// IngressClassName selects the ingress controller that serves the
+	// "Ingress" type. The cluster default class is used when this is blank.
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// Annotations added to the generated ingress, gateway, virtual service or
+	// head service.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// GatewaySelector selects the Istio ingress gateway pods that serve the
+	// "IstioGateway" type. The default Istio ingress gateway is used when this
+	// is empty.
+	GatewaySelector map[string]string `json:"gatewaySelector,omitempty"`
+
+	// PodLabels select the ingress controller or gateway pods that route
+	// external traffic to the head node. When network policies are enabled,
+	// matching pods are allowed to reach the exposed head ports. Pods in the
+	// cluster namespace are selected unless NamespaceLabels are provided.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// NamespaceLabels select the namespaces of the pods matched by
+	// PodLabels.
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+
+	// CIDRs grant the exposed head ports ingress access from the source
+	// addresses of load balancer and node port traffic when network policies
+	// are enabled.
+	CIDRs []string `json:"cidrs,omitempty"`
+}
+
+// DashboardAuthConfig defines an OIDC authenticating proxy that is placed in
+// front of the cluster dashboard. The proxy runs as a sidecar in the head pod
+// and the head service routes dashboard traffic through it.
+type DashboardAuthConfig struct {
+	// Enabled injects the proxy into the head pod. The dashboard must be
+	// enabled.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Image of an oauth2-proxy compatible proxy.
+	Image *OCIImageDefinition `json:"image,omitempty"`
+
+	// Port on which the proxy listens.
+	Port int32 `json:"port,omitempty"`
+
+	// IssuerURL of the OIDC provider. HTTP issuers are only accepted when
+	// InsecureSkipVerify is true.
+	IssuerURL string `json:"issuerURL,omitempty"`
+
+	// ClientSecretRef references a secret in the cluster namespace that holds
+	// the OIDC client configuration under the "client-id", "client-secret"
+	// and "cookie-secret" keys.
+	ClientSecretRef *corev1.LocalObjectReference `json:"clientSecretRef,omitempty"`
+
+	// InsecureSkipVerify disables TLS and issuer verification so that a local
+	// OIDC stand-in can be used during testing. It must not be used in
+	// production.
+	InsecureSkipVerify *bool `json:"insecureSkipVerify,omitempty"`
+
+	// ExtraArgs passed to the proxy (e.g. "--email-domain=example.com").
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+
+	// Resources required by the proxy container.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// MonitorKind selects the Prometheus Operator object used to scrape cluster
+// metrics.
+type MonitorKind string
+
+const (
+	// MonitorKindServiceMonitor scrapes metrics ports published by the
+	// cluster services.
+	MonitorKindServiceMonitor MonitorKind = "ServiceMonitor"
+	// MonitorKindPodMonitor scrapes metrics ports published by the cluster
+	// pods.
+	MonitorKindPodMonitor MonitorKind = "PodMonitor"
+)
+
+// MonitoringConfig defines how cluster processes export Prometheus metrics
+// and how they are scraped.
+type MonitoringConfig struct {
+	// Enabled configures cluster processes to export metrics and publishes
+	// the metrics ports. A scrape object is created when the Prometheus
+	// Operator API is installed.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Port on which Ray processes export metrics. Spark serves metrics from
+	// its web UI port and ignores this field.
+	Port int32 `json:"port,omitempty"`
+
+	// Kind of scrape object created for the cluster.
+	Kind MonitorKind `json:"kind,omitempty"`
+
+	// Interval between scrapes (e.g. "30s"). The Prometheus default is used
+	// when this is blank.
+	Interval string `json:"interval,omitempty"`
+
+	// Labels added to the scrape object so that it is selected by a
+	// Prometheus instance.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// NamespaceLabels select the namespaces that Prometheus runs in. When
+	// network policies are enabled, pods in these namespaces are allowed to
+	// reach the metrics ports. The cluster namespace is used when this is
+	// empty.
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+
+	// PodLabels select the Prometheus pods within the monitoring namespaces.
+	// All pods are selected when this is empty. Either podLabels or
+	// namespaceLabels is required when network policies are enabled.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// IstioPrincipals are the peer identities of Prometheus granted access
+	// to the metrics ports by the Istio authorization policy. Workloads in
+	// the cluster namespace are granted access when this is empty.
+	IstioPrincipals []string `json:"istioPrincipals,omitempty"`
 }
 
 // IstioConfig defines operator configuration parameters.
@@ -28,6 +364,13 @@ type IstioConfig struct {
 	// authentication policy that takes precedence over a global and/or
 	// namespace-wide policy.
 	MutualTLSMode string `json:"istioMutualTLSMode,omitempty"`
+
+	// AuthorizedPrincipals are the peer identities (e.g.
+	// "cluster.local/ns/<namespace>/sa/<service-account>") granted access to
+	// the client server and dashboard ports by the authorization policy that
+	// mirrors the cluster network policies. Workloads within the cluster
+	// namespace are granted access when this is blank.
+	AuthorizedPrincipals []string `json:"istioAuthorizedPrincipals,omitempty"`
 }
 
 // OCIImageDefinition describes where and when to fetch a container image.
@@ -0,0 +1,704 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func validateAutoscalingBackend(backend AutoscalingBackend, supported []AutoscalingBackend, fldPath *field.Path) *field.Error {
+	if backend == "" {
+		return nil
+	}
+
+	var values []string
+	for _, valid := range supported {
+		if backend == valid {
+			return nil
+		}
+		values = append(values, string(valid))
+	}
+
+	return field.NotSupported(fldPath, backend, values)
+}
+
+// usesHPABackend returns true when workloads are scaled with a horizontal pod
+// autoscaler, which is the default when no backend is specified.
+func usesHPABackend(as *Autoscaling) bool {
+	return as != nil && (as.Backend == "" || as.Backend == AutoscalingBackendHPA)
+}
+
+func validateKEDAConfig(cfg *KEDAConfig, fldPath *field.Path) field.ErrorList {
+	if cfg == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	if cfg.PollingInterval != nil && *cfg.PollingInterval < 1 {
+		errs = append(errs, field.Invalid(
+			fldPath.Child("pollingInterval"),
+			cfg.PollingInterval,
+			"must be greater than or equal to 1",
+		))
+	}
+	if cfg.CooldownPeriod != nil && *cfg.CooldownPeriod < 0 {
+		errs = append(errs, field.Invalid(
+			fldPath.Child("cooldownPeriod"),
+			cfg.CooldownPeriod,
+			"must be greater than or equal to 0",
+		))
+	}
+
+	for idx, trigger := range cfg.Triggers {
+		trigPath := fldPath.Child(fmt.Sprintf("triggers[%d]", idx))
+
+		if trigger.Type == "" {
+			errs = append(errs, field.Required(trigPath.Child("type"), "cannot be blank"))
+		}
+		if len(trigger.Metadata) == 0 {
+			errs = append(errs, field.Required(trigPath.Child("metadata"), "cannot be empty"))
+		}
+	}
+
+	return errs
+}
+
+func validatePodDisruptionBudget(cfg PodDisruptionBudgetConfig, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if cfg.MinAvailable != nil && cfg.MaxUnavailable != nil {
+		errs = append(errs, field.Invalid(
+			fldPath.Child("maxUnavailable"),
+			cfg.MaxUnavailable,
+			"cannot be used with spec.podDisruptionBudget.minAvailable",
+		))
+	}
+	if err := validateIntOrPercent(cfg.MinAvailable, fldPath.Child("minAvailable")); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateIntOrPercent(cfg.MaxUnavailable, fldPath.Child("maxUnavailable")); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+func validateIntOrPercent(val *intstr.IntOrString, fldPath *field.Path) *field.Error {
+	if val == nil {
+		return nil
+	}
+
+	switch val.Type {
+	case intstr.Int:
+		if val.IntVal < 0 {
+			return field.Invalid(fldPath, val.IntVal, "must be greater than or equal to 0")
+		}
+	case intstr.String:
+		pct, err := strconv.Atoi(strings.TrimSuffix(val.StrVal, "%"))
+		if err != nil || !strings.HasSuffix(val.StrVal, "%") {
+			return field.Invalid(fldPath, val.StrVal, "must be an integer or a percentage (e.g. 50%)")
+		}
+		if pct < 0 || pct > 100 {
+			return field.Invalid(fldPath, val.StrVal, "must be between 0% and 100%")
+		}
+	}
+
+	return nil
+}
+
+func validateScheduling(cfg *SchedulingConfig, fldPath *field.Path) field.ErrorList {
+	if cfg == nil || cfg.Gang == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	gangPath := fldPath.Child("gang")
+
+	switch cfg.Gang.Flavor {
+	case "", PodGroupFlavorSchedulerPlugins, PodGroupFlavorVolcano:
+	default:
+		errs = append(errs, field.NotSupported(
+			gangPath.Child("flavor"),
+			cfg.Gang.Flavor,
+			[]string{string(PodGroupFlavorSchedulerPlugins), string(PodGroupFlavorVolcano)},
+		))
+	}
+
+	if cfg.Gang.ScheduleTimeoutSeconds != nil && *cfg.Gang.ScheduleTimeoutSeconds < 1 {
+		errs = append(errs, field.Invalid(
+			gangPath.Child("scheduleTimeoutSeconds"),
+			cfg.Gang.ScheduleTimeoutSeconds,
+			"must be greater than or equal to 1",
+		))
+	}
+
+	return errs
+}
+
+// validateExpose checks that routing is only requested for endpoints that
+// can be served by the chosen expose type. Client routing is limited to
+// clusters whose client protocol can pass through an HTTP proxy. Exposed
+// traffic must be admitted by a peer when network policies are enabled.
+func validateExpose(cfg *ExposeConfig, dashboardEnabled, httpClient, networkPolicy bool, fldPath *field.Path) field.ErrorList {
+	if cfg == nil {
+		return nil
+	}
+
+	errs := validateCIDRs(cfg.CIDRs, fldPath.Child("cidrs"))
+	if networkPolicy && len(cfg.PodLabels) == 0 && len(cfg.NamespaceLabels) == 0 && len(cfg.CIDRs) == 0 {
+		errs = append(errs, field.Required(fldPath,
+			"podLabels, namespaceLabels or cidrs must admit exposed traffic when network policies are enabled"))
+	}
+
+	switch cfg.Type {
+	case ExposeTypeLoadBalancer, ExposeTypeNodePort:
+		return errs
+	case ExposeTypeIngress, ExposeTypeIstioGateway:
+	default:
+		return append(errs, field.NotSupported(fldPath.Child("type"), cfg.Type, []string{
+			string(ExposeTypeIngress),
+			string(ExposeTypeIstioGateway),
+			string(ExposeTypeLoadBalancer),
+			string(ExposeTypeNodePort),
+		}))
+	}
+
+	if cfg.Dashboard == nil && cfg.Client == nil {
+		errs = append(errs, field.Required(fldPath, fmt.Sprintf("dashboard or client routing is required with type %q", cfg.Type)))
+	}
+
+	if cfg.Dashboard != nil {
+		dashPath := fldPath.Child("dashboard")
+		if !dashboardEnabled {
+			errs = append(errs, field.Forbidden(dashPath, "dashboard must be enabled"))
+		}
+		if err := validatePathPrefix(cfg.Dashboard.PathPrefix, dashPath.Child("pathPrefix")); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if cfg.Client != nil {
+		clientPath := fldPath.Child("client")
+		switch {
+		case !httpClient:
+			errs = append(errs, field.Forbidden(clientPath, fmt.Sprintf("client endpoint cannot be routed with type %q", cfg.Type)))
+		case cfg.Client.PathPrefix != "" && cfg.Client.PathPrefix != "/":
+			errs = append(errs, field.Invalid(clientPath.Child("pathPrefix"), cfg.Client.PathPrefix, `client endpoint must be served from "/"`))
+		}
+	}
+
+	return errs
+}
+
+func validatePathPrefix(prefix string, fldPath *field.Path) *field.Error {
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		return field.Invalid(fldPath, prefix, `must start with "/"`)
+	}
+
+	return nil
+}
+
+var (
+	dashboardAuthDefaultPort  int32 = 4180
+	dashboardAuthDefaultImage       = OCIImageDefinition{
+		Registry:   "quay.io",
+		Repository: "oauth2-proxy/oauth2-proxy",
+		Tag:        "v7.1.3",
+	}
+)
+
+// defaultDashboardAuth sets the proxy image and port when they are omitted.
+func defaultDashboardAuth(cfg *DashboardAuthConfig, log logr.Logger) {
+	if cfg.Image == nil {
+		log.Info("setting default dashboard auth image", "value", dashboardAuthDefaultImage)
+		image := dashboardAuthDefaultImage
+		cfg.Image = &image
+	}
+	if cfg.Port == 0 {
+		log.Info("setting default dashboard auth port", "value", dashboardAuthDefaultPort)
+		cfg.Port = dashboardAuthDefaultPort
+	}
+}
+
+// validateDashboardAuth checks that the authenticating proxy can reach the
+// dashboard and an OIDC provider. The proxy port is checked against the other
+// cluster ports by validateUniquePorts.
+func validateDashboardAuth(cfg *DashboardAuthConfig, dashboardEnabled bool, fldPath *field.Path) field.ErrorList {
+	if cfg == nil || cfg.Enabled == nil || !*cfg.Enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	if !dashboardEnabled {
+		errs = append(errs, field.Forbidden(fldPath.Child("enabled"), "dashboard must be enabled"))
+	}
+	insecure := cfg.InsecureSkipVerify != nil && *cfg.InsecureSkipVerify
+	if err := validateIssuerURL(cfg.IssuerURL, insecure, fldPath.Child("issuerURL")); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.ClientSecretRef == nil || cfg.ClientSecretRef.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("clientSecretRef", "name"), "OIDC client secret is required"))
+	}
+
+	return errs
+}
+
+func validateIssuerURL(issuer string, insecure bool, fldPath *field.Path) *field.Error {
+	if issuer == "" {
+		return field.Required(fldPath, "OIDC issuer URL is required")
+	}
+
+	u, err := url.Parse(issuer)
+	if err != nil || u.Host == "" {
+		return field.Invalid(fldPath, issuer, "must be an absolute URL")
+	}
+
+	switch {
+	case u.Scheme == "https":
+		return nil
+	case u.Scheme == "http" && insecure:
+		return nil
+	case u.Scheme == "http":
+		return field.Invalid(fldPath, issuer, "http issuers require insecureSkipVerify")
+	default:
+		return field.Invalid(fldPath, issuer, `scheme must be "https"`)
+	}
+}
+
+var (
+	monitoringDefaultKind = MonitorKindServiceMonitor
+	monitorKinds          = []string{string(MonitorKindServiceMonitor), string(MonitorKindPodMonitor)}
+)
+
+// defaultMonitoring sets the scrape object kind when it is omitted.
+func defaultMonitoring(cfg *MonitoringConfig, log logr.Logger) {
+	if cfg.Kind == "" {
+		log.Info("setting default monitoring kind", "value", monitoringDefaultKind)
+		cfg.Kind = monitoringDefaultKind
+	}
+}
+
+// validateMonitoring checks the scrape object kind and interval. Monitoring
+// pods must be selected explicitly when network policies are enabled.
+func validateMonitoring(cfg *MonitoringConfig, networkPolicy bool, fldPath *field.Path) field.ErrorList {
+	if cfg == nil || cfg.Enabled == nil || !*cfg.Enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	switch cfg.Kind {
+	case MonitorKindServiceMonitor, MonitorKindPodMonitor:
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("kind"), cfg.Kind, monitorKinds))
+	}
+	if cfg.Interval != "" {
+		if d, err := time.ParseDuration(cfg.Interval); err != nil || d <= 0 {
+			errs = append(errs, field.Invalid(fldPath.Child("interval"), cfg.Interval, "must be a positive duration"))
+		}
+	}
+	if networkPolicy && len(cfg.PodLabels) == 0 && len(cfg.NamespaceLabels) == 0 {
+		errs = append(errs, field.Required(fldPath,
+			"podLabels or namespaceLabels must select the monitoring pods when network policies are enabled"))
+	}
+
+	return errs
+}
+
+// validateNetworkPolicyCIDRs checks that ingress and egress IP blocks are
+// valid CIDR notation.
+func validateNetworkPolicyCIDRs(clientServer, dashboard []string, egress *NetworkPolicyEgress, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validateCIDRs(clientServer, fldPath.Child("clientServerCIDRs"))...)
+	errs = append(errs, validateCIDRs(dashboard, fldPath.Child("dashboardCIDRs"))...)
+	if egress != nil {
+		errs = append(errs, validateCIDRs(egress.CIDRs, fldPath.Child("egress", "cidrs"))...)
+	}
+
+	return errs
+}
+
+func validateCIDRs(cidrs []string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for idx, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Index(idx), cidr, "must be a valid CIDR"))
+		}
+	}
+
+	return errs
+}
+
+// portField is a port opened by cluster pods and the field that sets it.
+type portField struct {
+	port int32
+	path *field.Path
+}
+
+// validateUniquePorts checks that every port is only assigned to one field
+// since processes sharing a pod cannot bind the same port.
+func validateUniquePorts(ports []portField) field.ErrorList {
+	var errs field.ErrorList
+
+	seen := make(map[int32]*field.Path, len(ports))
+	for _, pf := range ports {
+		if first, ok := seen[pf.port]; ok {
+			errs = append(errs, field.Invalid(pf.path, pf.port, fmt.Sprintf("must not overlap with %s", first)))
+			continue
+		}
+		seen[pf.port] = pf.path
+	}
+
+	return errs
+}
+
+// reservedLabelKeys are the pod labels set by the operator. Node labels with
+// these keys would be overridden when the pod labels are merged.
+var reservedLabelKeys = sets.NewString(
+	"app.kubernetes.io/name",
+	"app.kubernetes.io/instance",
+	"app.kubernetes.io/version",
+	"app.kubernetes.io/component",
+	"app.kubernetes.io/managed-by",
+)
+
+// validateNodeLabels checks that the labels are valid and do not override the
+// labels set by the operator.
+func validateNodeLabels(labels map[string]string, fldPath *field.Path) field.ErrorList {
+	errs := metav1validation.ValidateLabels(labels, fldPath)
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if reservedLabelKeys.Has(key) {
+			errs = append(errs, field.Forbidden(fldPath.Key(key), "is managed by the operator"))
+		}
+	}
+
+	return errs
+}
+
+// validateEnvVars checks that every env var is only declared once.
+func validateEnvVars(envVars []corev1.EnvVar, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	names := sets.NewString()
+	for idx, env := range envVars {
+		if names.Has(env.Name) {
+			errs = append(errs, field.Duplicate(fldPath.Index(idx).Child("name"), env.Name))
+		}
+		names.Insert(env.Name)
+	}
+
+	return errs
+}
+
+// nodeVolumes are the volumes and volume mounts of a cluster node.
+type nodeVolumes struct {
+	path *field.Path
+
+	volumes []corev1.Volume
+	mounts  []corev1.VolumeMount
+
+	// claims are the names of the volume claim templates declared in the
+	// claimsField of the node.
+	claims      []string
+	claimsField string
+
+	// operatorVolumes are the volumes added to node pods by the operator.
+	// Mounts may reference them but they cannot be declared again.
+	operatorVolumes []string
+}
+
+// validate checks that volume names are unique and that every mount
+// references a declared volume and uses a distinct mount path.
+func (n nodeVolumes) validate() field.ErrorList {
+	var errs field.ErrorList
+
+	names := sets.NewString()
+	declare := func(name string, fldPath *field.Path) {
+		switch {
+		case containsString(n.operatorVolumes, name):
+			errs = append(errs, field.Invalid(fldPath, name, "conflicts with a volume added by the operator"))
+		case names.Has(name):
+			errs = append(errs, field.Duplicate(fldPath, name))
+		}
+		names.Insert(name)
+	}
+
+	for idx, vol := range n.volumes {
+		declare(vol.Name, n.path.Child("volumes").Index(idx).Child("name"))
+	}
+	for idx, name := range n.claims {
+		declare(name, n.path.Child(n.claimsField).Index(idx).Child("name"))
+	}
+	names.Insert(n.operatorVolumes...)
+
+	mountPaths := sets.NewString()
+	for idx, mount := range n.mounts {
+		fldPath := n.path.Child("volumeMounts").Index(idx)
+
+		if !names.Has(mount.Name) {
+			errs = append(errs, field.NotFound(fldPath.Child("name"), mount.Name))
+		}
+		if mountPaths.Has(mount.MountPath) {
+			errs = append(errs, field.Duplicate(fldPath.Child("mountPath"), mount.MountPath))
+		}
+		mountPaths.Insert(mount.MountPath)
+	}
+
+	return errs
+}
+
+// validateWorkerReplicasInRange checks that the initial number of workers can
+// be reached by the autoscaler.
+func validateWorkerReplicasInRange(replicas *int32, as *Autoscaling, fldPath *field.Path) *field.Error {
+	if replicas == nil || as == nil {
+		return nil
+	}
+
+	if as.MinReplicas != nil && *replicas < *as.MinReplicas {
+		return field.Invalid(fldPath, *replicas, "must be greater than or equal to spec.autoscaling.minReplicas")
+	}
+	if as.MaxReplicas > 0 && *replicas > as.MaxReplicas {
+		return field.Invalid(fldPath, *replicas, "must be less than or equal to spec.autoscaling.maxReplicas")
+	}
+
+	return nil
+}
+
+// validateSecurityProfile checks that the profile is supported and that the
+// pod security context does not contradict it.
+func validateSecurityProfile(profile SecurityProfile, podCtx *corev1.PodSecurityContext, fldPath *field.Path) field.ErrorList {
+	switch profile {
+	case "", SecurityProfileNone:
+		return nil
+	case SecurityProfileBaseline, SecurityProfileRestricted:
+	default:
+		return field.ErrorList{field.NotSupported(fldPath.Child("securityProfile"), profile, []string{
+			string(SecurityProfileNone),
+			string(SecurityProfileBaseline),
+			string(SecurityProfileRestricted),
+		})}
+	}
+	if podCtx == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	ctxPath := fldPath.Child("podSecurityContext")
+
+	if sp := podCtx.SeccompProfile; sp != nil && sp.Type == corev1.SeccompProfileTypeUnconfined {
+		errs = append(errs, field.Invalid(ctxPath.Child("seccompProfile", "type"), sp.Type, profileMessage(profile)))
+	}
+	if profile == SecurityProfileRestricted {
+		if podCtx.RunAsNonRoot != nil && !*podCtx.RunAsNonRoot {
+			errs = append(errs, field.Invalid(ctxPath.Child("runAsNonRoot"), *podCtx.RunAsNonRoot, profileMessage(profile)))
+		}
+		if podCtx.RunAsUser != nil && *podCtx.RunAsUser == 0 {
+			errs = append(errs, field.Invalid(ctxPath.Child("runAsUser"), *podCtx.RunAsUser, profileMessage(profile)))
+		}
+	}
+
+	return errs
+}
+
+// validateContainerSecurity checks that the security context overrides on
+// user-provided containers remain compatible with the profile.
+func validateContainerSecurity(profile SecurityProfile, containers []corev1.Container, fldPath *field.Path) field.ErrorList {
+	if profile != SecurityProfileBaseline && profile != SecurityProfileRestricted {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for idx, container := range containers {
+		sc := container.SecurityContext
+		if sc == nil {
+			continue
+		}
+		scPath := fldPath.Index(idx).Child("securityContext")
+
+		if sc.Privileged != nil && *sc.Privileged {
+			errs = append(errs, field.Invalid(scPath.Child("privileged"), *sc.Privileged, profileMessage(profile)))
+		}
+		if sc.AllowPrivilegeEscalation != nil && *sc.AllowPrivilegeEscalation {
+			errs = append(errs, field.Invalid(
+				scPath.Child("allowPrivilegeEscalation"), *sc.AllowPrivilegeEscalation, profileMessage(profile)),
+			)
+		}
+		if sc.Capabilities != nil {
+			for capIdx, capability := range sc.Capabilities.Add {
+				if capability != "NET_BIND_SERVICE" {
+					errs = append(errs, field.Invalid(scPath.Child("capabilities", "add").Index(capIdx), capability, profileMessage(profile)))
+				}
+			}
+		}
+		if sp := sc.SeccompProfile; sp != nil && sp.Type == corev1.SeccompProfileTypeUnconfined {
+			errs = append(errs, field.Invalid(scPath.Child("seccompProfile", "type"), sp.Type, profileMessage(profile)))
+		}
+		if profile == SecurityProfileRestricted {
+			errs = append(errs, validateRestrictedContainer(sc, scPath)...)
+		}
+	}
+
+	return errs
+}
+
+func validateRestrictedContainer(sc *corev1.SecurityContext, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	msg := profileMessage(SecurityProfileRestricted)
+
+	if sc.RunAsNonRoot != nil && !*sc.RunAsNonRoot {
+		errs = append(errs, field.Invalid(fldPath.Child("runAsNonRoot"), *sc.RunAsNonRoot, msg))
+	}
+	if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("runAsUser"), *sc.RunAsUser, msg))
+	}
+	if sc.ReadOnlyRootFilesystem != nil && !*sc.ReadOnlyRootFilesystem {
+		errs = append(errs, field.Invalid(fldPath.Child("readOnlyRootFilesystem"), *sc.ReadOnlyRootFilesystem, msg))
+	}
+
+	return errs
+}
+
+func profileMessage(profile SecurityProfile) string {
+	return fmt.Sprintf("incompatible with the %q security profile", profile)
+}
+
+func validateControllerClass(class string, fldPath *field.Path) field.ErrorList {
+	if class == "" {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for _, msg := range validation.IsDNS1123Label(class) {
+		errs = append(errs, field.Invalid(fldPath, class, msg))
+	}
+
+	return errs
+}
+
+// registerWebhooks registers the defaulting and validating webhooks of a
+// cluster kind. Both consult the compute policies of the cluster namespace
+// and clusters are defaulted using the operator defaults of the source.
+// Requests for clusters that are not selected are allowed untouched so that
+// they are left to the operator instance managing them.
+func registerWebhooks(
+	mgr ctrl.Manager,
+	obj computePolicyObject,
+	selector ControllerSelector,
+	defaults ClusterDefaultsSource,
+) error {
+	gvk, err := apiutil.GVKForObject(obj, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	suffix := strings.Join([]string{
+		strings.ReplaceAll(gvk.Group, ".", "-"),
+		gvk.Version,
+		strings.ToLower(gvk.Kind),
+	}, "-")
+
+	defaulter := &policyDefaultingHandler{client: mgr.GetAPIReader(), defaults: defaults, obj: obj}
+	validator := &policyValidatingHandler{client: mgr.GetAPIReader(), obj: obj}
+
+	server := mgr.GetWebhookServer()
+	server.Register("/mutate-"+suffix, selectedWebhook(&admission.Webhook{Handler: defaulter}, selector))
+	server.Register("/validate-"+suffix, selectedWebhook(&admission.Webhook{Handler: validator}, selector))
+
+	return nil
+}
+
+func selectedWebhook(wh *admission.Webhook, selector ControllerSelector) *admission.Webhook {
+	wh.Handler = &selectedHandler{Handler: wh.Handler, selector: selector}
+	return wh
+}
+
+//+kubebuilder:object:generate=false
+
+// admissionObject decodes the fields of an admission request object that
+// select the operator instance managing it.
+type admissionObject struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec struct {
+		ControllerClass string `json:"controllerClass,omitempty"`
+	} `json:"spec,omitempty"`
+}
+
+func (o *admissionObject) GetControllerClass() string {
+	return o.Spec.ControllerClass
+}
+
+// selectedHandler only passes admission requests for selected clusters to
+// the wrapped handler. Invalid controller classes are rejected by every
+// operator instance. Other clusters are allowed with a warning that they are
+// left untouched, except that new clusters of the class run by the instance
+// are rejected when its label selector filters them out. One instance runs
+// each class, so no instance would ever manage them.
+type selectedHandler struct {
+	admission.Handler
+	selector ControllerSelector
+}
+
+func (h *selectedHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	raw := req.Object.Raw
+	if len(raw) == 0 {
+		raw = req.OldObject.Raw
+	}
+
+	obj := &admissionObject{}
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// clusters with an invalid class would never be selected by any instance
+	classPath := field.NewPath("spec").Child("controllerClass")
+	if errs := validateControllerClass(obj.Spec.ControllerClass, classPath); len(errs) > 0 {
+		return admission.Denied(errs.ToAggregate().Error())
+	}
+	if !h.selector.Matches(obj) {
+		class := controllerClassOrDefault(obj.Spec.ControllerClass)
+		if req.Operation == admissionv1.Create && class == controllerClassOrDefault(h.selector.Class) {
+			return admission.Denied(fmt.Sprintf("cluster labels are not selected by the operator running controller class %q", class))
+		}
+
+		return admission.Allowed("cluster is not selected by this operator instance").WithWarnings(fmt.Sprintf(
+			"cluster is only defaulted and validated by an operator running controller class %q", class))
+	}
+
+	return h.Handler.Handle(ctx, req)
+}
+
+// InjectDecoder passes the admission decoder to the wrapped handler.
+func (h *selectedHandler) InjectDecoder(d *admission.Decoder) error {
+	_, err := admission.InjectDecoderInto(d, h.Handler)
+	return err
+}
@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestSelectedHandler(t *testing.T) {
+	wrapped := admission.HandlerFunc(func(context.Context, admission.Request) admission.Response {
+		return admission.Allowed("handled")
+	})
+	h := &selectedHandler{
+		Handler:  wrapped,
+		selector: ControllerSelector{Labels: labels.SelectorFromSet(labels.Set{"team": "ml"})},
+	}
+	request := func(op admissionv1.Operation, raw string) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			Object:    runtime.RawExtension{Raw: []byte(raw)},
+		}}
+	}
+
+	t.Run("selected", func(t *testing.T) {
+		resp := h.Handle(context.Background(), request(admissionv1.Create, `{"metadata":{"labels":{"team":"ml"}}}`))
+		assert.True(t, resp.Allowed)
+		assert.EqualValues(t, "handled", resp.Result.Reason)
+	})
+
+	t.Run("another_class", func(t *testing.T) {
+		resp := h.Handle(context.Background(), request(admissionv1.Create, `{"spec":{"controllerClass":"experimental"}}`))
+		assert.True(t, resp.Allowed)
+		assert.Len(t, resp.Warnings, 1)
+	})
+
+	t.Run("unselected_labels", func(t *testing.T) {
+		resp := h.Handle(context.Background(), request(admissionv1.Create, `{"metadata":{"labels":{"team":"web"}}}`))
+		assert.False(t, resp.Allowed)
+
+		resp = h.Handle(context.Background(), request(admissionv1.Update, `{"metadata":{"labels":{"team":"web"}}}`))
+		assert.True(t, resp.Allowed, "existing clusters should remain editable")
+		assert.Len(t, resp.Warnings, 1)
+	})
+
+	t.Run("invalid_class", func(t *testing.T) {
+		resp := h.Handle(context.Background(), request(admissionv1.Create, `{"spec":{"controllerClass":"Not_Valid"}}`))
+		assert.False(t, resp.Allowed)
+	})
+}
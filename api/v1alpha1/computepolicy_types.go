@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComputePolicyDefaults are applied to clusters that do not set the
+// corresponding fields. They take precedence over the operator defaults.
+type ComputePolicyDefaults struct {
+	// RayImage used to launch the nodes of ray clusters.
+	RayImage *OCIImageDefinition `json:"rayImage,omitempty"`
+
+	// SparkImage used to launch the nodes of spark clusters.
+	SparkImage *OCIImageDefinition `json:"sparkImage,omitempty"`
+
+	// Resources requested by every cluster node.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector applied to every cluster node.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations applied to every cluster node.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// ComputePolicyLimits are enforced when clusters are created or their spec
+// is updated.
+type ComputePolicyLimits struct {
+	// MaxWorkers is the maximum number of worker replicas, including the
+	// upper bound of autoscaling.
+	MaxWorkers *int32 `json:"maxWorkers,omitempty"`
+
+	// MaxCPUPerNode is the maximum cpu request and limit of a cluster node.
+	MaxCPUPerNode *resource.Quantity `json:"maxCPUPerNode,omitempty"`
+
+	// MaxMemoryPerNode is the maximum memory request and limit of a cluster
+	// node.
+	MaxMemoryPerNode *resource.Quantity `json:"maxMemoryPerNode,omitempty"`
+
+	// AllowedRegistries that cluster images may be pulled from. Images
+	// without a registry are pulled from "docker.io". Every registry is
+	// allowed when this is empty.
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+
+	// RequireNetworkPolicy prevents clusters from disabling their network
+	// policies.
+	RequireNetworkPolicy bool `json:"requireNetworkPolicy,omitempty"`
+}
+
+// ComputePolicySpec defines the defaults and limits that platform admins
+// apply to compute clusters.
+type ComputePolicySpec struct {
+	// Defaults applied to clusters that do not set the corresponding fields.
+	Defaults ComputePolicyDefaults `json:"defaults,omitempty"`
+
+	// Limits enforced on cluster specs.
+	Limits ComputePolicyLimits `json:"limits,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=cpol
+
+// ComputePolicy is the Schema for the computepolicies API. Policies apply to
+// every cluster in their namespace and their defaults take precedence over
+// the ones of cluster compute policies.
+type ComputePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ComputePolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ComputePolicyList contains a list of ComputePolicy resources.
+type ComputePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComputePolicy `json:"items"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,shortName=ccpol
+
+// ClusterComputePolicy is the Schema for the clustercomputepolicies API.
+// Policies apply to every cluster in the Kubernetes cluster.
+type ClusterComputePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ComputePolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterComputePolicyList contains a list of ClusterComputePolicy resources.
+type ClusterComputePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterComputePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&ComputePolicy{},
+		&ComputePolicyList{},
+		&ClusterComputePolicy{},
+		&ClusterComputePolicyList{},
+	)
+}
@@ -0,0 +1,353 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// defaultImageRegistry is the registry of images that do not specify one.
+const defaultImageRegistry = "docker.io"
+
+// computePolicyObject is implemented by cluster kinds that are defaulted and
+// validated using the compute policies of their namespace.
+type computePolicyObject interface {
+	admission.Defaulter
+	admission.Validator
+
+	applyClusterDefaults(defaults ClusterDefaults)
+	applyComputePolicyDefaults(defaults ComputePolicyDefaults)
+	validateComputePolicies(policies []namedComputePolicy) error
+}
+
+// namedComputePolicy is a compute policy spec along with a reference to the
+// policy that is used in validation errors.
+type namedComputePolicy struct {
+	ref  string
+	spec ComputePolicySpec
+}
+
+//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=computepolicies;clustercomputepolicies,verbs=list
+
+// listComputePolicies returns the policies that apply to clusters in the
+// given namespace. Namespaced policies come first and policies of the same
+// kind are sorted by name. No policies are returned when the policy APIs are
+// not installed.
+func listComputePolicies(ctx context.Context, c client.Reader, namespace string) ([]namedComputePolicy, error) {
+	nsPolicies := &ComputePolicyList{}
+	if err := c.List(ctx, nsPolicies, client.InNamespace(namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot list compute policies: %w", err)
+	}
+
+	clusterPolicies := &ClusterComputePolicyList{}
+	if err := c.List(ctx, clusterPolicies); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot list cluster compute policies: %w", err)
+	}
+
+	sort.Slice(nsPolicies.Items, func(i, j int) bool {
+		return nsPolicies.Items[i].Name < nsPolicies.Items[j].Name
+	})
+	sort.Slice(clusterPolicies.Items, func(i, j int) bool {
+		return clusterPolicies.Items[i].Name < clusterPolicies.Items[j].Name
+	})
+
+	var policies []namedComputePolicy
+	for _, p := range nsPolicies.Items {
+		policies = append(policies, namedComputePolicy{
+			ref:  fmt.Sprintf("ComputePolicy %s/%s", p.Namespace, p.Name),
+			spec: p.Spec,
+		})
+	}
+	for _, p := range clusterPolicies.Items {
+		policies = append(policies, namedComputePolicy{
+			ref:  fmt.Sprintf("ClusterComputePolicy %s", p.Name),
+			spec: p.Spec,
+		})
+	}
+
+	return policies, nil
+}
+
+// mergeComputePolicyDefaults combines the defaults of several policies. Each
+// field is taken from the first policy that sets it.
+func mergeComputePolicyDefaults(policies []namedComputePolicy) ComputePolicyDefaults {
+	var merged ComputePolicyDefaults
+	for _, p := range policies {
+		d := p.spec.Defaults.DeepCopy()
+
+		if merged.RayImage == nil {
+			merged.RayImage = d.RayImage
+		}
+		if merged.SparkImage == nil {
+			merged.SparkImage = d.SparkImage
+		}
+		if merged.Resources == nil {
+			merged.Resources = d.Resources
+		}
+		if merged.NodeSelector == nil {
+			merged.NodeSelector = d.NodeSelector
+		}
+		if merged.Tolerations == nil {
+			merged.Tolerations = d.Tolerations
+		}
+	}
+
+	return merged
+}
+
+// applyNodePolicyDefaults sets the node selector, tolerations and resources
+// of a cluster node when they are empty.
+func applyNodePolicyDefaults(
+	defaults ComputePolicyDefaults,
+	nodeSelector *map[string]string,
+	tolerations *[]corev1.Toleration,
+	resources *corev1.ResourceRequirements,
+) {
+	if *nodeSelector == nil && defaults.NodeSelector != nil {
+		*nodeSelector = copyStringMap(defaults.NodeSelector)
+	}
+	if *tolerations == nil && defaults.Tolerations != nil {
+		*tolerations = append([]corev1.Toleration(nil), defaults.Tolerations...)
+	}
+	if resources.Requests == nil && resources.Limits == nil && defaults.Resources != nil {
+		*resources = *defaults.Resources.DeepCopy()
+	}
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// policyNode describes the resources of a cluster node that are limited by
+// compute policies.
+type policyNode struct {
+	path      *field.Path
+	resources corev1.ResourceRequirements
+}
+
+// policySubject describes the parts of a cluster that are limited by compute
+// policies.
+type policySubject struct {
+	image         *OCIImageDefinition
+	workers       *int32
+	autoscaling   *Autoscaling
+	nodes         []policyNode
+	networkPolicy *bool
+}
+
+// validate checks the subject against the limits of every policy.
+func (s policySubject) validate(policies []namedComputePolicy) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	for _, p := range policies {
+		limits := p.spec.Limits
+
+		if max := limits.MaxWorkers; max != nil {
+			msg := fmt.Sprintf("must be less than or equal to %d as required by %s", *max, p.ref)
+			if s.workers != nil && *s.workers > *max {
+				errs = append(errs, field.Invalid(specPath.Child("worker", "replicas"), *s.workers, msg))
+			}
+			if s.autoscaling != nil && s.autoscaling.MaxReplicas > *max {
+				errs = append(errs, field.Invalid(specPath.Child("autoscaling", "maxReplicas"), s.autoscaling.MaxReplicas, msg))
+			}
+		}
+
+		for _, node := range s.nodes {
+			errs = append(errs, validateNodeLimit(node, corev1.ResourceCPU, limits.MaxCPUPerNode, p.ref)...)
+			errs = append(errs, validateNodeLimit(node, corev1.ResourceMemory, limits.MaxMemoryPerNode, p.ref)...)
+		}
+
+		if len(limits.AllowedRegistries) > 0 && s.image != nil {
+			registry := s.image.Registry
+			if registry == "" {
+				registry = defaultImageRegistry
+			}
+			if !containsString(limits.AllowedRegistries, registry) {
+				errs = append(errs, field.Forbidden(
+					specPath.Child("image", "registry"),
+					fmt.Sprintf("registry %q is not allowed by %s", registry, p.ref),
+				))
+			}
+		}
+
+		if limits.RequireNetworkPolicy && s.networkPolicy != nil && !*s.networkPolicy {
+			errs = append(errs, field.Forbidden(
+				specPath.Child("networkPolicy", "enabled"),
+				fmt.Sprintf("network policies are required by %s", p.ref),
+			))
+		}
+	}
+
+	return errs
+}
+
+func validateNodeLimit(node policyNode, name corev1.ResourceName, max *resource.Quantity, ref string) field.ErrorList {
+	if max == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for _, list := range []struct {
+		fld       string
+		resources corev1.ResourceList
+	}{
+		{fld: "requests", resources: node.resources.Requests},
+		{fld: "limits", resources: node.resources.Limits},
+	} {
+		if qty, ok := list.resources[name]; ok && qty.Cmp(*max) > 0 {
+			errs = append(errs, field.Invalid(
+				node.path.Child("resources", list.fld, string(name)),
+				qty.String(),
+				fmt.Sprintf("must be less than or equal to %s as required by %s", max.String(), ref),
+			))
+		}
+	}
+
+	return errs
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// policyDefaultingHandler applies the defaults of the compute policies that
+// apply to a cluster before the operator defaults. The built-in operator
+// defaults are used when there is no defaults source.
+type policyDefaultingHandler struct {
+	client   client.Reader
+	defaults ClusterDefaultsSource
+	decoder  *admission.Decoder
+	obj      computePolicyObject
+}
+
+func (h *policyDefaultingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := h.obj.DeepCopyObject().(computePolicyObject)
+	if err := h.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	policies, err := listComputePolicies(ctx, h.client, req.Namespace)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	obj.applyComputePolicyDefaults(mergeComputePolicyDefaults(policies))
+	if h.defaults != nil {
+		obj.applyClusterDefaults(h.defaults.ClusterDefaults())
+	} else {
+		obj.Default()
+	}
+
+	marshalled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshalled)
+}
+
+// InjectDecoder injects the admission decoder.
+func (h *policyDefaultingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// policyValidatingHandler validates clusters against the limits of the
+// compute policies that apply to them. Limits are only enforced when a
+// cluster is created or its spec is updated so that policy changes never
+// block metadata updates, e.g. the removal of finalizers.
+type policyValidatingHandler struct {
+	client  client.Reader
+	decoder *admission.Decoder
+	obj     computePolicyObject
+}
+
+func (h *policyValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	obj := h.obj.DeepCopyObject().(computePolicyObject)
+	if err := h.decoder.DecodeRaw(req.Object, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	enforce := req.Operation == admissionv1.Create
+	if !enforce {
+		changed, err := specChanged(req)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		enforce = changed
+	}
+
+	var policies []namedComputePolicy
+	if enforce {
+		var err error
+		if policies, err = listComputePolicies(ctx, h.client, req.Namespace); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	}
+
+	if err := obj.validateComputePolicies(policies); err != nil {
+		var apiStatus apierrors.APIStatus
+		if errors.As(err, &apiStatus) {
+			status := apiStatus.Status()
+			return admission.Response{AdmissionResponse: admissionv1.AdmissionResponse{Result: &status}}
+		}
+		return admission.Denied(err.Error())
+	}
+
+	return admission.Allowed("")
+}
+
+// InjectDecoder injects the admission decoder.
+func (h *policyValidatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// specChanged returns true when an update request modifies the spec of an
+// object.
+func specChanged(req admission.Request) (bool, error) {
+	var obj, old struct {
+		Spec map[string]interface{} `json:"spec"`
+	}
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(req.OldObject.Raw, &old); err != nil {
+		return false, err
+	}
+
+	return !equality.Semantic.DeepEqual(obj.Spec, old.Spec), nil
+}
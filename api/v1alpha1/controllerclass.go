@@ -0,0 +1,61 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DefaultControllerClass is the controller class of clusters that do not
+// specify one.
+const DefaultControllerClass = "default"
+
+// controllerClassObject is implemented by the cluster kinds that can be
+// assigned to an operator instance.
+type controllerClassObject interface {
+	GetControllerClass() string
+}
+
+// GetControllerClass returns the controller class of the cluster.
+func (r *RayCluster) GetControllerClass() string {
+	return r.Spec.ControllerClass
+}
+
+// GetControllerClass returns the controller class of the cluster.
+func (r *SparkCluster) GetControllerClass() string {
+	return r.Spec.ControllerClass
+}
+
+//+kubebuilder:object:generate=false
+
+// ControllerSelector selects the clusters that are managed by an operator
+// instance when several instances share a Kubernetes cluster.
+type ControllerSelector struct {
+	// Class of the selected clusters. DefaultControllerClass is used when
+	// this is blank.
+	Class string
+	// Labels that selected clusters must match. Labels are not considered
+	// when this is nil.
+	Labels labels.Selector
+}
+
+// Matches returns true when obj is managed by the operator instance. Objects
+// without a controller class are matched against DefaultControllerClass.
+func (s ControllerSelector) Matches(obj metav1.Object) bool {
+	if s.Labels != nil && !s.Labels.Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+
+	var class string
+	if cc, ok := obj.(controllerClassObject); ok {
+		class = cc.GetControllerClass()
+	}
+
+	return controllerClassOrDefault(class) == controllerClassOrDefault(s.Class)
+}
+
+func controllerClassOrDefault(class string) string {
+	if class == "" {
+		return DefaultControllerClass
+	}
+	return class
+}
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -71,6 +72,85 @@ type RayClusterNetworkPolicy struct {
 	// DashboardLabels defines the pod selector clause used to grant ingress
 	// access to the head dashboard port.
 	DashboardLabels map[string]string `json:"dashboardLabels,omitempty"`
+
+	// ClientServerNamespaceLabels defines the namespace selector clause used
+	// to grant ingress access to the head client server port from other
+	// namespaces. Only pods matching ClientServerLabels within the selected
+	// namespaces are granted access.
+	ClientServerNamespaceLabels map[string]string `json:"clientServerNamespaceLabels,omitempty"`
+
+	// DashboardNamespaceLabels defines the namespace selector clause used to
+	// grant ingress access to the head dashboard port from other namespaces.
+	// Only pods matching DashboardLabels within the selected namespaces are
+	// granted access.
+	DashboardNamespaceLabels map[string]string `json:"dashboardNamespaceLabels,omitempty"`
+
+	// ClientServerCIDRs grants ingress access to the head client server port
+	// from the provided IP blocks.
+	ClientServerCIDRs []string `json:"clientServerCIDRs,omitempty"`
+
+	// DashboardCIDRs grants ingress access to the head dashboard port from
+	// the provided IP blocks.
+	DashboardCIDRs []string `json:"dashboardCIDRs,omitempty"`
+
+	// IngressRules are additional rules that grant ingress access to all
+	// cluster nodes.
+	IngressRules []networkingv1.NetworkPolicyIngressRule `json:"ingressRules,omitempty"`
+
+	// Egress restricts the outbound traffic permitted from cluster nodes.
+	Egress *NetworkPolicyEgress `json:"egress,omitempty"`
+}
+
+// RayTLSIssuer selects how the cluster certificate authority is issued.
+type RayTLSIssuer string
+
+const (
+	// RayTLSIssuerOperator generates the certificate authority in-process and
+	// stores it in a Secret owned by the cluster.
+	RayTLSIssuerOperator RayTLSIssuer = "operator"
+	// RayTLSIssuerCertManager requests the certificate authority from a
+	// cert-manager issuer. The cert-manager API must be installed.
+	RayTLSIssuerCertManager RayTLSIssuer = "cert-manager"
+)
+
+// CertificateIssuerRef references a cert-manager Issuer or ClusterIssuer.
+type CertificateIssuerRef struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+
+	// Kind of the issuer, either "Issuer" or "ClusterIssuer". Defaults to
+	// "Issuer" when blank.
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer. Defaults to "cert-manager.io" when blank.
+	Group string `json:"group,omitempty"`
+}
+
+// RayClusterTLS defines the TLS configuration used to secure the gRPC
+// channels between ray processes.
+type RayClusterTLS struct {
+	// Enabled turns on TLS for all ray gRPC channels. The operator signs a
+	// node certificate for the cluster pod IPs with the cluster certificate
+	// authority, which is never mounted into pods. Pods wait in an init
+	// container until the certificate includes their IP, so the cluster image
+	// must provide /bin/sh and grep.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Issuer of the cluster certificate authority.
+	Issuer RayTLSIssuer `json:"issuer,omitempty"`
+
+	// IssuerRef is the cert-manager issuer used to sign the cluster
+	// certificate authority. Only used by the "cert-manager" issuer.
+	IssuerRef *CertificateIssuerRef `json:"issuerRef,omitempty"`
+
+	// Duration is the lifetime of the cluster certificate authority and the
+	// node certificates signed by it.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is the amount of time before expiry that the certificate
+	// authority is reissued. Cluster pods are restarted with new node
+	// certificates after every renewal.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
 }
 
 // RayClusterSpec defines the desired state of a RayCluster resource.
@@ -89,6 +169,34 @@ type RayClusterSpec struct {
 	// access to cluster nodes.
 	NetworkPolicy RayClusterNetworkPolicy `json:"networkPolicy,omitempty"`
 
+	// PodDisruptionBudget parameters that protect cluster nodes from voluntary
+	// evictions.
+	PodDisruptionBudget PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+
+	// Scheduling parameters used to dispatch cluster pods to nodes.
+	Scheduling *SchedulingConfig `json:"scheduling,omitempty"`
+
+	// ControllerClass assigns the cluster to the operator instances started
+	// with a matching controller class. Clusters without a class belong to
+	// the "default" class.
+	ControllerClass string `json:"controllerClass,omitempty"`
+
+	// Expose parameters used to reach the dashboard and client endpoints from
+	// outside of the Kubernetes cluster.
+	Expose *ExposeConfig `json:"expose,omitempty"`
+
+	// DashboardAuth parameters used to require OIDC authentication before
+	// the dashboard can be reached through the head service.
+	DashboardAuth *DashboardAuthConfig `json:"dashboardAuth,omitempty"`
+
+	// Monitoring parameters used to export and scrape workload metrics.
+	Monitoring *MonitoringConfig `json:"monitoring,omitempty"`
+
+	// QueueName references a ClusterQueue in the same namespace that limits
+	// the resources requested by the clusters admitted through it. The
+	// headroom of the namespace resource quotas is used when this is blank.
+	QueueName string `json:"queueName,omitempty"`
+
 	// Port is the port of the head ray process.
 	Port int32 `json:"port,omitempty"`
 
@@ -122,12 +230,23 @@ type RayClusterSpec struct {
 	EnableDashboard *bool `json:"enableDashboard,omitempty"`
 
 	// PodSecurityPolicy name can be provided to govern execution of the ray
-	// processes within pods.
+	// processes within pods. This is only used by the "psp" pod security
+	// backend.
 	PodSecurityPolicy string `json:"podSecurityPolicy,omitempty"`
 
+	// SecurityContextConstraints name can be provided to govern execution of
+	// the ray processes within pods on OpenShift. This is only used by the
+	// "scc" pod security backend.
+	SecurityContextConstraints string `json:"securityContextConstraints,omitempty"`
+
 	// PodSecurityContext added to every ray pod.
 	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
 
+	// SecurityProfile applies a hardened security context to every ray
+	// container. Security context fields set on containers take precedence
+	// over the values provided by the profile.
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
 	// ServiceAccountName will disable the creation of a dedicated cluster
 	// service account. The service account referenced by the provided name
 	// will be used instead.
@@ -136,6 +255,10 @@ type RayClusterSpec struct {
 	// EnvVars added to all every ray pod container.
 	EnvVars []corev1.EnvVar `json:"envVars,omitempty"`
 
+	// TLS parameters used to encrypt and authenticate traffic between ray
+	// processes.
+	TLS *RayClusterTLS `json:"tls,omitempty"`
+
 	// IstioConfig parameters for ray clusters.
 	IstioConfig `json:",inline"`
 
@@ -156,6 +279,34 @@ type RayClusterStatus struct {
 
 	// WorkerSelector is the scale.status.selector subresource field.
 	WorkerSelector string `json:"workerSelector,omitempty"`
+
+	// Phase is the admission state of the cluster.
+	Phase ClusterPhase `json:"phase,omitempty"`
+
+	// QueuePosition is the place of the cluster in the admission queue while
+	// it is queued. Position 1 is the next cluster to be admitted.
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+
+	// QueueMessage explains why the cluster has not been admitted.
+	QueueMessage string `json:"queueMessage,omitempty"`
+
+	// DashboardURL is the external address of the dashboard. It is published
+	// once the address is known and is never set for the "NodePort" type.
+	DashboardURL string `json:"dashboardURL,omitempty"`
+
+	// ClientURL is the external address used by clients to connect to the
+	// cluster. It follows the same rules as DashboardURL.
+	ClientURL string `json:"clientURL,omitempty"`
+
+	// PodSecurityViolations lists the Pod Security Standards checks failed by
+	// the cluster pod templates at the level enforced on the namespace. It is
+	// only populated by the "pod-security-admission" pod security backend.
+	PodSecurityViolations []string `json:"podSecurityViolations,omitempty"`
+
+	// RedisPasswordSecretName is the name of the secret that holds the redis
+	// password under the "password" key. Clients that connect to the head
+	// node directly must provide this password.
+	RedisPasswordSecretName string `json:"redisPasswordSecretName,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -164,6 +315,8 @@ type RayClusterStatus struct {
 //+kubebuilder:subresource:scale:specpath=.spec.worker.replicas,statuspath=.status.workerReplicas,selectorpath=.status.workerSelector
 //+kubebuilder:printcolumn:name="Worker Replicas",type=integer,JSONPath=".spec.worker.replicas"
 //+kubebuilder:printcolumn:name="Image",type=string,JSONPath=".spec.image"
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Queue Position",type=integer,JSONPath=".status.queuePosition",priority=1
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
 
 // RayCluster is the Schema for the rayclusters API.
@@ -2,10 +2,13 @@ package v1alpha1
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/go-logr/logr"
 	securityv1beta1 "istio.io/api/security/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -22,96 +25,140 @@ const (
 )
 
 var (
-	rayDefaultRedisPort           int32 = 6379
-	rayDefaultClientServerPort    int32 = 10001
-	rayDefaultObjectManagerPort   int32 = 2384
-	rayDefaultNodeManagerPort     int32 = 2385
-	rayDefaultGCSServerPort       int32 = 2386
-	rayDefaultDashboardPort       int32 = 8265
-	rayDefaultRedisShardPorts           = []int32{6380, 6381}
-	rayDefaultEnableDashboard           = pointer.BoolPtr(true)
-	rayDefaultEnableNetworkPolicy       = pointer.BoolPtr(true)
-	rayDefaultWorkerReplicas            = pointer.Int32Ptr(1)
-	rayDefaultNetworkPolicyLabels       = map[string]string{
-		"ray-client": "true",
-	}
-
-	rayDefaultImage = &OCIImageDefinition{
-		Repository: "rayproject/ray",
-		Tag:        "1.3.0-cpu",
+	rayDefaultTLSIssuer      = RayTLSIssuerOperator
+	rayDefaultTLSDuration    = metav1.Duration{Duration: 90 * 24 * time.Hour}
+	rayDefaultTLSRenewBefore = metav1.Duration{Duration: 30 * 24 * time.Hour}
+
+	rayAutoscalingBackends = []AutoscalingBackend{
+		AutoscalingBackendHPA,
+		AutoscalingBackendKEDA,
 	}
+
+	// raySharedMemoryVolume and rayTLSVolume are the volumes added to ray
+	// pods by the operator, the latter only when TLS is enabled.
+	raySharedMemoryVolume = "dshm"
+	rayTLSVolume          = "ray-tls"
 )
 
 // logger is for webhook logging.
 var logger = logf.Log.WithName("webhooks").WithName("RayCluster")
 
 // SetupWebhookWithManager creates and registers this webhook with the manager.
-func (r *RayCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		Complete()
+// Only clusters matched by selector are defaulted and validated. Clusters are
+// defaulted using the operator defaults of the source, or the built-in
+// defaults when it is nil.
+func (r *RayCluster) SetupWebhookWithManager(mgr ctrl.Manager, selector ControllerSelector, defaults ClusterDefaultsSource) error {
+	return registerWebhooks(mgr, r, selector, defaults)
 }
 
 //+kubebuilder:webhook:path=/mutate-distributed-compute-dominodatalab-com-v1alpha1-raycluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=distributed-compute.dominodatalab.com,resources=rayclusters,verbs=create;update,versions=v1alpha1,name=mraycluster.kb.io,admissionReviewVersions={v1,v1beta1}
 
 var _ webhook.Defaulter = &RayCluster{}
 
-// Default implements webhook.Defaulter so a webhook will be registered for the type
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+// The built-in operator defaults are applied.
 func (r *RayCluster) Default() {
+	r.applyClusterDefaults(NewClusterDefaults())
+}
+
+// applyClusterDefaults sets the fields that are empty using the operator
+// defaults.
+func (r *RayCluster) applyClusterDefaults(defaults ClusterDefaults) {
+	d := defaults.Ray
 	log := logger.WithValues("raycluster", client.ObjectKeyFromObject(r))
 	log.Info("applying defaults")
 
 	if r.Spec.Port == 0 {
-		log.Info("setting default port", "value", rayDefaultRedisPort)
-		r.Spec.Port = rayDefaultRedisPort
+		log.Info("setting default port", "value", d.Port)
+		r.Spec.Port = d.Port
 	}
 	if r.Spec.RedisShardPorts == nil {
-		log.Info("setting default redis shard ports", "value", rayDefaultRedisShardPorts)
-		r.Spec.RedisShardPorts = rayDefaultRedisShardPorts
+		log.Info("setting default redis shard ports", "value", d.RedisShardPorts)
+		r.Spec.RedisShardPorts = d.RedisShardPorts
 	}
 	if r.Spec.ClientServerPort == 0 {
-		log.Info("setting default client server port", "value", rayDefaultClientServerPort)
-		r.Spec.ClientServerPort = rayDefaultClientServerPort
+		log.Info("setting default client server port", "value", d.ClientServerPort)
+		r.Spec.ClientServerPort = d.ClientServerPort
 	}
 	if r.Spec.ObjectManagerPort == 0 {
-		log.Info("setting default object manager port", "value", rayDefaultObjectManagerPort)
-		r.Spec.ObjectManagerPort = rayDefaultObjectManagerPort
+		log.Info("setting default object manager port", "value", d.ObjectManagerPort)
+		r.Spec.ObjectManagerPort = d.ObjectManagerPort
 	}
 	if r.Spec.GCSServerPort == 0 {
-		log.Info("setting default gcs server port", "value", rayDefaultGCSServerPort)
-		r.Spec.GCSServerPort = rayDefaultGCSServerPort
+		log.Info("setting default gcs server port", "value", d.GCSServerPort)
+		r.Spec.GCSServerPort = d.GCSServerPort
 	}
 	if r.Spec.NodeManagerPort == 0 {
-		log.Info("setting default node manager port", "value", rayDefaultNodeManagerPort)
-		r.Spec.NodeManagerPort = rayDefaultNodeManagerPort
+		log.Info("setting default node manager port", "value", d.NodeManagerPort)
+		r.Spec.NodeManagerPort = d.NodeManagerPort
 	}
 	if r.Spec.DashboardPort == 0 {
-		log.Info("setting default dashboard port", "value", rayDefaultDashboardPort)
-		r.Spec.DashboardPort = rayDefaultDashboardPort
+		log.Info("setting default dashboard port", "value", d.DashboardPort)
+		r.Spec.DashboardPort = d.DashboardPort
 	}
 	if r.Spec.EnableDashboard == nil {
-		log.Info("setting enable dashboard flag", "value", *rayDefaultEnableDashboard)
-		r.Spec.EnableDashboard = rayDefaultEnableDashboard
+		log.Info("setting enable dashboard flag", "value", *d.EnableDashboard)
+		r.Spec.EnableDashboard = d.EnableDashboard
 	}
 	if r.Spec.NetworkPolicy.Enabled == nil {
-		log.Info("setting enable network policy flag", "value", *rayDefaultEnableNetworkPolicy)
-		r.Spec.NetworkPolicy.Enabled = rayDefaultEnableNetworkPolicy
+		log.Info("setting enable network policy flag", "value", *d.EnableNetworkPolicy)
+		r.Spec.NetworkPolicy.Enabled = d.EnableNetworkPolicy
 	}
 	if r.Spec.NetworkPolicy.ClientServerLabels == nil {
-		log.Info("setting default network policy client server labels", "value", rayDefaultNetworkPolicyLabels)
-		r.Spec.NetworkPolicy.ClientServerLabels = rayDefaultNetworkPolicyLabels
+		log.Info("setting default network policy client server labels", "value", d.NetworkPolicyLabels)
+		r.Spec.NetworkPolicy.ClientServerLabels = d.NetworkPolicyLabels
 	}
 	if r.Spec.NetworkPolicy.DashboardLabels == nil {
-		log.Info("setting default network policy dashboard labels", "value", rayDefaultNetworkPolicyLabels)
-		r.Spec.NetworkPolicy.DashboardLabels = rayDefaultNetworkPolicyLabels
+		log.Info("setting default network policy dashboard labels", "value", d.NetworkPolicyLabels)
+		r.Spec.NetworkPolicy.DashboardLabels = d.NetworkPolicyLabels
 	}
 	if r.Spec.Worker.Replicas == nil {
-		log.Info("setting default worker replicas", "value", *rayDefaultWorkerReplicas)
-		r.Spec.Worker.Replicas = rayDefaultWorkerReplicas
+		log.Info("setting default worker replicas", "value", *d.WorkerReplicas)
+		r.Spec.Worker.Replicas = d.WorkerReplicas
 	}
 	if r.Spec.Image == nil {
-		log.Info("setting default image", "value", *rayDefaultImage)
-		r.Spec.Image = rayDefaultImage
+		log.Info("setting default image", "value", *d.Image)
+		r.Spec.Image = d.Image
+	}
+	if r.Spec.SecurityProfile == "" {
+		log.Info("setting default security profile", "value", d.SecurityProfile)
+		r.Spec.SecurityProfile = d.SecurityProfile
+	}
+	r.defaultOptionalConfigs(log, d)
+}
+
+// defaultOptionalConfigs sets defaults on the optional feature blocks that are
+// present in the spec.
+func (r *RayCluster) defaultOptionalConfigs(log logr.Logger, d RayClusterDefaults) {
+	if r.Spec.TLS != nil {
+		r.defaultTLS(log)
+	}
+	if r.Spec.DashboardAuth != nil {
+		defaultDashboardAuth(r.Spec.DashboardAuth, log)
+	}
+	if mon := r.Spec.Monitoring; mon != nil {
+		defaultMonitoring(mon, log)
+		if mon.Port == 0 {
+			log.Info("setting default metrics port", "value", d.MetricsPort)
+			mon.Port = d.MetricsPort
+		}
+	}
+}
+
+func (r *RayCluster) defaultTLS(log logr.Logger) {
+	tls := r.Spec.TLS
+
+	if tls.Issuer == "" {
+		log.Info("setting default tls issuer", "value", rayDefaultTLSIssuer)
+		tls.Issuer = rayDefaultTLSIssuer
+	}
+	if tls.Duration == nil {
+		log.Info("setting default tls duration", "value", rayDefaultTLSDuration)
+		tls.Duration = rayDefaultTLSDuration.DeepCopy()
+	}
+	if tls.RenewBefore == nil {
+		log.Info("setting default tls renew before", "value", rayDefaultTLSRenewBefore)
+		tls.RenewBefore = rayDefaultTLSRenewBefore.DeepCopy()
 	}
 }
 
@@ -123,14 +170,14 @@ var _ webhook.Validator = &RayCluster{}
 func (r *RayCluster) ValidateCreate() error {
 	logger.WithValues("raycluster", client.ObjectKeyFromObject(r)).Info("validating create")
 
-	return r.validateRayCluster()
+	return r.validateRayCluster(nil)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (r *RayCluster) ValidateUpdate(old runtime.Object) error {
 	logger.WithValues("raycluster", client.ObjectKeyFromObject(r)).Info("validating update")
 
-	return r.validateRayCluster()
+	return r.validateRayCluster(nil)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -139,7 +186,9 @@ func (r *RayCluster) ValidateDelete() error {
 	return nil
 }
 
-func (r *RayCluster) validateRayCluster() error {
+// validateRayCluster validates the cluster spec and the limits of the given
+// compute policies.
+func (r *RayCluster) validateRayCluster(policies []namedComputePolicy) error {
 	var allErrs field.ErrorList
 
 	if err := r.validateMutualTLSMode(); err != nil {
@@ -163,6 +212,51 @@ func (r *RayCluster) validateRayCluster() error {
 	if errs := r.validateImage(); errs != nil {
 		allErrs = append(allErrs, errs...)
 	}
+	if errs := r.validateNodes(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateEnvVars(r.Spec.EnvVars, field.NewPath("spec").Child("envVars")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validatePodDisruptionBudget(
+		r.Spec.PodDisruptionBudget,
+		field.NewPath("spec").Child("podDisruptionBudget"),
+	); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateScheduling(r.Spec.Scheduling, field.NewPath("spec").Child("scheduling")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateExpose(
+		r.Spec.Expose,
+		pointer.BoolPtrDerefOr(r.Spec.EnableDashboard, false),
+		true,
+		pointer.BoolPtrDerefOr(r.Spec.NetworkPolicy.Enabled, false),
+		field.NewPath("spec").Child("expose"),
+	); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateNetworkPolicyCIDRs(
+		r.Spec.NetworkPolicy.ClientServerCIDRs,
+		r.Spec.NetworkPolicy.DashboardCIDRs,
+		r.Spec.NetworkPolicy.Egress,
+		field.NewPath("spec").Child("networkPolicy"),
+	); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := r.validateSecurity(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateMonitoring(
+		r.Spec.Monitoring,
+		pointer.BoolPtrDerefOr(r.Spec.NetworkPolicy.Enabled, false),
+		field.NewPath("spec").Child("monitoring"),
+	); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := r.computePolicySubject().validate(policies); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
 
 	if len(allErrs) == 0 {
 		return nil
@@ -175,6 +269,20 @@ func (r *RayCluster) validateRayCluster() error {
 	)
 }
 
+// validateSecurity checks the settings that harden cluster pods and
+// endpoints.
+func (r *RayCluster) validateSecurity() field.ErrorList {
+	errs := r.validateSecurityProfile()
+	errs = append(errs, r.validateTLS()...)
+	errs = append(errs, validateDashboardAuth(
+		r.Spec.DashboardAuth,
+		r.Spec.EnableDashboard != nil && *r.Spec.EnableDashboard,
+		field.NewPath("spec").Child("dashboardAuth"),
+	)...)
+
+	return errs
+}
+
 func (r *RayCluster) validateMutualTLSMode() *field.Error {
 	if r.Spec.MutualTLSMode == "" {
 		return nil
@@ -209,6 +317,101 @@ func (r *RayCluster) validateImage() field.ErrorList {
 	return errs
 }
 
+// validateNodes checks the labels and volume references of the head and
+// worker nodes.
+func (r *RayCluster) validateNodes() field.ErrorList {
+	var errs field.ErrorList
+
+	operatorVolumes := []string{raySharedMemoryVolume}
+	if tls := r.Spec.TLS; tls != nil && tls.Enabled != nil && *tls.Enabled {
+		operatorVolumes = append(operatorVolumes, rayTLSVolume)
+	}
+
+	fldPath := field.NewPath("spec")
+	for _, node := range []struct {
+		path *field.Path
+		spec RayClusterNode
+	}{
+		{fldPath.Child("head"), r.Spec.Head.RayClusterNode},
+		{fldPath.Child("worker"), r.Spec.Worker.RayClusterNode},
+	} {
+		errs = append(errs, validateNodeLabels(node.spec.Labels, node.path.Child("labels"))...)
+
+		var claims []string
+		for _, vct := range node.spec.VolumeClaimTemplates {
+			claims = append(claims, vct.Name)
+		}
+		errs = append(errs, nodeVolumes{
+			path:            node.path,
+			volumes:         node.spec.Volumes,
+			mounts:          node.spec.VolumeMounts,
+			claims:          claims,
+			claimsField:     "volumeClaimTemplates",
+			operatorVolumes: operatorVolumes,
+		}.validate()...)
+	}
+
+	return errs
+}
+
+func (r *RayCluster) validateSecurityProfile() field.ErrorList {
+	fldPath := field.NewPath("spec")
+
+	errs := validateSecurityProfile(r.Spec.SecurityProfile, r.Spec.PodSecurityContext, fldPath)
+	errs = append(errs, validateContainerSecurity(
+		r.Spec.SecurityProfile,
+		r.Spec.Head.InitContainers,
+		fldPath.Child("head", "initContainers"),
+	)...)
+	errs = append(errs, validateContainerSecurity(
+		r.Spec.SecurityProfile,
+		r.Spec.Worker.InitContainers,
+		fldPath.Child("worker", "initContainers"),
+	)...)
+
+	return errs
+}
+
+func (r *RayCluster) validateTLS() field.ErrorList {
+	tls := r.Spec.TLS
+	if tls == nil || tls.Enabled == nil || !*tls.Enabled {
+		return nil
+	}
+
+	var errs field.ErrorList
+	fldPath := field.NewPath("spec").Child("tls")
+
+	switch tls.Issuer {
+	case RayTLSIssuerOperator:
+	case RayTLSIssuerCertManager:
+		if tls.IssuerRef == nil || tls.IssuerRef.Name == "" {
+			errs = append(errs, field.Required(fldPath.Child("issuerRef", "name"), "required by the cert-manager issuer"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("issuer"), tls.Issuer, []string{
+			string(RayTLSIssuerOperator),
+			string(RayTLSIssuerCertManager),
+		}))
+	}
+
+	if tls.Duration != nil && tls.Duration.Duration < time.Hour {
+		errs = append(errs, field.Invalid(fldPath.Child("duration"), tls.Duration.Duration.String(), "must be at least 1h"))
+	}
+	if tls.RenewBefore != nil {
+		if tls.RenewBefore.Duration <= 0 {
+			errs = append(errs, field.Invalid(fldPath.Child("renewBefore"), tls.RenewBefore.Duration.String(), "must be greater than 0"))
+		} else if tls.Duration != nil && tls.RenewBefore.Duration >= tls.Duration.Duration {
+			errs = append(errs, field.Invalid(
+				fldPath.Child("renewBefore"),
+				tls.RenewBefore.Duration.String(),
+				"must be less than the duration",
+			))
+		}
+	}
+
+	return errs
+}
+
 func (r *RayCluster) validateWorkerReplicas() *field.Error {
 	replicas := r.Spec.Worker.Replicas
 	if replicas == nil || *replicas >= 0 {
@@ -223,7 +426,7 @@ func (r *RayCluster) validateWorkerReplicas() *field.Error {
 }
 
 func (r *RayCluster) validateWorkerResourceRequestsCPU() *field.Error {
-	if r.Spec.Autoscaling == nil {
+	if !usesHPABackend(r.Spec.Autoscaling) {
 		return nil
 	}
 	if _, ok := r.Spec.Worker.Resources.Requests[v1.ResourceCPU]; ok {
@@ -253,43 +456,43 @@ func (r *RayCluster) validateObjectStoreMemoryBytes() *field.Error {
 func (r *RayCluster) validatePorts() field.ErrorList {
 	var errs field.ErrorList
 
-	if err := r.validatePort(r.Spec.Port, field.NewPath("spec").Child("port")); err != nil {
-		errs = append(errs, err)
-	}
-
-	for idx, port := range r.Spec.RedisShardPorts {
-		name := fmt.Sprintf("redisShardPorts[%d]", idx)
-		if err := r.validatePort(port, field.NewPath("spec").Child(name)); err != nil {
+	ports := r.portFields()
+	for _, pf := range ports {
+		if err := r.validatePort(pf.port, pf.path); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
-	for idx, port := range r.Spec.WorkerPorts {
-		name := fmt.Sprintf("workerPorts[%d]", idx)
-		if err := r.validatePort(port, field.NewPath("spec").Child(name)); err != nil {
-			errs = append(errs, err)
-		}
-	}
+	return append(errs, validateUniquePorts(ports)...)
+}
 
-	if err := r.validatePort(r.Spec.ClientServerPort, field.NewPath("spec").Child("clientServerPort")); err != nil {
-		errs = append(errs, err)
-	}
-	if err := r.validatePort(r.Spec.ObjectManagerPort, field.NewPath("spec").Child("objectManagerPort")); err != nil {
-		errs = append(errs, err)
+// portFields returns the ports opened by ray pods. The proxy and metrics ports
+// are included whenever dashboard auth and monitoring are configured.
+func (r *RayCluster) portFields() []portField {
+	fldPath := field.NewPath("spec")
+
+	ports := []portField{{r.Spec.Port, fldPath.Child("port")}}
+	for idx, port := range r.Spec.RedisShardPorts {
+		ports = append(ports, portField{port, fldPath.Child("redisShardPorts").Index(idx)})
 	}
-	if err := r.validatePort(r.Spec.NodeManagerPort, field.NewPath("spec").Child("nodeManagerPort")); err != nil {
-		errs = append(errs, err)
+	ports = append(ports,
+		portField{r.Spec.ClientServerPort, fldPath.Child("clientServerPort")},
+		portField{r.Spec.ObjectManagerPort, fldPath.Child("objectManagerPort")},
+		portField{r.Spec.NodeManagerPort, fldPath.Child("nodeManagerPort")},
+		portField{r.Spec.GCSServerPort, fldPath.Child("gcsServerPort")},
+	)
+	for idx, port := range r.Spec.WorkerPorts {
+		ports = append(ports, portField{port, fldPath.Child("workerPorts").Index(idx)})
 	}
-	if err := r.validatePort(r.Spec.GCSServerPort, field.NewPath("spec").Child("gcsServerPort")); err != nil {
-		errs = append(errs, err)
+	ports = append(ports, portField{r.Spec.DashboardPort, fldPath.Child("dashboardPort")})
+	if auth := r.Spec.DashboardAuth; auth != nil {
+		ports = append(ports, portField{auth.Port, fldPath.Child("dashboardAuth", "port")})
 	}
-	if err := r.validatePort(r.Spec.DashboardPort, field.NewPath("spec").Child("dashboardPort")); err != nil {
-		errs = append(errs, err)
+	if mon := r.Spec.Monitoring; mon != nil {
+		ports = append(ports, portField{mon.Port, fldPath.Child("monitoring", "port")})
 	}
 
-	// TODO: add validation to prevent port values overlap
-
-	return errs
+	return ports
 }
 
 func (r *RayCluster) validatePort(port int32, fldPath *field.Path) *field.Error {
@@ -314,12 +517,27 @@ func (r *RayCluster) validateAutoscaler() field.ErrorList {
 
 	fldPath := field.NewPath("spec").Child("autoscaling")
 
+	if err := validateAutoscalingBackend(as.Backend, rayAutoscalingBackends, fldPath.Child("backend")); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateWorkerReplicasInRange(r.Spec.Worker.Replicas, as, field.NewPath("spec").Child("worker", "replicas")); err != nil {
+		errs = append(errs, err)
+	}
+
 	if as.MinReplicas != nil {
-		if *as.MinReplicas < 1 {
+		if as.Backend == AutoscalingBackendKEDA {
+			if *as.MinReplicas < 0 {
+				errs = append(errs, field.Invalid(
+					fldPath.Child("minReplicas"),
+					as.MinReplicas,
+					"must be greater than or equal to 0",
+				))
+			}
+		} else if *as.MinReplicas < 1 {
 			errs = append(errs, field.Invalid(
 				fldPath.Child("minReplicas"),
 				as.MinReplicas,
-				"must be greater than or equal to 1",
+				"must be greater than or equal to 1 unless the keda backend is used",
 			))
 		}
 
@@ -356,5 +574,55 @@ func (r *RayCluster) validateAutoscaler() field.ErrorList {
 		))
 	}
 
+	if as.ScaleUpStabilizationWindowSeconds != nil && *as.ScaleUpStabilizationWindowSeconds < 0 {
+		errs = append(errs, field.Invalid(
+			fldPath.Child("scaleUpStabilizationWindowSeconds"),
+			as.ScaleUpStabilizationWindowSeconds,
+			"must be greater than or equal to 0",
+		))
+	}
+
+	if as.Backend == AutoscalingBackendKEDA {
+		if as.KEDA == nil || len(as.KEDA.Triggers) == 0 {
+			errs = append(errs, field.Required(
+				fldPath.Child("keda").Child("triggers"),
+				"at least one trigger is mandatory when using the keda backend",
+			))
+		}
+		errs = append(errs, validateKEDAConfig(as.KEDA, fldPath.Child("keda"))...)
+	}
+
 	return errs
 }
+
+// applyComputePolicyDefaults sets the image and node fields that are empty
+// using the defaults of the compute policies in the cluster namespace.
+func (r *RayCluster) applyComputePolicyDefaults(defaults ComputePolicyDefaults) {
+	if r.Spec.Image == nil && defaults.RayImage != nil {
+		logger.WithValues("raycluster", client.ObjectKeyFromObject(r)).Info("setting compute policy image", "value", *defaults.RayImage)
+		r.Spec.Image = defaults.RayImage
+	}
+
+	for _, node := range []*RayClusterNode{&r.Spec.Head.RayClusterNode, &r.Spec.Worker.RayClusterNode} {
+		applyNodePolicyDefaults(defaults, &node.NodeSelector, &node.Tolerations, &node.Resources)
+	}
+}
+
+func (r *RayCluster) validateComputePolicies(policies []namedComputePolicy) error {
+	return r.validateRayCluster(policies)
+}
+
+func (r *RayCluster) computePolicySubject() policySubject {
+	fldPath := field.NewPath("spec")
+
+	return policySubject{
+		image:       r.Spec.Image,
+		workers:     r.Spec.Worker.Replicas,
+		autoscaling: r.Spec.Autoscaling,
+		nodes: []policyNode{
+			{path: fldPath.Child("head"), resources: r.Spec.Head.Resources},
+			{path: fldPath.Child("worker"), resources: r.Spec.Worker.Resources},
+		},
+		networkPolicy: r.Spec.NetworkPolicy.Enabled,
+	}
+}
@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -8,6 +10,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 )
 
@@ -93,6 +96,10 @@ var _ = Describe("RayCluster", func() {
 				Equal(&OCIImageDefinition{Repository: "rayproject/ray", Tag: "1.3.0-cpu"}),
 				`image reference should equal "rayproject/ray:1.3.0-cpu"`,
 			)
+			Expect(rc.Spec.SecurityProfile).To(
+				Equal(SecurityProfileNone),
+				`security profile should equal "none"`,
+			)
 		})
 
 		It("does not set the port when present", func() {
@@ -182,6 +189,155 @@ var _ = Describe("RayCluster", func() {
 		})
 	})
 
+	Describe("Controller class", func() {
+		It("ignores clusters managed by another controller class", func() {
+			rc := rayFixture(testNS.Name)
+			rc.Spec.ControllerClass = "experimental"
+			rc.Spec.Worker.Replicas = pointer.Int32Ptr(-1)
+
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			Expect(rc.Spec.Port).To(BeZero(), "defaults should not be applied")
+		})
+
+		It("rejects an invalid controller class", func() {
+			rc := rayFixture(testNS.Name)
+			rc.Spec.ControllerClass = "Not_Valid"
+
+			Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+		})
+	})
+
+	Describe("Compute policies", func() {
+		createPolicy := func(spec ComputePolicySpec) {
+			policy := &ComputePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "test-",
+					Namespace:    testNS.Name,
+				},
+				Spec: spec,
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		}
+
+		It("applies policy defaults before operator defaults", func() {
+			createPolicy(ComputePolicySpec{
+				Defaults: ComputePolicyDefaults{
+					RayImage: &OCIImageDefinition{
+						Registry:   "quay.io",
+						Repository: "example/ray",
+						Tag:        "1.2.0",
+					},
+					NodeSelector: map[string]string{"node-pool": "compute"},
+				},
+			})
+
+			rc := rayFixture(testNS.Name)
+			rc.Spec.Worker.NodeSelector = map[string]string{"node-pool": "gpu"}
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+
+			Expect(rc.Spec.Image).To(Equal(&OCIImageDefinition{
+				Registry:   "quay.io",
+				Repository: "example/ray",
+				Tag:        "1.2.0",
+			}))
+			Expect(rc.Spec.Head.NodeSelector).To(Equal(map[string]string{"node-pool": "compute"}))
+			Expect(rc.Spec.Worker.NodeSelector).To(Equal(map[string]string{"node-pool": "gpu"}))
+			Expect(rc.Spec.Port).To(BeNumerically("==", 6379))
+		})
+
+		It("prefers namespaced policy defaults over cluster policy defaults", func() {
+			clusterPolicy := &ClusterComputePolicy{
+				ObjectMeta: metav1.ObjectMeta{GenerateName: "test-"},
+				Spec: ComputePolicySpec{
+					Defaults: ComputePolicyDefaults{
+						NodeSelector: map[string]string{"node-pool": "default"},
+						Tolerations:  []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpExists}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, clusterPolicy)).To(Succeed())
+			defer func() { Expect(k8sClient.Delete(ctx, clusterPolicy)).To(Succeed()) }()
+
+			createPolicy(ComputePolicySpec{
+				Defaults: ComputePolicyDefaults{
+					NodeSelector: map[string]string{"node-pool": "compute"},
+				},
+			})
+
+			rc := rayFixture(testNS.Name)
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+
+			Expect(rc.Spec.Head.NodeSelector).To(Equal(map[string]string{"node-pool": "compute"}))
+			Expect(rc.Spec.Head.Tolerations).To(HaveLen(1))
+		})
+
+		It("rejects clusters that exceed the max workers", func() {
+			createPolicy(ComputePolicySpec{
+				Limits: ComputePolicyLimits{MaxWorkers: pointer.Int32Ptr(2)},
+			})
+
+			rc := rayFixture(testNS.Name)
+			rc.Spec.Worker.Replicas = pointer.Int32Ptr(3)
+			Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+
+			rc = rayFixture(testNS.Name)
+			rc.Spec.Worker.Replicas = pointer.Int32Ptr(1)
+			rc.Spec.Autoscaling = &Autoscaling{MaxReplicas: 5}
+			Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+		})
+
+		It("rejects nodes that exceed the per-node resource limits", func() {
+			maxCPU := resource.MustParse("2")
+			createPolicy(ComputePolicySpec{
+				Limits: ComputePolicyLimits{MaxCPUPerNode: &maxCPU},
+			})
+
+			rc := rayFixture(testNS.Name)
+			rc.Spec.Worker.Resources = v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+			}
+			Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+		})
+
+		It("rejects images from registries that are not allowed", func() {
+			createPolicy(ComputePolicySpec{
+				Limits: ComputePolicyLimits{AllowedRegistries: []string{"quay.io"}},
+			})
+
+			rc := rayFixture(testNS.Name)
+			Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed(), "default image is pulled from docker.io")
+
+			rc.Spec.Image = &OCIImageDefinition{Registry: "quay.io", Repository: "example/ray", Tag: "1.2.0"}
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+		})
+
+		It("rejects clusters that disable network policies when they are required", func() {
+			createPolicy(ComputePolicySpec{
+				Limits: ComputePolicyLimits{RequireNetworkPolicy: true},
+			})
+
+			rc := rayFixture(testNS.Name)
+			rc.Spec.NetworkPolicy.Enabled = pointer.BoolPtr(false)
+			Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+		})
+
+		It("does not enforce limits on metadata updates", func() {
+			rc := rayFixture(testNS.Name)
+			rc.Spec.Worker.Replicas = pointer.Int32Ptr(3)
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+
+			createPolicy(ComputePolicySpec{
+				Limits: ComputePolicyLimits{MaxWorkers: pointer.Int32Ptr(2)},
+			})
+
+			rc.Labels = map[string]string{"updated": "true"}
+			Expect(k8sClient.Update(ctx, rc)).To(Succeed())
+
+			rc.Spec.Worker.Replicas = pointer.Int32Ptr(4)
+			Expect(k8sClient.Update(ctx, rc)).ToNot(Succeed())
+		})
+	})
+
 	Describe("Validation", func() {
 		It("passes when object is valid", func() {
 			rc := rayFixture(testNS.Name)
@@ -238,6 +394,113 @@ var _ = Describe("RayCluster", func() {
 			),
 		)
 
+		It("rejects overlapping ports", func() {
+			rc := rayFixture(testNS.Name)
+			rc.Spec.WorkerPorts = []int32{11000, 10001}
+
+			Expect(k8sClient.Create(ctx, rc)).To(MatchError(ContainSubstring("spec.workerPorts[1]")))
+
+			rc.Spec.WorkerPorts = []int32{11000, 11001}
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+		})
+
+		It("rejects duplicate env vars", func() {
+			rc := rayFixture(testNS.Name)
+			rc.Spec.EnvVars = []v1.EnvVar{
+				{Name: "LOG_LEVEL", Value: "info"},
+				{Name: "LOG_LEVEL", Value: "debug"},
+			}
+
+			Expect(k8sClient.Create(ctx, rc)).To(MatchError(ContainSubstring("spec.envVars[1].name")))
+		})
+
+		Context("With node labels", func() {
+			It("passes with custom labels", func() {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.Worker.Labels = map[string]string{"team": "ml"}
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects labels managed by the operator", func() {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.Head.Labels = map[string]string{"app.kubernetes.io/component": "custom"}
+
+				Expect(k8sClient.Create(ctx, rc)).To(
+					MatchError(ContainSubstring("spec.head.labels[app.kubernetes.io/component]")),
+				)
+			})
+
+			It("rejects invalid label values", func() {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.Worker.Labels = map[string]string{"team": "machine learning"}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With node volumes", func() {
+			clusterWithVolumes := func() *RayCluster {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.Worker.Volumes = []v1.Volume{
+					{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+				}
+				rc.Spec.Worker.VolumeClaimTemplates = []PersistentVolumeClaimTemplate{
+					{
+						Name: "scratch",
+						Spec: v1.PersistentVolumeClaimSpec{
+							AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+							},
+						},
+					},
+				}
+				rc.Spec.Worker.VolumeMounts = []v1.VolumeMount{
+					{Name: "cache", MountPath: "/mnt/cache"},
+					{Name: "scratch", MountPath: "/mnt/scratch"},
+					{Name: "dshm", MountPath: "/mnt/shm"},
+				}
+
+				return rc
+			}
+
+			It("passes when mounts reference declared volumes", func() {
+				rc := clusterWithVolumes()
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects mounts of undeclared volumes", func() {
+				rc := clusterWithVolumes()
+				rc.Spec.Head.VolumeMounts = []v1.VolumeMount{{Name: "cache", MountPath: "/mnt/cache"}}
+
+				Expect(k8sClient.Create(ctx, rc)).To(MatchError(ContainSubstring("spec.head.volumeMounts[0].name")))
+			})
+
+			It("rejects duplicate mount paths", func() {
+				rc := clusterWithVolumes()
+				rc.Spec.Worker.VolumeMounts[1].MountPath = "/mnt/cache"
+
+				Expect(k8sClient.Create(ctx, rc)).To(MatchError(ContainSubstring("spec.worker.volumeMounts[1].mountPath")))
+			})
+
+			It("rejects duplicate volume names", func() {
+				rc := clusterWithVolumes()
+				rc.Spec.Worker.VolumeClaimTemplates[0].Name = "cache"
+
+				Expect(k8sClient.Create(ctx, rc)).To(
+					MatchError(ContainSubstring("spec.worker.volumeClaimTemplates[0].name")),
+				)
+			})
+
+			It("rejects volumes named after operator volumes", func() {
+				rc := clusterWithVolumes()
+				rc.Spec.Worker.Volumes[0].Name = "dshm"
+
+				Expect(k8sClient.Create(ctx, rc)).To(MatchError(ContainSubstring("spec.worker.volumes[0].name")))
+			})
+		})
+
 		Context("With a provided image", func() {
 			It("requires a non-blank image registry", func() {
 				rc := rayFixture(testNS.Name)
@@ -254,6 +517,396 @@ var _ = Describe("RayCluster", func() {
 			})
 		})
 
+		Context("With pod disruption budgets enabled", func() {
+			clusterWithBudgets := func() *RayCluster {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.PodDisruptionBudget.Enabled = pointer.BoolPtr(true)
+
+				return rc
+			}
+
+			It("passes with a worker min available percentage", func() {
+				rc := clusterWithBudgets()
+				minAvailable := intstr.FromString("50%")
+				rc.Spec.PodDisruptionBudget.MinAvailable = &minAvailable
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects min available and max unavailable together", func() {
+				rc := clusterWithBudgets()
+				minAvailable := intstr.FromInt(1)
+				maxUnavailable := intstr.FromInt(1)
+				rc.Spec.PodDisruptionBudget.MinAvailable = &minAvailable
+				rc.Spec.PodDisruptionBudget.MaxUnavailable = &maxUnavailable
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects an invalid max unavailable percentage", func() {
+				rc := clusterWithBudgets()
+				maxUnavailable := intstr.FromString("150%")
+				rc.Spec.PodDisruptionBudget.MaxUnavailable = &maxUnavailable
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects a negative min available", func() {
+				rc := clusterWithBudgets()
+				minAvailable := intstr.FromInt(-1)
+				rc.Spec.PodDisruptionBudget.MinAvailable = &minAvailable
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With gang scheduling enabled", func() {
+			clusterWithGang := func() *RayCluster {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.Scheduling = &SchedulingConfig{
+					Gang: &GangSchedulingConfig{
+						Enabled: pointer.BoolPtr(true),
+					},
+				}
+
+				return rc
+			}
+
+			It("passes with the volcano flavor", func() {
+				rc := clusterWithGang()
+				rc.Spec.Scheduling.Gang.Flavor = PodGroupFlavorVolcano
+				rc.Spec.Scheduling.Gang.Queue = "research"
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects an unknown flavor", func() {
+				rc := clusterWithGang()
+				rc.Spec.Scheduling.Gang.Flavor = "garbage"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires a positive schedule timeout", func() {
+				rc := clusterWithGang()
+				rc.Spec.Scheduling.Gang.ScheduleTimeoutSeconds = pointer.Int32Ptr(0)
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With a security profile", func() {
+			It("passes with a supported profile", func() {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.SecurityProfile = SecurityProfileRestricted
+				rc.Spec.PodSecurityContext = &v1.PodSecurityContext{
+					RunAsUser: pointer.Int64Ptr(1001),
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects unsupported profiles", func() {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.SecurityProfile = "garbage"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects a root pod user with the restricted profile", func() {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.SecurityProfile = SecurityProfileRestricted
+				rc.Spec.PodSecurityContext = &v1.PodSecurityContext{
+					RunAsUser: pointer.Int64Ptr(0),
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects privileged init containers", func() {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.SecurityProfile = SecurityProfileBaseline
+				rc.Spec.Head.InitContainers = []v1.Container{
+					{
+						Name:  "init",
+						Image: "busybox",
+						SecurityContext: &v1.SecurityContext{
+							Privileged: pointer.BoolPtr(true),
+						},
+					},
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With TLS enabled", func() {
+			clusterWithTLS := func() *RayCluster {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.TLS = &RayClusterTLS{
+					Enabled: pointer.BoolPtr(true),
+				}
+
+				return rc
+			}
+
+			It("defaults to operator issued certificates", func() {
+				rc := clusterWithTLS()
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+
+				Expect(rc.Spec.TLS.Issuer).To(Equal(RayTLSIssuerOperator))
+				Expect(rc.Spec.TLS.Duration).To(Equal(&metav1.Duration{Duration: 90 * 24 * time.Hour}))
+				Expect(rc.Spec.TLS.RenewBefore).To(Equal(&metav1.Duration{Duration: 30 * 24 * time.Hour}))
+			})
+
+			It("passes with a cert-manager issuer reference", func() {
+				rc := clusterWithTLS()
+				rc.Spec.TLS.Issuer = RayTLSIssuerCertManager
+				rc.Spec.TLS.IssuerRef = &CertificateIssuerRef{Name: "root-ca", Kind: "ClusterIssuer"}
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("requires an issuer reference with cert-manager", func() {
+				rc := clusterWithTLS()
+				rc.Spec.TLS.Issuer = RayTLSIssuerCertManager
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects unsupported issuers", func() {
+				rc := clusterWithTLS()
+				rc.Spec.TLS.Issuer = "garbage"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects a renewal window longer than the certificate duration", func() {
+				rc := clusterWithTLS()
+				rc.Spec.TLS.Duration = &metav1.Duration{Duration: 24 * time.Hour}
+				rc.Spec.TLS.RenewBefore = &metav1.Duration{Duration: 48 * time.Hour}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With dashboard auth", func() {
+			clusterWithDashboardAuth := func() *RayCluster {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.EnableDashboard = pointer.BoolPtr(true)
+				rc.Spec.DashboardAuth = &DashboardAuthConfig{
+					Enabled:         pointer.BoolPtr(true),
+					IssuerURL:       "https://accounts.example.com",
+					ClientSecretRef: &v1.LocalObjectReference{Name: "oidc-client"},
+				}
+
+				return rc
+			}
+
+			It("defaults the proxy image and port", func() {
+				rc := clusterWithDashboardAuth()
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+
+				Expect(rc.Spec.DashboardAuth.Port).To(BeNumerically("==", 4180))
+				Expect(rc.Spec.DashboardAuth.Image).To(Equal(&OCIImageDefinition{
+					Registry:   "quay.io",
+					Repository: "oauth2-proxy/oauth2-proxy",
+					Tag:        "v7.1.3",
+				}))
+			})
+
+			It("passes with a local issuer when verification is skipped", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.DashboardAuth.IssuerURL = "http://oidc-stand-in:8080"
+				rc.Spec.DashboardAuth.InsecureSkipVerify = pointer.BoolPtr(true)
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects a plain http issuer", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.DashboardAuth.IssuerURL = "http://oidc-stand-in:8080"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires a client secret reference", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.DashboardAuth.ClientSecretRef = nil
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires the dashboard to be enabled", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.EnableDashboard = pointer.BoolPtr(false)
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects a proxy port equal to the dashboard port", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.DashboardAuth.Port = 8265
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With monitoring", func() {
+			clusterWithMonitoring := func() *RayCluster {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.Monitoring = &MonitoringConfig{
+					Enabled:         pointer.BoolPtr(true),
+					NamespaceLabels: map[string]string{"monitoring": "true"},
+				}
+
+				return rc
+			}
+
+			It("defaults the scrape object kind and metrics port", func() {
+				rc := clusterWithMonitoring()
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+
+				Expect(rc.Spec.Monitoring.Kind).To(Equal(MonitorKindServiceMonitor))
+				Expect(rc.Spec.Monitoring.Port).To(BeNumerically("==", 8080))
+			})
+
+			It("rejects an unsupported scrape object kind", func() {
+				rc := clusterWithMonitoring()
+				rc.Spec.Monitoring.Kind = "Probe"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects an invalid scrape interval", func() {
+				rc := clusterWithMonitoring()
+				rc.Spec.Monitoring.Interval = "often"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires monitoring peers with network policies", func() {
+				rc := clusterWithMonitoring()
+				rc.Spec.Monitoring.NamespaceLabels = nil
+				rc.Spec.NetworkPolicy.Enabled = pointer.BoolPtr(true)
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects an invalid metrics port", func() {
+				rc := clusterWithMonitoring()
+				rc.Spec.Monitoring.Port = 80
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With network policy CIDRs", func() {
+			It("passes with valid blocks", func() {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.NetworkPolicy.ClientServerCIDRs = []string{"10.0.0.0/8"}
+				rc.Spec.NetworkPolicy.Egress = &NetworkPolicyEgress{
+					Enabled: pointer.BoolPtr(true),
+					CIDRs:   []string{"52.216.0.0/15"},
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects invalid ingress blocks", func() {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.NetworkPolicy.DashboardCIDRs = []string{"10.0.0.1"}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects invalid egress blocks", func() {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.NetworkPolicy.Egress = &NetworkPolicyEgress{
+					CIDRs: []string{"garbage"},
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With external exposure", func() {
+			exposedCluster := func(typ ExposeType) *RayCluster {
+				rc := rayFixture(testNS.Name)
+				rc.Spec.Expose = &ExposeConfig{
+					Type:            typ,
+					Dashboard:       &ExposeEndpoint{Host: "ray.example.com", PathPrefix: "/dashboard"},
+					Client:          &ExposeEndpoint{Host: "client.example.com"},
+					NamespaceLabels: map[string]string{"ingress": "true"},
+				}
+
+				return rc
+			}
+
+			It("requires exposure peers with network policies", func() {
+				rc := exposedCluster(ExposeTypeLoadBalancer)
+				rc.Spec.Expose.NamespaceLabels = nil
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("passes without exposure peers when network policies are disabled", func() {
+				rc := exposedCluster(ExposeTypeLoadBalancer)
+				rc.Spec.Expose.NamespaceLabels = nil
+				rc.Spec.NetworkPolicy.Enabled = pointer.BoolPtr(false)
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects invalid exposure CIDRs", func() {
+				rc := exposedCluster(ExposeTypeLoadBalancer)
+				rc.Spec.Expose.CIDRs = []string{"garbage"}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("passes with ingress routing", func() {
+				Expect(k8sClient.Create(ctx, exposedCluster(ExposeTypeIngress))).To(Succeed())
+			})
+
+			It("passes with a load balancer", func() {
+				Expect(k8sClient.Create(ctx, exposedCluster(ExposeTypeLoadBalancer))).To(Succeed())
+			})
+
+			It("rejects an unknown type", func() {
+				Expect(k8sClient.Create(ctx, exposedCluster("garbage"))).ToNot(Succeed())
+			})
+
+			It("requires an endpoint to route", func() {
+				rc := exposedCluster(ExposeTypeIstioGateway)
+				rc.Spec.Expose.Dashboard = nil
+				rc.Spec.Expose.Client = nil
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires the dashboard to route it", func() {
+				rc := exposedCluster(ExposeTypeIngress)
+				rc.Spec.EnableDashboard = pointer.BoolPtr(false)
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires absolute path prefixes", func() {
+				rc := exposedCluster(ExposeTypeIngress)
+				rc.Spec.Expose.Dashboard.PathPrefix = "dashboard"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects client path prefixes", func() {
+				rc := exposedCluster(ExposeTypeIngress)
+				rc.Spec.Expose.Client.PathPrefix = "/client"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
 		Context("With autoscaling enabled", func() {
 			clusterWithAutoscaling := func() *RayCluster {
 				rc := rayFixture(testNS.Name)
@@ -282,6 +935,21 @@ var _ = Describe("RayCluster", func() {
 				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
 			})
 
+			It("requires worker replicas within the autoscaling bounds", func() {
+				rc := clusterWithAutoscaling()
+				rc.Spec.Autoscaling.MinReplicas = pointer.Int32Ptr(2)
+				rc.Spec.Autoscaling.MaxReplicas = 4
+
+				rc.Spec.Worker.Replicas = pointer.Int32Ptr(1)
+				Expect(k8sClient.Create(ctx, rc)).To(MatchError(ContainSubstring("spec.worker.replicas")))
+
+				rc.Spec.Worker.Replicas = pointer.Int32Ptr(5)
+				Expect(k8sClient.Create(ctx, rc)).To(MatchError(ContainSubstring("spec.worker.replicas")))
+
+				rc.Spec.Worker.Replicas = pointer.Int32Ptr(3)
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
 			It("requires max replicas to be > 0", func() {
 				rc := clusterWithAutoscaling()
 				rc.Spec.Autoscaling.MaxReplicas = 0
@@ -327,6 +995,43 @@ var _ = Describe("RayCluster", func() {
 
 				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
 			})
+
+			It("rejects an unknown backend", func() {
+				rc := clusterWithAutoscaling()
+				rc.Spec.Autoscaling.Backend = "garbage"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects the spark-master backend", func() {
+				rc := clusterWithAutoscaling()
+				rc.Spec.Autoscaling.Backend = AutoscalingBackendSparkMaster
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("allows min replicas of 0 with the keda backend", func() {
+				rc := clusterWithAutoscaling()
+				rc.Spec.Autoscaling.Backend = AutoscalingBackendKEDA
+				rc.Spec.Autoscaling.MinReplicas = pointer.Int32Ptr(0)
+				rc.Spec.Autoscaling.KEDA = &KEDAConfig{
+					Triggers: []KEDATrigger{
+						{
+							Type:     "prometheus",
+							Metadata: map[string]string{"query": "sum(ray_pending_tasks)"},
+						},
+					},
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("requires triggers with the keda backend", func() {
+				rc := clusterWithAutoscaling()
+				rc.Spec.Autoscaling.Backend = AutoscalingBackendKEDA
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
 		})
 
 		DescribeTable("With mutal tls mode set",
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -94,16 +95,60 @@ type SparkClusterSpec struct {
 	// only applicable when EnableNetworkPolicy is true.
 	NetworkPolicy SparkClusterNetworkPolicy `json:"networkPolicy,omitempty"`
 
+	// PodDisruptionBudget parameters that protect cluster nodes from voluntary
+	// evictions.
+	PodDisruptionBudget PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+
+	// Scheduling parameters used to dispatch cluster pods to nodes.
+	Scheduling *SchedulingConfig `json:"scheduling,omitempty"`
+
+	// ControllerClass assigns the cluster to the operator instances started
+	// with a matching controller class. Clusters without a class belong to
+	// the "default" class.
+	ControllerClass string `json:"controllerClass,omitempty"`
+
+	// Expose parameters used to reach the dashboard and client endpoints from
+	// outside of the Kubernetes cluster.
+	Expose *ExposeConfig `json:"expose,omitempty"`
+
+	// DashboardAuth parameters used to require OIDC authentication before
+	// the dashboard can be reached through the head service.
+	DashboardAuth *DashboardAuthConfig `json:"dashboardAuth,omitempty"`
+
+	// Monitoring parameters used to export and scrape workload metrics.
+	Monitoring *MonitoringConfig `json:"monitoring,omitempty"`
+
+	// QueueName references a ClusterQueue in the same namespace that limits
+	// the resources requested by the clusters admitted through it. The
+	// headroom of the namespace resource quotas is used when this is blank.
+	QueueName string `json:"queueName,omitempty"`
+
 	// PodSecurityPolicy name can be provided to govern execution of the spark processes within pods.
+	// This is only used by the "psp" pod security backend.
 	PodSecurityPolicy string `json:"podSecurityPolicy,omitempty"`
 
+	// SecurityContextConstraints name can be provided to govern execution of the spark processes within pods on
+	// OpenShift. This is only used by the "scc" pod security backend.
+	SecurityContextConstraints string `json:"securityContextConstraints,omitempty"`
+
 	// PodSecurityContext added to every spark pod.
 	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
 
+	// SecurityProfile applies a hardened security context to every spark container. Security context fields set
+	// on containers take precedence over the values provided by the profile.
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// Security parameters used to authenticate and encrypt connections between spark processes.
+	Security *SparkClusterSecurity `json:"security,omitempty"`
+
 	// ServiceAccountName will disable the creation of a dedicated cluster service account.
 	// The service account referenced by the provided name will be used instead.
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 
+	// IstioConfig parameters for spark clusters. Sidecars are only injected
+	// into nodes whose annotations set "sidecar.istio.io/inject" to "true".
+	IstioConfig `json:",inline"`
+
 	// EnvVars added to every spark pod container.
 	EnvVars []corev1.EnvVar `json:"envVars,omitempty"`
 
@@ -127,6 +172,82 @@ type SparkClusterNetworkPolicy struct {
 	// DashboardLabels defines the pod selector clause used to grant ingress
 	// access to the head dashboard port.
 	DashboardLabels map[string]string `json:"dashboardLabels,omitempty"`
+
+	// ClientServerNamespaceLabels defines the namespace selector clause used
+	// to grant ingress access to the head client server port from other
+	// namespaces. Only pods matching ClientServerLabels within the selected
+	// namespaces are granted access.
+	ClientServerNamespaceLabels map[string]string `json:"clientServerNamespaceLabels,omitempty"`
+
+	// DashboardNamespaceLabels defines the namespace selector clause used to
+	// grant ingress access to the head dashboard port from other namespaces.
+	// Only pods matching DashboardLabels within the selected namespaces are
+	// granted access.
+	DashboardNamespaceLabels map[string]string `json:"dashboardNamespaceLabels,omitempty"`
+
+	// ClientServerCIDRs grants ingress access to the head client server port
+	// from the provided IP blocks.
+	ClientServerCIDRs []string `json:"clientServerCIDRs,omitempty"`
+
+	// DashboardCIDRs grants ingress access to the head dashboard port from
+	// the provided IP blocks.
+	DashboardCIDRs []string `json:"dashboardCIDRs,omitempty"`
+
+	// IngressRules are additional rules that grant ingress access to all
+	// cluster nodes.
+	IngressRules []networkingv1.NetworkPolicyIngressRule `json:"ingressRules,omitempty"`
+
+	// Egress restricts the outbound traffic permitted from cluster nodes.
+	Egress *NetworkPolicyEgress `json:"egress,omitempty"`
+
+	// Driver grants connectivity between client-mode drivers and the
+	// executors running on cluster workers.
+	Driver *SparkDriverNetworkPolicy `json:"driver,omitempty"`
+}
+
+// SparkDriverNetworkPolicy defines the traffic permitted between client-mode
+// drivers and cluster workers. Drivers must run in the cluster namespace and
+// set the spark conf named by each port field so that drivers and executors
+// bind to the expected ports. Each port opens a range spanning the port and
+// the subsequent "spark.port.maxRetries" ports.
+type SparkDriverNetworkPolicy struct {
+	// Enabled controls the creation of network policies that grant drivers
+	// access to worker and executor ports, and executors access to drivers.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// DriverLabels defines the pod selector clause used to identify driver
+	// pods and is required when Enabled is true. Matching pods become
+	// isolated for ingress, so they only accept traffic admitted by this or
+	// other network policies in the cluster namespace.
+	DriverLabels map[string]string `json:"driverLabels,omitempty"`
+
+	// WorkerPort is the fixed port used by worker processes.
+	WorkerPort int32 `json:"workerPort,omitempty"`
+
+	// DriverPort is the value of "spark.driver.port".
+	DriverPort int32 `json:"driverPort,omitempty"`
+
+	// DriverBlockManagerPort is the value of "spark.driver.blockManager.port".
+	DriverBlockManagerPort int32 `json:"driverBlockManagerPort,omitempty"`
+
+	// BlockManagerPort is the value of "spark.blockManager.port" used by
+	// executors.
+	BlockManagerPort int32 `json:"blockManagerPort,omitempty"`
+
+	// PortMaxRetries is the value of "spark.port.maxRetries".
+	PortMaxRetries *int32 `json:"portMaxRetries,omitempty"`
+}
+
+// SparkClusterSecurity defines the shared secret security settings of a SparkCluster.
+type SparkClusterSecurity struct {
+	// Authenticate requires every spark process, including drivers, to
+	// present a shared secret generated by the operator. The secret is
+	// published in the cluster status so that authorized clients can mount it.
+	Authenticate *bool `json:"authenticate,omitempty"`
+
+	// Encryption enables AES-based encryption of RPC connections. It can only
+	// be enabled along with Authenticate.
+	Encryption *bool `json:"encryption,omitempty"`
 }
 
 // SparkClusterStatus defines the observed state of a SparkCluster resource.
@@ -138,6 +259,34 @@ type SparkClusterStatus struct {
 	WorkerReplicas int32 `json:"workerReplicas,omitempty"`
 	// WorkerSelector is the scale.status.selector subresource field.
 	WorkerSelector string `json:"workerSelector,omitempty"`
+
+	// Phase is the admission state of the cluster.
+	Phase ClusterPhase `json:"phase,omitempty"`
+
+	// QueuePosition is the place of the cluster in the admission queue while
+	// it is queued. Position 1 is the next cluster to be admitted.
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+
+	// QueueMessage explains why the cluster has not been admitted.
+	QueueMessage string `json:"queueMessage,omitempty"`
+
+	// DashboardURL is the external address of the dashboard. It is published
+	// once the address is known and is never set for the "NodePort" type.
+	DashboardURL string `json:"dashboardURL,omitempty"`
+
+	// ClientURL is the external address used by clients to connect to the
+	// cluster. It follows the same rules as DashboardURL.
+	ClientURL string `json:"clientURL,omitempty"`
+
+	// PodSecurityViolations lists the Pod Security Standards checks failed by
+	// the cluster pod templates at the level enforced on the namespace. It is
+	// only populated by the "pod-security-admission" pod security backend.
+	PodSecurityViolations []string `json:"podSecurityViolations,omitempty"`
+
+	// AuthSecretName is the name of the secret that holds the shared
+	// authentication secret. Clients must set "spark.authenticate.secret" to
+	// the value stored under the "auth-secret" key.
+	AuthSecretName string `json:"authSecretName,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -146,6 +295,8 @@ type SparkClusterStatus struct {
 //+kubebuilder:subresource:scale:specpath=.spec.worker.replicas,statuspath=.status.workerReplicas,selectorpath=.status.workerSelector
 //+kubebuilder:printcolumn:name="Worker Replicas",type=integer,JSONPath=".spec.worker.replicas"
 //+kubebuilder:printcolumn:name="Image",type=string,JSONPath=".spec.image"
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Queue Position",type=integer,JSONPath=".status.queuePosition",priority=1
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
 
 // SparkCluster is the Schema for the sparkclusters API.
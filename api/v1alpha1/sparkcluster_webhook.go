@@ -6,6 +6,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -22,17 +23,18 @@ const (
 )
 
 var (
-	sparkDefaultDashboardPort             int32 = 8265
-	sparkDefaultClusterPort               int32 = 7077
-	sparkDefaultEnableNetworkPolicy             = pointer.BoolPtr(true)
-	sparkDefaultWorkerReplicas                  = pointer.Int32Ptr(1)
-	sparkDefaultEnableDashboard                 = pointer.BoolPtr(true)
-	sparkDefaultNetworkPolicyClientLabels       = map[string]string{
-		"spark-client": "true",
-	}
-	sparkDefaultImage = &OCIImageDefinition{
-		Repository: "bitnami/spark",
-		Tag:        "3.0.2-debian-10-r0",
+	sparkDefaultDriverPorts = SparkDriverNetworkPolicy{
+		WorkerPort:             7078,
+		DriverPort:             40000,
+		DriverBlockManagerPort: 40100,
+		BlockManagerPort:       40200,
+		PortMaxRetries:         pointer.Int32Ptr(16),
+	}
+
+	sparkAutoscalingBackends = []AutoscalingBackend{
+		AutoscalingBackendHPA,
+		AutoscalingBackendKEDA,
+		AutoscalingBackendSparkMaster,
 	}
 )
 
@@ -40,53 +42,94 @@ var (
 var sparkLogger = logf.Log.WithName("webhooks").WithName("SparkCluster")
 
 // SetupWebhookWithManager creates and registers this webhook with the manager.
-func (r *SparkCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
-		Complete()
+// Only clusters matched by selector are defaulted and validated. Clusters are
+// defaulted using the operator defaults of the source, or the built-in
+// defaults when it is nil.
+func (r *SparkCluster) SetupWebhookWithManager(mgr ctrl.Manager, selector ControllerSelector, defaults ClusterDefaultsSource) error {
+	return registerWebhooks(mgr, r, selector, defaults)
 }
 
 //+kubebuilder:webhook:path=/mutate-distributed-compute-dominodatalab-com-v1alpha1-sparkcluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=distributed-compute.dominodatalab.com,resources=sparkclusters,verbs=create;update,versions=v1alpha1,name=msparkcluster.kb.io,admissionReviewVersions={v1,v1beta1}
 
 var _ webhook.Defaulter = &SparkCluster{}
 
-// Default implements webhook.Defaulter so a webhook will be registered for the type
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+// The built-in operator defaults are applied.
 func (r *SparkCluster) Default() {
+	r.applyClusterDefaults(NewClusterDefaults())
+}
+
+// applyClusterDefaults sets the fields that are empty using the operator
+// defaults.
+func (r *SparkCluster) applyClusterDefaults(defaults ClusterDefaults) {
+	d := defaults.Spark
 	log := sparkLogger.WithValues("sparkcluster", client.ObjectKeyFromObject(r))
 	log.Info("applying defaults")
 
 	if r.Spec.ClusterPort == 0 {
-		log.Info("setting default cluster port", "value", sparkDefaultClusterPort)
-		r.Spec.ClusterPort = sparkDefaultClusterPort
+		log.Info("setting default cluster port", "value", d.ClusterPort)
+		r.Spec.ClusterPort = d.ClusterPort
 	}
 	if r.Spec.DashboardPort == 0 {
-		log.Info("setting default dashboard port", "value", sparkDefaultDashboardPort)
-		r.Spec.DashboardPort = sparkDefaultDashboardPort
+		log.Info("setting default dashboard port", "value", d.DashboardPort)
+		r.Spec.DashboardPort = d.DashboardPort
 	}
 	if r.Spec.EnableDashboard == nil {
-		log.Info("setting enable dashboard flag", "value", *sparkDefaultEnableDashboard)
-		r.Spec.EnableDashboard = sparkDefaultEnableDashboard
+		log.Info("setting enable dashboard flag", "value", *d.EnableDashboard)
+		r.Spec.EnableDashboard = d.EnableDashboard
 	}
 	if r.Spec.NetworkPolicy.Enabled == nil {
-		log.Info("setting enable network policy flag", "value", *sparkDefaultEnableNetworkPolicy)
-		r.Spec.NetworkPolicy.Enabled = sparkDefaultEnableNetworkPolicy
+		log.Info("setting enable network policy flag", "value", *d.EnableNetworkPolicy)
+		r.Spec.NetworkPolicy.Enabled = d.EnableNetworkPolicy
 	}
 	if r.Spec.NetworkPolicy.ClientServerLabels == nil {
-		log.Info("setting default network policy client labels", "value", sparkDefaultNetworkPolicyClientLabels)
-		r.Spec.NetworkPolicy.ClientServerLabels = sparkDefaultNetworkPolicyClientLabels
+		log.Info("setting default network policy client labels", "value", d.NetworkPolicyLabels)
+		r.Spec.NetworkPolicy.ClientServerLabels = d.NetworkPolicyLabels
 	}
 	if r.Spec.NetworkPolicy.DashboardLabels == nil {
-		log.Info("setting default network policy dashboard labels", "value", sparkDefaultNetworkPolicyClientLabels)
-		r.Spec.NetworkPolicy.DashboardLabels = sparkDefaultNetworkPolicyClientLabels
+		log.Info("setting default network policy dashboard labels", "value", d.NetworkPolicyLabels)
+		r.Spec.NetworkPolicy.DashboardLabels = d.NetworkPolicyLabels
+	}
+	if driver := r.Spec.NetworkPolicy.Driver; driver != nil {
+		if driver.WorkerPort == 0 {
+			log.Info("setting default driver network policy worker port", "value", sparkDefaultDriverPorts.WorkerPort)
+			driver.WorkerPort = sparkDefaultDriverPorts.WorkerPort
+		}
+		if driver.DriverPort == 0 {
+			log.Info("setting default driver network policy driver port", "value", sparkDefaultDriverPorts.DriverPort)
+			driver.DriverPort = sparkDefaultDriverPorts.DriverPort
+		}
+		if driver.DriverBlockManagerPort == 0 {
+			log.Info("setting default driver network policy driver block manager port", "value", sparkDefaultDriverPorts.DriverBlockManagerPort)
+			driver.DriverBlockManagerPort = sparkDefaultDriverPorts.DriverBlockManagerPort
+		}
+		if driver.BlockManagerPort == 0 {
+			log.Info("setting default driver network policy block manager port", "value", sparkDefaultDriverPorts.BlockManagerPort)
+			driver.BlockManagerPort = sparkDefaultDriverPorts.BlockManagerPort
+		}
+		if driver.PortMaxRetries == nil {
+			log.Info("setting default driver network policy port max retries", "value", *sparkDefaultDriverPorts.PortMaxRetries)
+			driver.PortMaxRetries = sparkDefaultDriverPorts.PortMaxRetries
+		}
 	}
 	if r.Spec.Worker.Replicas == nil {
-		log.Info("setting default worker replicas", "value", *sparkDefaultWorkerReplicas)
-		r.Spec.Worker.Replicas = sparkDefaultWorkerReplicas
+		log.Info("setting default worker replicas", "value", *d.WorkerReplicas)
+		r.Spec.Worker.Replicas = d.WorkerReplicas
 	}
 
 	if r.Spec.Image == nil {
-		log.Info("setting default image", "value", *sparkDefaultImage)
-		r.Spec.Image = sparkDefaultImage
+		log.Info("setting default image", "value", *d.Image)
+		r.Spec.Image = d.Image
+	}
+	if r.Spec.SecurityProfile == "" {
+		log.Info("setting default security profile", "value", d.SecurityProfile)
+		r.Spec.SecurityProfile = d.SecurityProfile
+	}
+	if r.Spec.DashboardAuth != nil {
+		defaultDashboardAuth(r.Spec.DashboardAuth, log)
+	}
+	if r.Spec.Monitoring != nil {
+		defaultMonitoring(r.Spec.Monitoring, log)
 	}
 
 	annotations := make(map[string]string)
@@ -97,11 +140,15 @@ func (r *SparkCluster) Default() {
 		r.Spec.Master.Annotations = annotations
 	}
 
+	// sidecars are only injected into spark pods that request them so that
+	// the istio policies can be opted into per cluster.
 	for _, node := range []SparkClusterNode{r.Spec.Master.SparkClusterNode, r.Spec.Worker.SparkClusterNode} {
 		if node.Annotations == nil {
 			node.Annotations = annotations
 		}
-		node.Annotations["sidecar.istio.io/inject"] = "false"
+		if _, ok := node.Annotations["sidecar.istio.io/inject"]; !ok {
+			node.Annotations["sidecar.istio.io/inject"] = "false"
+		}
 	}
 }
 
@@ -113,14 +160,14 @@ var _ webhook.Validator = &SparkCluster{}
 func (r *SparkCluster) ValidateCreate() error {
 	sparkLogger.WithValues("sparkcluster", client.ObjectKeyFromObject(r)).Info("validating create")
 
-	return r.validateSparkCluster()
+	return r.validateSparkCluster(nil)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (r *SparkCluster) ValidateUpdate(old runtime.Object) error {
 	sparkLogger.WithValues("sparkcluster", client.ObjectKeyFromObject(r)).Info("validating update")
 
-	return r.validateSparkCluster()
+	return r.validateSparkCluster(nil)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -129,7 +176,9 @@ func (r *SparkCluster) ValidateDelete() error {
 	return nil
 }
 
-func (r *SparkCluster) validateSparkCluster() error {
+// validateSparkCluster validates the cluster spec and the limits of the given
+// compute policies.
+func (r *SparkCluster) validateSparkCluster(policies []namedComputePolicy) error {
 	var allErrs field.ErrorList
 
 	if err := r.validateWorkerReplicas(); err != nil {
@@ -147,6 +196,51 @@ func (r *SparkCluster) validateSparkCluster() error {
 	if errs := r.validateImage(); errs != nil {
 		allErrs = append(allErrs, errs...)
 	}
+	if errs := r.validateNodes(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateEnvVars(r.Spec.EnvVars, field.NewPath("spec").Child("envVars")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validatePodDisruptionBudget(
+		r.Spec.PodDisruptionBudget,
+		field.NewPath("spec").Child("podDisruptionBudget"),
+	); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateScheduling(r.Spec.Scheduling, field.NewPath("spec").Child("scheduling")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateExpose(
+		r.Spec.Expose,
+		r.Spec.EnableDashboard != nil && *r.Spec.EnableDashboard,
+		false,
+		pointer.BoolPtrDerefOr(r.Spec.NetworkPolicy.Enabled, false),
+		field.NewPath("spec").Child("expose"),
+	); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateNetworkPolicyCIDRs(
+		r.Spec.NetworkPolicy.ClientServerCIDRs,
+		r.Spec.NetworkPolicy.DashboardCIDRs,
+		r.Spec.NetworkPolicy.Egress,
+		field.NewPath("spec").Child("networkPolicy"),
+	); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := r.validateSecurity(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateMonitoring(
+		r.Spec.Monitoring,
+		pointer.BoolPtrDerefOr(r.Spec.NetworkPolicy.Enabled, false),
+		field.NewPath("spec").Child("monitoring"),
+	); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := r.computePolicySubject().validate(policies); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
 
 	if len(allErrs) == 0 {
 		return nil
@@ -159,6 +253,91 @@ func (r *SparkCluster) validateSparkCluster() error {
 	)
 }
 
+// validateNodes checks the labels, additional storage and volume references
+// of the master and worker nodes.
+func (r *SparkCluster) validateNodes() field.ErrorList {
+	var errs field.ErrorList
+
+	fldPath := field.NewPath("spec")
+	for _, node := range []struct {
+		path *field.Path
+		spec SparkClusterNode
+	}{
+		{fldPath.Child("head"), r.Spec.Master.SparkClusterNode},
+		{fldPath.Child("worker"), r.Spec.Worker.SparkClusterNode},
+	} {
+		errs = append(errs, validateNodeLabels(node.spec.Labels, node.path.Child("labels"))...)
+
+		var claims []string
+		for idx, as := range node.spec.AdditionalStorage {
+			if _, err := resource.ParseQuantity(as.Size); err != nil {
+				sizePath := node.path.Child("additionalStorage").Index(idx).Child("size")
+				errs = append(errs, field.Invalid(sizePath, as.Size, err.Error()))
+			}
+			claims = append(claims, as.Name)
+		}
+		errs = append(errs, nodeVolumes{
+			path:        node.path,
+			volumes:     node.spec.Volumes,
+			mounts:      node.spec.VolumeMounts,
+			claims:      claims,
+			claimsField: "additionalStorage",
+		}.validate()...)
+	}
+
+	return errs
+}
+
+func (r *SparkCluster) validateSecurityProfile() field.ErrorList {
+	fldPath := field.NewPath("spec")
+
+	errs := validateSecurityProfile(r.Spec.SecurityProfile, r.Spec.PodSecurityContext, fldPath)
+	errs = append(errs, validateContainerSecurity(
+		r.Spec.SecurityProfile,
+		r.Spec.Master.InitContainers,
+		fldPath.Child("head", "initContainers"),
+	)...)
+	errs = append(errs, validateContainerSecurity(
+		r.Spec.SecurityProfile,
+		r.Spec.Worker.InitContainers,
+		fldPath.Child("worker", "initContainers"),
+	)...)
+
+	return errs
+}
+
+// validateSecurity checks the settings that harden cluster pods and
+// endpoints.
+func (r *SparkCluster) validateSecurity() field.ErrorList {
+	errs := r.validateSecurityProfile()
+	if err := r.validateEncryption(); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, validateDashboardAuth(
+		r.Spec.DashboardAuth,
+		r.Spec.EnableDashboard != nil && *r.Spec.EnableDashboard,
+		field.NewPath("spec").Child("dashboardAuth"),
+	)...)
+
+	return errs
+}
+
+func (r *SparkCluster) validateEncryption() *field.Error {
+	sec := r.Spec.Security
+	if sec == nil || sec.Encryption == nil || !*sec.Encryption {
+		return nil
+	}
+	if sec.Authenticate != nil && *sec.Authenticate {
+		return nil
+	}
+
+	return field.Invalid(
+		field.NewPath("spec").Child("security").Child("encryption"),
+		*sec.Encryption,
+		"requires authenticate to be enabled",
+	)
+}
+
 func (r *SparkCluster) validateWorkerReplicas() *field.Error {
 	replicas := r.Spec.Worker.Replicas
 	if replicas == nil || *replicas >= 0 {
@@ -181,8 +360,71 @@ func (r *SparkCluster) validatePorts() field.ErrorList {
 	if err := r.validatePort(r.Spec.DashboardPort, field.NewPath("spec").Child("dashboardPort")); err != nil {
 		errs = append(errs, err)
 	}
+	if auth := r.Spec.DashboardAuth; auth != nil {
+		if err := r.validatePort(auth.Port, field.NewPath("spec").Child("dashboardAuth", "port")); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if driver := r.Spec.NetworkPolicy.Driver; driver != nil {
+		fldPath := field.NewPath("spec").Child("networkPolicy", "driver")
+		errs = append(errs, r.validateDriverPorts(driver, fldPath)...)
+		if driver.Enabled != nil && *driver.Enabled && len(driver.DriverLabels) == 0 {
+			errs = append(errs, field.Required(fldPath.Child("driverLabels"), "must select driver pods when enabled"))
+		}
+	}
+
+	return append(errs, validateUniquePorts(r.portFields())...)
+}
+
+// portFields returns the ports opened by spark pods. The driver ports are
+// opened by driver pods, except for the worker port.
+func (r *SparkCluster) portFields() []portField {
+	fldPath := field.NewPath("spec")
+
+	ports := []portField{
+		{r.Spec.ClusterPort, fldPath.Child("clusterPort")},
+		{r.Spec.DashboardPort, fldPath.Child("dashboardPort")},
+	}
+	if auth := r.Spec.DashboardAuth; auth != nil {
+		ports = append(ports, portField{auth.Port, fldPath.Child("dashboardAuth", "port")})
+	}
+	if driver := r.Spec.NetworkPolicy.Driver; driver != nil {
+		ports = append(ports, portField{driver.WorkerPort, fldPath.Child("networkPolicy", "driver", "workerPort")})
+	}
+
+	return ports
+}
+
+// validateDriverPorts ensures every port range opened for client-mode drivers
+// fits within the valid port range.
+func (r *SparkCluster) validateDriverPorts(driver *SparkDriverNetworkPolicy, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	var retries int32
+	if driver.PortMaxRetries != nil {
+		retries = *driver.PortMaxRetries
+	}
+	if retries < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("portMaxRetries"), retries, "must be greater than or equal to 0"))
+	}
 
-	// TODO: add validation to prevent port values overlap
+	if err := r.validatePort(driver.WorkerPort, fldPath.Child("workerPort")); err != nil {
+		errs = append(errs, err)
+	}
+	for _, pr := range []struct {
+		name string
+		port int32
+	}{
+		{"driverPort", driver.DriverPort},
+		{"driverBlockManagerPort", driver.DriverBlockManagerPort},
+		{"blockManagerPort", driver.BlockManagerPort},
+	} {
+		if err := r.validatePort(pr.port, fldPath.Child(pr.name)); err != nil {
+			errs = append(errs, err)
+		} else if retries > 0 && pr.port+retries > sparkMaxValidPort {
+			errs = append(errs, field.Invalid(fldPath.Child(pr.name), pr.port, "port range exceeds the maximum port after retries"))
+		}
+	}
 
 	return errs
 }
@@ -209,12 +451,27 @@ func (r *SparkCluster) validateAutoscaler() field.ErrorList {
 
 	fldPath := field.NewPath("spec").Child("autoscaling")
 
+	if err := validateAutoscalingBackend(as.Backend, sparkAutoscalingBackends, fldPath.Child("backend")); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateWorkerReplicasInRange(r.Spec.Worker.Replicas, as, field.NewPath("spec").Child("worker", "replicas")); err != nil {
+		errs = append(errs, err)
+	}
+
 	if as.MinReplicas != nil {
-		if *as.MinReplicas < 1 {
+		if as.Backend == AutoscalingBackendKEDA {
+			if *as.MinReplicas < 0 {
+				errs = append(errs, field.Invalid(
+					fldPath.Child("minReplicas"),
+					as.MinReplicas,
+					"must be greater than or equal to 0",
+				))
+			}
+		} else if *as.MinReplicas < 1 {
 			errs = append(errs, field.Invalid(
 				fldPath.Child("minReplicas"),
 				as.MinReplicas,
-				"must be greater than or equal to 1",
+				"must be greater than or equal to 1 unless the keda backend is used",
 			))
 		}
 
@@ -251,11 +508,37 @@ func (r *SparkCluster) validateAutoscaler() field.ErrorList {
 		))
 	}
 
+	if as.ScaleUpStabilizationWindowSeconds != nil && *as.ScaleUpStabilizationWindowSeconds < 0 {
+		errs = append(errs, field.Invalid(
+			fldPath.Child("scaleUpStabilizationWindowSeconds"),
+			as.ScaleUpStabilizationWindowSeconds,
+			"must be greater than or equal to 0",
+		))
+	}
+
+	if as.Backend == AutoscalingBackendKEDA {
+		if (as.KEDA == nil || len(as.KEDA.Triggers) == 0) && (r.Spec.EnableDashboard == nil || !*r.Spec.EnableDashboard) {
+			errs = append(errs, field.Required(
+				fldPath.Child("keda").Child("triggers"),
+				"at least one trigger is mandatory when the dashboard is disabled",
+			))
+		}
+		errs = append(errs, validateKEDAConfig(as.KEDA, fldPath.Child("keda"))...)
+	}
+
+	if as.Backend == AutoscalingBackendSparkMaster && (r.Spec.EnableDashboard == nil || !*r.Spec.EnableDashboard) {
+		errs = append(errs, field.Invalid(
+			fldPath.Child("backend"),
+			as.Backend,
+			"requires spec.enableDashboard to be true",
+		))
+	}
+
 	return errs
 }
 
 func (r *SparkCluster) validateWorkerResourceRequestsCPU() *field.Error {
-	if r.Spec.Autoscaling == nil {
+	if !usesHPABackend(r.Spec.Autoscaling) {
 		return nil
 	}
 	if _, ok := r.Spec.Worker.Resources.Requests[v1.ResourceCPU]; ok {
@@ -281,3 +564,35 @@ func (r *SparkCluster) validateImage() field.ErrorList {
 
 	return errs
 }
+
+// applyComputePolicyDefaults sets the image and node fields that are empty
+// using the defaults of the compute policies in the cluster namespace.
+func (r *SparkCluster) applyComputePolicyDefaults(defaults ComputePolicyDefaults) {
+	if r.Spec.Image == nil && defaults.SparkImage != nil {
+		sparkLogger.WithValues("sparkcluster", client.ObjectKeyFromObject(r)).Info("setting compute policy image", "value", *defaults.SparkImage)
+		r.Spec.Image = defaults.SparkImage
+	}
+
+	for _, node := range []*SparkClusterNode{&r.Spec.Master.SparkClusterNode, &r.Spec.Worker.SparkClusterNode} {
+		applyNodePolicyDefaults(defaults, &node.NodeSelector, &node.Tolerations, &node.Resources)
+	}
+}
+
+func (r *SparkCluster) validateComputePolicies(policies []namedComputePolicy) error {
+	return r.validateSparkCluster(policies)
+}
+
+func (r *SparkCluster) computePolicySubject() policySubject {
+	fldPath := field.NewPath("spec")
+
+	return policySubject{
+		image:       r.Spec.Image,
+		workers:     r.Spec.Worker.Replicas,
+		autoscaling: r.Spec.Autoscaling,
+		nodes: []policyNode{
+			{path: fldPath.Child("head"), resources: r.Spec.Master.Resources},
+			{path: fldPath.Child("worker"), resources: r.Spec.Worker.Resources},
+		},
+		networkPolicy: r.Spec.NetworkPolicy.Enabled,
+	}
+}
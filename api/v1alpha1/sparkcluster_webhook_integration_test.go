@@ -8,6 +8,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 )
 
@@ -73,6 +74,10 @@ var _ = Describe("SparkCluster", func() {
 				Equal(&OCIImageDefinition{Repository: "bitnami/spark", Tag: "3.0.2-debian-10-r0"}),
 				`image reference should equal "bitnami/spark:3.0.2-debian-10-r0"`,
 			)
+			Expect(rc.Spec.SecurityProfile).To(
+				Equal(SecurityProfileNone),
+				`security profile should equal "none"`,
+			)
 		})
 
 		It("does not set the cluster port when present", func() {
@@ -127,6 +132,24 @@ var _ = Describe("SparkCluster", func() {
 				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
 				Expect(rc.Spec.NetworkPolicy.DashboardLabels).To(Equal(expected))
 			})
+
+			It("set driver connectivity ports", func() {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.NetworkPolicy.Driver = &SparkDriverNetworkPolicy{
+					Enabled:    pointer.BoolPtr(true),
+					DriverPort: 45000,
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+				Expect(rc.Spec.NetworkPolicy.Driver).To(Equal(&SparkDriverNetworkPolicy{
+					Enabled:                pointer.BoolPtr(true),
+					WorkerPort:             7078,
+					DriverPort:             45000,
+					DriverBlockManagerPort: 40100,
+					BlockManagerPort:       40200,
+					PortMaxRetries:         pointer.Int32Ptr(16),
+				}))
+			})
 		})
 
 		Context("Annotations", func() {
@@ -145,13 +168,13 @@ var _ = Describe("SparkCluster", func() {
 				Expect(rc.Spec.Worker.Annotations).To(Equal(expected))
 			})
 
-			It("override provided istio annotation", func() {
+			It("keeps provided istio annotation", func() {
 				rc := sparkFixture(testNS.Name)
 				provided := map[string]string{"sidecar.istio.io/inject": "true"}
 				rc.Spec.Master.Annotations = provided
 				rc.Spec.Worker.Annotations = provided
 
-				expected := map[string]string{"sidecar.istio.io/inject": "false"}
+				expected := map[string]string{"sidecar.istio.io/inject": "true"}
 				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
 				Expect(rc.Spec.Master.Annotations).To(Equal(expected))
 				Expect(rc.Spec.Worker.Annotations).To(Equal(expected))
@@ -159,6 +182,86 @@ var _ = Describe("SparkCluster", func() {
 		})
 	})
 
+	Describe("Controller class", func() {
+		It("ignores clusters managed by another controller class", func() {
+			rc := sparkFixture(testNS.Name)
+			rc.Spec.ControllerClass = "experimental"
+			rc.Spec.Worker.Replicas = pointer.Int32Ptr(-1)
+
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			Expect(rc.Spec.ClusterPort).To(BeZero(), "defaults should not be applied")
+		})
+
+		It("rejects an invalid controller class", func() {
+			rc := sparkFixture(testNS.Name)
+			rc.Spec.ControllerClass = "Not_Valid"
+
+			Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+		})
+	})
+
+	Describe("Compute policies", func() {
+		createPolicy := func(spec ComputePolicySpec) {
+			policy := &ComputePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "test-",
+					Namespace:    testNS.Name,
+				},
+				Spec: spec,
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		}
+
+		It("applies policy defaults before operator defaults", func() {
+			requests := v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}
+			createPolicy(ComputePolicySpec{
+				Defaults: ComputePolicyDefaults{
+					SparkImage: &OCIImageDefinition{
+						Registry:   "quay.io",
+						Repository: "example/spark",
+						Tag:        "3.0.2",
+					},
+					Resources: &v1.ResourceRequirements{Requests: requests},
+				},
+			})
+
+			rc := sparkFixture(testNS.Name)
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+
+			Expect(rc.Spec.Image.Repository).To(Equal("example/spark"))
+			Expect(rc.Spec.Master.Resources.Requests).To(Equal(requests))
+			Expect(rc.Spec.Worker.Resources.Requests).To(Equal(requests))
+			Expect(rc.Spec.ClusterPort).ToNot(BeZero())
+		})
+
+		It("rejects master nodes that exceed the per-node resource limits", func() {
+			maxMemory := resource.MustParse("1Gi")
+			createPolicy(ComputePolicySpec{
+				Limits: ComputePolicyLimits{MaxMemoryPerNode: &maxMemory},
+			})
+
+			rc := sparkFixture(testNS.Name)
+			rc.Spec.Master.Resources = v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("2Gi")},
+			}
+			Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+		})
+
+		It("ignores policies from other namespaces", func() {
+			createPolicy(ComputePolicySpec{
+				Limits: ComputePolicyLimits{MaxWorkers: pointer.Int32Ptr(1)},
+			})
+
+			otherNS := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "test-"}}
+			Expect(k8sClient.Create(ctx, otherNS)).To(Succeed())
+			defer func() { Expect(k8sClient.Delete(ctx, otherNS)).To(Succeed()) }()
+
+			rc := sparkFixture(otherNS.Name)
+			rc.Spec.Worker.Replicas = pointer.Int32Ptr(3)
+			Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+		})
+	})
+
 	Describe("Validation", func() {
 		It("passes when object is valid", func() {
 			rc := sparkFixture(testNS.Name)
@@ -190,6 +293,80 @@ var _ = Describe("SparkCluster", func() {
 			),
 		)
 
+		It("rejects overlapping ports", func() {
+			rc := sparkFixture(testNS.Name)
+			rc.Spec.DashboardPort = 7077
+
+			Expect(k8sClient.Create(ctx, rc)).To(MatchError(ContainSubstring("spec.dashboardPort")))
+		})
+
+		It("rejects duplicate env vars", func() {
+			rc := sparkFixture(testNS.Name)
+			rc.Spec.EnvVars = []v1.EnvVar{
+				{Name: "SPARK_LOG_LEVEL", Value: "INFO"},
+				{Name: "SPARK_LOG_LEVEL", Value: "DEBUG"},
+			}
+
+			Expect(k8sClient.Create(ctx, rc)).To(MatchError(ContainSubstring("spec.envVars[1].name")))
+		})
+
+		It("rejects labels managed by the operator", func() {
+			rc := sparkFixture(testNS.Name)
+			rc.Spec.Worker.Labels = map[string]string{"app.kubernetes.io/name": "custom"}
+
+			Expect(k8sClient.Create(ctx, rc)).To(
+				MatchError(ContainSubstring("spec.worker.labels[app.kubernetes.io/name]")),
+			)
+		})
+
+		Context("With additional storage", func() {
+			clusterWithStorage := func() *SparkCluster {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.Worker.AdditionalStorage = []SparkAdditionalStorage{
+					{
+						AccessModes:  []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+						Size:         "1Gi",
+						StorageClass: "standard",
+						Name:         "spark-data",
+					},
+				}
+				rc.Spec.Worker.VolumeMounts = []v1.VolumeMount{{Name: "spark-data", MountPath: "/data"}}
+
+				return rc
+			}
+
+			It("passes when mounts reference declared storage", func() {
+				Expect(k8sClient.Create(ctx, clusterWithStorage())).To(Succeed())
+			})
+
+			It("rejects mounts of undeclared volumes", func() {
+				rc := clusterWithStorage()
+				rc.Spec.Worker.VolumeMounts[0].Name = "spark-scratch"
+
+				Expect(k8sClient.Create(ctx, rc)).To(MatchError(ContainSubstring("spec.worker.volumeMounts[0].name")))
+			})
+
+			It("rejects storage that duplicates a volume name", func() {
+				rc := clusterWithStorage()
+				rc.Spec.Worker.Volumes = []v1.Volume{
+					{Name: "spark-data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).To(
+					MatchError(ContainSubstring("spec.worker.additionalStorage[0].name")),
+				)
+			})
+
+			It("rejects an invalid size", func() {
+				rc := clusterWithStorage()
+				rc.Spec.Worker.AdditionalStorage[0].Size = "lots"
+
+				Expect(k8sClient.Create(ctx, rc)).To(
+					MatchError(ContainSubstring("spec.worker.additionalStorage[0].size")),
+				)
+			})
+		})
+
 		Context("With a provided image", func() {
 			It("requires a non-blank image registry", func() {
 				rc := sparkFixture(testNS.Name)
@@ -206,6 +383,373 @@ var _ = Describe("SparkCluster", func() {
 			})
 		})
 
+		Context("With pod disruption budgets enabled", func() {
+			clusterWithBudgets := func() *SparkCluster {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.PodDisruptionBudget.Enabled = pointer.BoolPtr(true)
+
+				return rc
+			}
+
+			It("passes with a worker min available percentage", func() {
+				rc := clusterWithBudgets()
+				minAvailable := intstr.FromString("50%")
+				rc.Spec.PodDisruptionBudget.MinAvailable = &minAvailable
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects min available and max unavailable together", func() {
+				rc := clusterWithBudgets()
+				minAvailable := intstr.FromInt(1)
+				maxUnavailable := intstr.FromInt(1)
+				rc.Spec.PodDisruptionBudget.MinAvailable = &minAvailable
+				rc.Spec.PodDisruptionBudget.MaxUnavailable = &maxUnavailable
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects an invalid max unavailable percentage", func() {
+				rc := clusterWithBudgets()
+				maxUnavailable := intstr.FromString("150%")
+				rc.Spec.PodDisruptionBudget.MaxUnavailable = &maxUnavailable
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects a negative min available", func() {
+				rc := clusterWithBudgets()
+				minAvailable := intstr.FromInt(-1)
+				rc.Spec.PodDisruptionBudget.MinAvailable = &minAvailable
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With gang scheduling enabled", func() {
+			clusterWithGang := func() *SparkCluster {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.Scheduling = &SchedulingConfig{
+					Gang: &GangSchedulingConfig{
+						Enabled: pointer.BoolPtr(true),
+					},
+				}
+
+				return rc
+			}
+
+			It("passes with the volcano flavor", func() {
+				rc := clusterWithGang()
+				rc.Spec.Scheduling.Gang.Flavor = PodGroupFlavorVolcano
+				rc.Spec.Scheduling.Gang.Queue = "research"
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects an unknown flavor", func() {
+				rc := clusterWithGang()
+				rc.Spec.Scheduling.Gang.Flavor = "garbage"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires a positive schedule timeout", func() {
+				rc := clusterWithGang()
+				rc.Spec.Scheduling.Gang.ScheduleTimeoutSeconds = pointer.Int32Ptr(0)
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With a security profile", func() {
+			It("passes with a supported profile", func() {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.SecurityProfile = SecurityProfileRestricted
+				rc.Spec.PodSecurityContext = &v1.PodSecurityContext{
+					RunAsUser: pointer.Int64Ptr(1001),
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects unsupported profiles", func() {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.SecurityProfile = "garbage"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects a root pod user with the restricted profile", func() {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.SecurityProfile = SecurityProfileRestricted
+				rc.Spec.PodSecurityContext = &v1.PodSecurityContext{
+					RunAsUser: pointer.Int64Ptr(0),
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects privileged init containers", func() {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.SecurityProfile = SecurityProfileBaseline
+				rc.Spec.Master.InitContainers = []v1.Container{
+					{
+						Name:  "init",
+						Image: "busybox",
+						SecurityContext: &v1.SecurityContext{
+							Privileged: pointer.BoolPtr(true),
+						},
+					},
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With shared secret security", func() {
+			It("passes with authentication and encryption", func() {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.Security = &SparkClusterSecurity{
+					Authenticate: pointer.BoolPtr(true),
+					Encryption:   pointer.BoolPtr(true),
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects encryption without authentication", func() {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.Security = &SparkClusterSecurity{
+					Encryption: pointer.BoolPtr(true),
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With dashboard auth", func() {
+			clusterWithDashboardAuth := func() *SparkCluster {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.EnableDashboard = pointer.BoolPtr(true)
+				rc.Spec.DashboardAuth = &DashboardAuthConfig{
+					Enabled:         pointer.BoolPtr(true),
+					IssuerURL:       "https://accounts.example.com",
+					ClientSecretRef: &v1.LocalObjectReference{Name: "oidc-client"},
+				}
+
+				return rc
+			}
+
+			It("defaults the proxy image and port", func() {
+				rc := clusterWithDashboardAuth()
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+
+				Expect(rc.Spec.DashboardAuth.Port).To(BeNumerically("==", 4180))
+				Expect(rc.Spec.DashboardAuth.Image).To(Equal(&OCIImageDefinition{
+					Registry:   "quay.io",
+					Repository: "oauth2-proxy/oauth2-proxy",
+					Tag:        "v7.1.3",
+				}))
+			})
+
+			It("passes with a local issuer when verification is skipped", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.DashboardAuth.IssuerURL = "http://oidc-stand-in:8080"
+				rc.Spec.DashboardAuth.InsecureSkipVerify = pointer.BoolPtr(true)
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects a plain http issuer", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.DashboardAuth.IssuerURL = "http://oidc-stand-in:8080"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires a client secret reference", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.DashboardAuth.ClientSecretRef = nil
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires the dashboard to be enabled", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.EnableDashboard = pointer.BoolPtr(false)
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects a proxy port equal to the dashboard port", func() {
+				rc := clusterWithDashboardAuth()
+				rc.Spec.DashboardAuth.Port = 8265
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With monitoring", func() {
+			clusterWithMonitoring := func() *SparkCluster {
+				sc := sparkFixture(testNS.Name)
+				sc.Spec.Monitoring = &MonitoringConfig{
+					Enabled:         pointer.BoolPtr(true),
+					NamespaceLabels: map[string]string{"monitoring": "true"},
+				}
+
+				return sc
+			}
+
+			It("defaults the scrape object kind", func() {
+				sc := clusterWithMonitoring()
+				Expect(k8sClient.Create(ctx, sc)).To(Succeed())
+
+				Expect(sc.Spec.Monitoring.Kind).To(Equal(MonitorKindServiceMonitor))
+			})
+
+			It("rejects an unsupported scrape object kind", func() {
+				sc := clusterWithMonitoring()
+				sc.Spec.Monitoring.Kind = "Probe"
+
+				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
+			})
+
+			It("rejects an invalid scrape interval", func() {
+				sc := clusterWithMonitoring()
+				sc.Spec.Monitoring.Interval = "-30s"
+
+				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
+			})
+
+			It("requires monitoring peers with network policies", func() {
+				sc := clusterWithMonitoring()
+				sc.Spec.Monitoring.NamespaceLabels = nil
+				sc.Spec.NetworkPolicy.Enabled = pointer.BoolPtr(true)
+
+				Expect(k8sClient.Create(ctx, sc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With network policy CIDRs", func() {
+			It("passes with valid blocks", func() {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.NetworkPolicy.ClientServerCIDRs = []string{"10.0.0.0/8"}
+				rc.Spec.NetworkPolicy.Egress = &NetworkPolicyEgress{
+					Enabled: pointer.BoolPtr(true),
+					CIDRs:   []string{"52.216.0.0/15"},
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("rejects invalid ingress blocks", func() {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.NetworkPolicy.DashboardCIDRs = []string{"10.0.0.1"}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects invalid egress blocks", func() {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.NetworkPolicy.Egress = &NetworkPolicyEgress{
+					CIDRs: []string{"garbage"},
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
+		Context("With driver connectivity enabled", func() {
+			clusterWithDriver := func() *SparkCluster {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.NetworkPolicy.Driver = &SparkDriverNetworkPolicy{
+					Enabled:      pointer.BoolPtr(true),
+					DriverLabels: map[string]string{"spark-driver": "true"},
+				}
+
+				return rc
+			}
+
+			It("passes with default ports", func() {
+				Expect(k8sClient.Create(ctx, clusterWithDriver())).To(Succeed())
+			})
+
+			It("rejects invalid ports", func() {
+				rc := clusterWithDriver()
+				rc.Spec.NetworkPolicy.Driver.WorkerPort = 80
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects port ranges beyond the maximum port", func() {
+				rc := clusterWithDriver()
+				rc.Spec.NetworkPolicy.Driver.BlockManagerPort = 65530
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects a worker port that overlaps with the cluster port", func() {
+				rc := clusterWithDriver()
+				rc.Spec.NetworkPolicy.Driver.WorkerPort = 7077
+
+				Expect(k8sClient.Create(ctx, rc)).To(
+					MatchError(ContainSubstring("spec.networkPolicy.driver.workerPort")),
+				)
+			})
+
+			It("rejects negative retries", func() {
+				rc := clusterWithDriver()
+				rc.Spec.NetworkPolicy.Driver.PortMaxRetries = pointer.Int32Ptr(-1)
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("requires driver labels", func() {
+				rc := clusterWithDriver()
+				rc.Spec.NetworkPolicy.ClientServerLabels = map[string]string{"spark-client": "true"}
+				rc.Spec.NetworkPolicy.Driver.DriverLabels = nil
+
+				Expect(k8sClient.Create(ctx, rc)).To(
+					MatchError(ContainSubstring("spec.networkPolicy.driver.driverLabels")),
+				)
+			})
+		})
+
+		Context("With external exposure", func() {
+			exposedCluster := func(typ ExposeType) *SparkCluster {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.Expose = &ExposeConfig{
+					Type:            typ,
+					Dashboard:       &ExposeEndpoint{Host: "spark.example.com"},
+					NamespaceLabels: map[string]string{"ingress": "true"},
+				}
+
+				return rc
+			}
+
+			It("requires exposure peers with network policies", func() {
+				rc := exposedCluster(ExposeTypeNodePort)
+				rc.Spec.Expose.NamespaceLabels = nil
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("passes with gateway routing", func() {
+				Expect(k8sClient.Create(ctx, exposedCluster(ExposeTypeIstioGateway))).To(Succeed())
+			})
+
+			It("passes with a node port", func() {
+				Expect(k8sClient.Create(ctx, exposedCluster(ExposeTypeNodePort))).To(Succeed())
+			})
+
+			It("rejects client routing", func() {
+				rc := exposedCluster(ExposeTypeIngress)
+				rc.Spec.Expose.Client = &ExposeEndpoint{Host: "client.example.com"}
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+		})
+
 		Context("With autoscaling enabled", func() {
 			clusterWithAutoscaling := func() *SparkCluster {
 				rc := sparkFixture(testNS.Name)
@@ -234,6 +778,17 @@ var _ = Describe("SparkCluster", func() {
 				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
 			})
 
+			It("requires worker replicas within the autoscaling bounds", func() {
+				rc := clusterWithAutoscaling()
+				rc.Spec.Autoscaling.MaxReplicas = 2
+
+				rc.Spec.Worker.Replicas = pointer.Int32Ptr(3)
+				Expect(k8sClient.Create(ctx, rc)).To(MatchError(ContainSubstring("spec.worker.replicas")))
+
+				rc.Spec.Worker.Replicas = pointer.Int32Ptr(2)
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
 			It("requires max replicas to be > 0", func() {
 				rc := clusterWithAutoscaling()
 				rc.Spec.Autoscaling.MaxReplicas = 0
@@ -279,6 +834,54 @@ var _ = Describe("SparkCluster", func() {
 
 				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
 			})
+
+			It("rejects an unknown backend", func() {
+				rc := clusterWithAutoscaling()
+				rc.Spec.Autoscaling.Backend = "garbage"
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("allows min replicas of 0 with the keda backend", func() {
+				rc := clusterWithAutoscaling()
+				rc.Spec.Autoscaling.Backend = AutoscalingBackendKEDA
+				rc.Spec.Autoscaling.MinReplicas = pointer.Int32Ptr(0)
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("requires triggers with the keda backend when the dashboard is disabled", func() {
+				rc := clusterWithAutoscaling()
+				rc.Spec.Autoscaling.Backend = AutoscalingBackendKEDA
+				rc.Spec.EnableDashboard = pointer.BoolPtr(false)
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("allows the spark-master backend without worker cpu requests", func() {
+				rc := sparkFixture(testNS.Name)
+				rc.Spec.Autoscaling = &Autoscaling{
+					Backend:     AutoscalingBackendSparkMaster,
+					MaxReplicas: 3,
+				}
+
+				Expect(k8sClient.Create(ctx, rc)).To(Succeed())
+			})
+
+			It("requires the dashboard with the spark-master backend", func() {
+				rc := clusterWithAutoscaling()
+				rc.Spec.Autoscaling.Backend = AutoscalingBackendSparkMaster
+				rc.Spec.EnableDashboard = pointer.BoolPtr(false)
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
+
+			It("rejects a negative scale up stabilization window", func() {
+				rc := clusterWithAutoscaling()
+				rc.Spec.Autoscaling.ScaleUpStabilizationWindowSeconds = pointer.Int32Ptr(-1)
+
+				Expect(k8sClient.Create(ctx, rc)).ToNot(Succeed())
+			})
 		})
 	})
 })
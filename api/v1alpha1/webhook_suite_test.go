@@ -94,9 +94,9 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = (&RayCluster{}).SetupWebhookWithManager(mgr)
+	err = (&RayCluster{}).SetupWebhookWithManager(mgr, ControllerSelector{}, nil)
 	Expect(err).NotTo(HaveOccurred())
-	err = (&SparkCluster{}).SetupWebhookWithManager(mgr)
+	err = (&SparkCluster{}).SetupWebhookWithManager(mgr, ControllerSelector{}, nil)
 	Expect(err).NotTo(HaveOccurred())
 
 	//+kubebuilder:scaffold:webhook
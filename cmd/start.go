@@ -2,19 +2,35 @@ package cmd
 
 import (
 	"flag"
+	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	configv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/config/v1alpha1"
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/manager"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/podsecurity"
 )
 
 var (
-	namespace            string
+	configFile           string
+	namespaces           []string
+	namespaceSelector    string
+	controllerClass      string
+	watchLabelSelector   string
 	probeAddr            string
 	metricsAddr          string
 	webhookPort          int
 	enableLeaderElection bool
+	kedaEnabled          bool
+	kedaNamespace        string
+	operatorNamespace    string
+	queueingEnabled      bool
+	podSecurityBackend   string
+	serverSideApply      bool
 
 	zapOpts = zap.Options{}
 )
@@ -23,13 +39,38 @@ var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start the controller manager",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if configFile != "" {
+			oc, err := manager.LoadConfigFile(configFile)
+			if err != nil {
+				return err
+			}
+			if err = applyConfigFile(cmd.Flags(), oc); err != nil {
+				return err
+			}
+		}
+
+		backend, err := podsecurity.ParseBackend(podSecurityBackend)
+		if err != nil {
+			return err
+		}
+
 		cfg := &manager.Config{
-			Namespace:            namespace,
+			ConfigFile:           configFile,
+			Namespaces:           namespaces,
+			NamespaceSelector:    namespaceSelector,
+			ControllerClass:      controllerClass,
+			WatchLabelSelector:   watchLabelSelector,
 			MetricsAddr:          metricsAddr,
 			HealthProbeAddr:      probeAddr,
 			WebhookServerPort:    webhookPort,
 			EnableLeaderElection: enableLeaderElection,
 			IstioEnabled:         istioEnabled,
+			KEDAEnabled:          kedaEnabled,
+			KEDANamespace:        kedaNamespace,
+			OperatorNamespace:    operatorNamespace,
+			QueueingEnabled:      queueingEnabled,
+			PodSecurityBackend:   backend,
+			ServerSideApply:      serverSideApply,
 			ZapOptions:           zapOpts,
 		}
 
@@ -44,7 +85,16 @@ func init() {
 	zapOpts.BindFlags(fs)
 
 	startCmd.Flags().AddGoFlagSet(fs)
-	startCmd.Flags().StringVar(&namespace, "namespace", "default", "Reconcile clusters resources in this namespace")
+	startCmd.Flags().StringVar(&configFile, "config", "",
+		"Load manager options, features and cluster defaults from this operator configuration file")
+	startCmd.Flags().StringSliceVar(&namespaces, "namespace", []string{"default"},
+		"Reconcile cluster resources in this comma-separated list of namespaces, or in every namespace when empty")
+	startCmd.Flags().StringVar(&namespaceSelector, "namespace-selector", "",
+		"Only reconcile cluster resources in namespaces whose labels match this selector")
+	startCmd.Flags().StringVar(&controllerClass, "controller-class", dcv1alpha1.DefaultControllerClass,
+		"Only reconcile cluster resources whose spec.controllerClass matches, unset classes match the default class")
+	startCmd.Flags().StringVar(&watchLabelSelector, "watch-label-selector", "",
+		"Only reconcile cluster resources whose labels match this selector")
 	startCmd.Flags().IntVar(&webhookPort, "webhook-server-port", 9443, "Webhook server will bind to this port")
 	startCmd.Flags().StringVar(&metricsAddr, "metrics-bind-address", ":8080",
 		"Metrics endpoint will bind to this address")
@@ -52,6 +102,68 @@ func init() {
 		"Health probe endpoint will bind to this address")
 	startCmd.Flags().BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election to ensure there is only one active controller manager")
+	startCmd.Flags().BoolVar(&kedaEnabled, "keda-enabled", false,
+		"Enable support for the KEDA autoscaling backend")
+	startCmd.Flags().StringVar(&kedaNamespace, "keda-namespace", "keda",
+		"Namespace that KEDA runs in, admitted by cluster network policies when KEDA polls the spark master")
+	startCmd.Flags().StringVar(&operatorNamespace, "operator-namespace", "",
+		"Namespace that the operator runs in, admitted by cluster network policies when it polls the spark master")
+	startCmd.Flags().BoolVar(&queueingEnabled, "queueing-enabled", false,
+		"Hold new clusters in a queue until there is enough resource capacity to run them")
+	startCmd.Flags().StringVar(&podSecurityBackend, "pod-security-backend", string(podsecurity.BackendPodSecurityPolicy),
+		"Mechanism used to govern cluster pod security: psp, pod-security-admission or scc")
+	startCmd.Flags().BoolVar(&serverSideApply, "server-side-apply", false,
+		"Manage resources owned by clusters with server-side apply instead of last-applied annotations")
 
 	rootCmd.AddCommand(startCmd)
 }
+
+// applyConfigFile sets the flags that are not given on the command line to
+// the values of the operator configuration file. These values are only read
+// here, so changing them in the file requires a restart.
+func applyConfigFile(fs *pflag.FlagSet, oc *configv1alpha1.OperatorConfig) error {
+	values := map[string]string{}
+	if oc.CacheNamespace != "" {
+		values["namespace"] = oc.CacheNamespace
+	}
+	if oc.Metrics.BindAddress != "" {
+		values["metrics-bind-address"] = oc.Metrics.BindAddress
+	}
+	if oc.Health.HealthProbeBindAddress != "" {
+		values["health-probe-bind-address"] = oc.Health.HealthProbeBindAddress
+	}
+	if oc.Webhook.Port != nil {
+		values["webhook-server-port"] = strconv.Itoa(*oc.Webhook.Port)
+	}
+	if le := oc.LeaderElection; le != nil && le.LeaderElect != nil {
+		values["leader-elect"] = strconv.FormatBool(*le.LeaderElect)
+	}
+
+	features := oc.Features
+	if features.Istio {
+		values["istio-enabled"] = "true"
+	}
+	if features.KEDA {
+		values["keda-enabled"] = "true"
+	}
+	if features.Queueing {
+		values["queueing-enabled"] = "true"
+	}
+	if features.ServerSideApply {
+		values["server-side-apply"] = "true"
+	}
+	if features.PodSecurityBackend != "" {
+		values["pod-security-backend"] = features.PodSecurityBackend
+	}
+
+	for name, value := range values {
+		if fs.Changed(name) {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid config file value for %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
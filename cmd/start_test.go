@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/config/v1alpha1"
+)
+
+func TestApplyConfigFile(t *testing.T) {
+	oc := &configv1alpha1.OperatorConfig{}
+	oc.Metrics.BindAddress = ":9090"
+	oc.Health.HealthProbeBindAddress = ":9091"
+	oc.Features.KEDA = true
+
+	var metrics, probe string
+	var keda bool
+	fs := pflag.NewFlagSet("start", pflag.ContinueOnError)
+	fs.StringVar(&metrics, "metrics-bind-address", ":8080", "")
+	fs.StringVar(&probe, "health-probe-bind-address", ":8081", "")
+	fs.BoolVar(&keda, "keda-enabled", false, "")
+	require.NoError(t, fs.Parse([]string{"--health-probe-bind-address=:7070"}))
+
+	require.NoError(t, applyConfigFile(fs, oc))
+	assert.Equal(t, ":9090", metrics)
+	assert.Equal(t, ":7070", probe, "command line flags should take precedence")
+	assert.True(t, keda)
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+)
+
+var _ = Describe("Server-side apply", func() {
+	ctx := context.Background()
+	timeout := time.Second * 10
+	gvk := corev1.SchemeGroupVersion.WithKind("Service")
+
+	var owner *corev1.ConfigMap
+	var recorder *record.FakeRecorder
+
+	BeforeEach(func() {
+		owner = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "ssa-owner-",
+				Namespace:    "default",
+			},
+		}
+		Expect(k8sClient.Create(ctx, owner)).To(Succeed())
+
+		recorder = record.NewFakeRecorder(100)
+	})
+
+	apply := func(svc *corev1.Service) error {
+		c := &core.Context{
+			Context:         ctx,
+			Log:             logf.Log.WithName("apply"),
+			Client:          k8sClient,
+			Scheme:          scheme.Scheme,
+			Recorder:        recorder,
+			Object:          owner,
+			Kind:            "RayCluster",
+			ServerSideApply: true,
+		}
+		return c.CreateOrUpdateOwnedResource(svc)
+	}
+	events := func() []string {
+		var recorded []string
+		for {
+			select {
+			case event := <-recorder.Events:
+				recorded = append(recorded, event)
+			default:
+				return recorded
+			}
+		}
+	}
+	newService := func(name string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{"tier": "desired"},
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+			},
+		}
+	}
+	getService := func(name string) *corev1.Service {
+		svc := &corev1.Service{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, svc)).To(Succeed())
+		return svc
+	}
+
+	It("should create and update objects with the operator field manager", func() {
+		Expect(apply(newService("ssa-create"))).To(Succeed())
+		Expect(events()).To(ConsistOf("Normal Created Created Service ssa-create"))
+
+		Eventually(func() []string {
+			var managers []string
+			for _, entry := range getService("ssa-create").ManagedFields {
+				managers = append(managers, entry.Manager)
+			}
+			return managers
+		}, timeout).Should(ContainElement(core.FieldManager))
+
+		Eventually(func() []string {
+			Expect(apply(newService("ssa-create"))).To(Succeed())
+			return events()
+		}, timeout).Should(BeEmpty())
+	})
+
+	It("should correct drift introduced by other field managers", func() {
+		Expect(apply(newService("ssa-drift"))).To(Succeed())
+
+		drift := &unstructured.Unstructured{}
+		drift.SetGroupVersionKind(gvk)
+		drift.SetNamespace("default")
+		drift.SetName("ssa-drift")
+		drift.SetLabels(map[string]string{"tier": "drifted"})
+		Expect(k8sClient.Patch(ctx, drift, client.Apply, client.FieldOwner("other"), client.ForceOwnership)).To(Succeed())
+
+		Eventually(func() string {
+			return getService("ssa-drift").Labels["tier"]
+		}, timeout).Should(Equal("drifted"))
+
+		Expect(apply(newService("ssa-drift"))).To(Succeed())
+		Expect(events()).To(ContainElement("Normal Updated Updated Service ssa-drift"))
+
+		Eventually(func() string {
+			return getService("ssa-drift").Labels["tier"]
+		}, timeout).Should(Equal("desired"))
+	})
+
+	It("should migrate objects off the last-applied annotations", func() {
+		svc := newService("ssa-migrate")
+		svc.Annotations = map[string]string{core.LastAppliedAnnotation: "legacy"}
+		Expect(k8sClient.Create(ctx, svc)).To(Succeed())
+
+		// the annotations are only removed once the object is in the cache
+		Eventually(func() map[string]string {
+			Expect(apply(newService("ssa-migrate"))).To(Succeed())
+
+			return getService("ssa-migrate").Annotations
+		}, timeout).ShouldNot(HaveKey(core.LastAppliedAnnotation))
+	})
+})
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/admission"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/metrics"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/expose"
+)
+
+// admissionStatus references the admission fields in the status of a
+// cluster object.
+type admissionStatus struct {
+	phase    *dcv1alpha1.ClusterPhase
+	position *int32
+	message  *string
+}
+
+// admissionGate holds new clusters in the "Queued" phase until the admitter
+// decides there is enough capacity to run them and records the outcome in
+// their status. Admitted clusters are never re-queued.
+func admissionGate(admitter *admission.Admitter, statusFor func(client.Object) admissionStatus) core.GateFunc {
+	return func(ctx *core.Context) (bool, error) {
+		status := statusFor(ctx.Object)
+		if *status.phase == dcv1alpha1.ClusterPhaseAdmitted {
+			return true, nil
+		}
+
+		decision, err := admitter.Evaluate(ctx, ctx.Object)
+		if err != nil {
+			return false, err
+		}
+
+		phase := dcv1alpha1.ClusterPhaseQueued
+		if decision.Admit {
+			phase = dcv1alpha1.ClusterPhaseAdmitted
+		}
+
+		if *status.phase == phase && *status.position == decision.Position && *status.message == decision.Message {
+			return decision.Admit, nil
+		}
+
+		ctx.Log.Info("modifying admission status", "phase", phase, "position", decision.Position, "message", decision.Message)
+
+		*status.phase = phase
+		*status.position = decision.Position
+		*status.message = decision.Message
+
+		return decision.Admit, ctx.Client.Status().Update(ctx, ctx.Object)
+	}
+}
+
+// clusterState reports the lifecycle phase and worker readiness of a cluster
+// using its head and worker stateful sets. The cluster is ready once the head
+// and the desired number of workers are ready.
+func clusterState(
+	ctx *core.Context,
+	phase dcv1alpha1.ClusterPhase,
+	workerReplicas *int32,
+	headName, workerName string,
+) (metrics.ClusterState, error) {
+	obj := ctx.Object
+
+	head := &appsv1.StatefulSet{}
+	headKey := types.NamespacedName{Namespace: obj.GetNamespace(), Name: headName}
+	if err := ctx.Client.Get(ctx, headKey, head); client.IgnoreNotFound(err) != nil {
+		return metrics.ClusterState{}, err
+	}
+
+	worker := &appsv1.StatefulSet{}
+	workerKey := types.NamespacedName{Namespace: obj.GetNamespace(), Name: workerName}
+	if err := ctx.Client.Get(ctx, workerKey, worker); client.IgnoreNotFound(err) != nil {
+		return metrics.ClusterState{}, err
+	}
+
+	desired := pointer.Int32PtrDerefOr(worker.Spec.Replicas, pointer.Int32PtrDerefOr(workerReplicas, 0))
+	ready := head.Status.ReadyReplicas > 0 && worker.Status.ReadyReplicas >= desired
+
+	return metrics.ClusterState{
+		Phase:           metrics.Phase(!obj.GetDeletionTimestamp().IsZero(), phase, ready),
+		DesiredReplicas: desired,
+		ReadyReplicas:   worker.Status.ReadyReplicas,
+		Created:         obj.GetCreationTimestamp().Time,
+	}, nil
+}
+
+// listPodNames returns the sorted names of the cluster pods that match the
+// given labels.
+func listPodNames(ctx *core.Context, labels map[string]string) ([]string, error) {
+	podList := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(ctx.Object.GetNamespace()),
+		client.MatchingLabels(labels),
+	}
+	if err := ctx.Client.List(ctx, podList, listOpts...); err != nil {
+		return nil, err
+	}
+
+	var podNames []string
+	for _, pod := range podList.Items {
+		podNames = append(podNames, pod.Name)
+	}
+	sort.Strings(podNames)
+
+	return podNames, nil
+}
+
+// listPodIPs returns the sorted IPs of the cluster pods that match the given
+// labels. Pods that have not been assigned an IP are skipped.
+func listPodIPs(ctx *core.Context, labels map[string]string) ([]string, error) {
+	podList := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(ctx.Object.GetNamespace()),
+		client.MatchingLabels(labels),
+	}
+	if err := ctx.Client.List(ctx, podList, listOpts...); err != nil {
+		return nil, err
+	}
+
+	var podIPs []string
+	for _, pod := range podList.Items {
+		if pod.Status.PodIP != "" {
+			podIPs = append(podIPs, pod.Status.PodIP)
+		}
+	}
+	sort.Strings(podIPs)
+
+	return podIPs, nil
+}
+
+// nodePortEndpoint returns the address of the node running the head pod and
+// the node ports allocated to the head service. Node ports are served by
+// every node, so the head node is merely a stable choice. An empty address is
+// returned until the head pod has been scheduled.
+func nodePortEndpoint(ctx *core.Context, service string, headLabels map[string]string) (string, map[int32]int32, error) {
+	svc := &corev1.Service{}
+	key := types.NamespacedName{Namespace: ctx.Object.GetNamespace(), Name: service}
+	if err := ctx.Client.Get(ctx, key, svc); client.IgnoreNotFound(err) != nil {
+		return "", nil, err
+	}
+
+	podList := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(ctx.Object.GetNamespace()),
+		client.MatchingLabels(headLabels),
+	}
+	if err := ctx.Client.List(ctx, podList, listOpts...); err != nil {
+		return "", nil, err
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.HostIP != "" {
+			return pod.Status.HostIP, expose.NodePorts(svc), nil
+		}
+	}
+
+	return "", nil, nil
+}
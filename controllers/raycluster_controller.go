@@ -1,13 +1,11 @@
 package controllers
 
 import (
-	"context"
 	"fmt"
 	"reflect"
-	"sort"
-	"strings"
 	"time"
 
+	istionetworking "istio.io/client-go/pkg/apis/networking/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
@@ -16,19 +14,27 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/admission"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/logging"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/metrics"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/podsecurity"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/certmanager"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/expose"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/netpol"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/ray"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
@@ -39,142 +45,261 @@ type RayClusterReconciler struct {
 	Log          logging.ContextLogger
 	Scheme       *runtime.Scheme
 	IstioEnabled bool
-}
+	KEDAEnabled  bool
 
-// nolint:dupl
-// SetupWithManager creates and registers this controller with the manager.
-func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&dcv1alpha1.RayCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.ServiceAccount{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&rbacv1.Role{}).
-		Owns(&rbacv1.RoleBinding{}).
-		Owns(&networkingv1.NetworkPolicy{}).
-		Owns(&autoscalingv2beta2.HorizontalPodAutoscaler{}).
-		Complete(r)
-}
+	// Recorder publishes events that describe reconciliation actions on
+	// cluster objects.
+	Recorder record.EventRecorder
 
-//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=rayclusters/status,verbs=get;update;patch
-//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=rayclusters/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=pods,verbs=list;watch
-//+kubebuilder:rbac:groups="",resources=services;serviceaccounts,verbs=create;update;list;watch
-//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=create;update;list;watch
-//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=create;update;delete;list;watch
-//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;update;delete;list;watch
-//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=create;update;delete;list;watch
-
-// Reconcile implements state reconciliation logic for RayCluster objects.
-func (r *RayClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	ctx, log := r.Log.NewContext(ctx, "raycluster", req.NamespacedName)
-
-	log.V(2).Info("reconciliation loop triggered")
-
-	rc := &dcv1alpha1.RayCluster{}
-	if err := r.Get(ctx, req.NamespacedName, rc); err != nil {
-		if apierrors.IsNotFound(err) {
-			log.Info("resource not found, assuming object was deleted")
-			return ctrl.Result{}, nil
-		}
+	// ServerSideApply manages owned resources with server-side apply instead
+	// of patch-based updates that rely on last-applied annotations.
+	ServerSideApply bool
 
-		log.Error(err, "failed to retrieve resource")
-		return ctrl.Result{}, err
-	}
+	// NamespaceSelector restricts reconciliation to clusters in namespaces
+	// whose labels match. Every watched namespace is selected when this is nil.
+	NamespaceSelector labels.Selector
 
-	if updated, err := r.manageFinalization(ctx, rc); err != nil {
-		return ctrl.Result{}, err
-	} else if updated {
-		return ctrl.Result{Requeue: true}, nil
-	}
+	// ControllerSelector restricts reconciliation to the clusters managed by
+	// this operator instance.
+	ControllerSelector dcv1alpha1.ControllerSelector
 
-	if err := r.reconcileResources(ctx, rc); err != nil {
-		log.Error(err, "failed to reconcile cluster resources")
-		return ctrl.Result{}, err
-	}
+	// PodSecurityBackend selects how cluster pods are granted access to the
+	// platform pod security mechanism.
+	PodSecurityBackend podsecurity.Backend
 
-	if err := r.updateStatus(ctx, rc); err != nil {
-		if strings.Contains(err.Error(), genericregistry.OptimisticLockErrorMsg) {
-			log.V(1).Info("cannot update status on modified object, requeuing key for reprocessing")
-			return ctrl.Result{RequeueAfter: 500 * time.Millisecond}, nil
-		}
+	// Admitter holds new clusters in the "Queued" phase until there is enough
+	// capacity to run them. Clusters are admitted immediately when this is nil.
+	Admitter *admission.Admitter
 
-		log.Error(err, "failed to update cluster status")
-		return ctrl.Result{}, err
-	}
+	// Defaults supplies the operator defaults used to build cluster
+	// resources. The built-in defaults are used when this is nil.
+	Defaults dcv1alpha1.ClusterDefaultsSource
 
-	return ctrl.Result{}, nil
+	// shared reconciles the resources that ray clusters manage like every
+	// other cluster kind.
+	shared *core.ClusterComponents
+	// certManager is set when the cert-manager Certificate API is installed.
+	certManager bool
 }
 
-// manageFinalization will add a finalizer to new ray cluster resources if it's
-// absent and remove it during a delete request after performing the required
-// finalization steps.
-func (r *RayClusterReconciler) manageFinalization(ctx context.Context, rc *dcv1alpha1.RayCluster) (bool, error) {
-	log := r.Log.FromContext(ctx)
-	registered := controllerutil.ContainsFinalizer(rc, DistributedComputeFinalizer)
-
-	if rc.GetDeletionTimestamp().IsZero() && !registered {
-		log.V(1).Info("registering finalizer", "name", DistributedComputeFinalizer)
-		controllerutil.AddFinalizer(rc, DistributedComputeFinalizer)
-
-		if err := r.Update(ctx, rc); err != nil {
-			log.Error(err, "failed to register finalizer")
-			return false, err
+// SetupWithManager creates and registers this controller with the manager.
+func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := core.NewReconciler(mgr, &dcv1alpha1.RayCluster{}, core.Options{
+		Client:             r.Client,
+		Scheme:             r.Scheme,
+		Log:                r.Log,
+		Recorder:           r.Recorder,
+		ServerSideApply:    r.ServerSideApply,
+		NamespaceSelector:  r.NamespaceSelector,
+		ControllerSelector: r.ControllerSelector,
+	}, builder.WithPredicates(predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+	)))
+	r.shared = &core.ClusterComponents{
+		Resources:          r.clusterResources(),
+		IstioEnabled:       r.IstioEnabled,
+		KEDAEnabled:        r.KEDAEnabled,
+		PodSecurityBackend: r.PodSecurityBackend,
+	}
+	r.shared.Own(mgr, b)
+
+	r.certManager = certmanager.Available(mgr.GetRESTMapper())
+	if r.certManager {
+		b.Owns(certmanager.NewCertificateReference("", ""))
+	}
+	b.WatchesPodAddresses(func(pod *corev1.Pod) (string, bool) {
+		if pod.Labels[resources.ApplicationNameLabelKey] != ray.ApplicationName {
+			return "", false
 		}
+		name, ok := pod.Labels[resources.ApplicationInstanceLabelKey]
+		return name, ok
+	})
 
-		return true, nil
-	}
+	b.Finalizer(DistributedComputeFinalizer, core.DeletePersistentVolumeClaims(func(obj client.Object) map[string]string {
+		return ray.SelectorLabels(obj.(*dcv1alpha1.RayCluster))
+	}))
+	if r.Admitter != nil {
+		b.Gate("admit", admissionRetryInterval, admissionGate(r.Admitter, func(obj client.Object) admissionStatus {
+			status := &obj.(*dcv1alpha1.RayCluster).Status
+			return admissionStatus{&status.Phase, &status.QueuePosition, &status.QueueMessage}
+		}))
+	}
+
+	b.Component("reconcileIstio", rayComponent(r.reconcileIstio)).
+		Component("reconcileServiceAccount", rayComponent(r.reconcileServiceAccount)).
+		Component("reconcileServices", rayComponent(r.reconcileServices)).
+		Component("reconcileExpose", core.ComponentFunc(r.shared.ReconcileExpose)).
+		Component("reconcileNetworkPolicies", rayComponent(r.reconcileNetworkPolicies)).
+		Component("reconcileMonitoring", core.ComponentFunc(r.shared.ReconcileMonitoring)).
+		Component("reconcilePodSecurity", core.ComponentFunc(r.shared.ReconcilePodSecurity)).
+		Component("reconcilePodDisruptionBudgets", core.ComponentFunc(r.shared.ReconcilePodDisruptionBudgets)).
+		Component("reconcileAutoscaler", core.ComponentFunc(r.shared.ReconcileAutoscaler)).
+		Component("reconcilePodGroup", core.ComponentFunc(r.shared.ReconcilePodGroup)).
+		Component("reconcileTLS", rayComponent(r.reconcileTLS)).
+		Component("reconcileRedisPassword", rayComponent(r.reconcileRedisPassword)).
+		Component("reconcileStatefulSets", rayComponent(r.reconcileStatefulSets))
+
+	b.StatusModifier("nodes", rayStatusModifier(r.modifyStatusNodes)).
+		StatusModifier("worker fields", rayStatusModifier(r.modifyStatusWorkerFields)).
+		StatusModifier("urls", rayStatusModifier(r.modifyStatusURLs)).
+		StatusModifier("pod security violations", core.StatusModifierFunc(r.shared.ModifyStatusPodSecurity)).
+		StatusModifier("redis password", rayStatusModifier(r.modifyStatusRedisPassword))
+
+	b.Result(func(ctx *core.Context) (ctrl.Result, error) {
+		renewAfter, err := r.tlsRenewalInterval(ctx, ctx.Object.(*dcv1alpha1.RayCluster))
+		return ctrl.Result{RequeueAfter: renewAfter}, err
+	})
+	b.ClusterState(func(ctx *core.Context) (metrics.ClusterState, error) {
+		rc := ctx.Object.(*dcv1alpha1.RayCluster)
+		return clusterState(ctx, rc.Status.Phase, rc.Spec.Worker.Replicas,
+			ray.InstanceObjectName(rc.Name, ray.ComponentHead), ray.InstanceObjectName(rc.Name, ray.ComponentWorker))
+	})
 
-	if rc.GetDeletionTimestamp() != nil && registered {
-		log.Info("executing finalization steps")
-		if err := r.deleteExternalStorage(ctx, rc); err != nil {
-			log.Error(err, "failed to clean up storage")
-			return false, err
-		}
+	return b.Complete()
+}
 
-		log.V(1).Info("removing finalizer", "name", DistributedComputeFinalizer)
-		controllerutil.RemoveFinalizer(rc, DistributedComputeFinalizer)
+// clusterDefaults returns the operator defaults used to build the resources
+// of ray clusters.
+func (r *RayClusterReconciler) clusterDefaults() dcv1alpha1.RayClusterDefaults {
+	if r.Defaults == nil {
+		return dcv1alpha1.NewClusterDefaults().Ray
+	}
+	return r.Defaults.ClusterDefaults().Ray
+}
 
-		if err := r.Update(ctx, rc); err != nil {
-			log.Error(err, "failed to remove finalizer")
-			return false, err
-		}
+// clusterResources returns the builders of the ray resources managed by the
+// components shared with other cluster kinds.
+func (r *RayClusterReconciler) clusterResources() core.ClusterResources {
+	return core.ClusterResources{
+		Spec: func(obj client.Object) core.ClusterSpec {
+			rc := obj.(*dcv1alpha1.RayCluster)
+			return core.ClusterSpec{
+				Expose:                     rc.Spec.Expose,
+				NetworkPolicyEnabled:       util.BoolPtrIsTrue(rc.Spec.NetworkPolicy.Enabled),
+				Monitoring:                 rc.Spec.Monitoring,
+				Scheduling:                 rc.Spec.Scheduling,
+				PodDisruptionBudgetEnabled: util.BoolPtrIsTrue(rc.Spec.PodDisruptionBudget.Enabled),
+				Autoscaling:                rc.Spec.Autoscaling,
+				PodSecurityPolicy:          rc.Spec.PodSecurityPolicy,
+				SecurityContextConstraints: rc.Spec.SecurityContextConstraints,
+			}
+		},
+		PodSecurityViolations: func(obj client.Object) *[]string {
+			return &obj.(*dcv1alpha1.RayCluster).Status.PodSecurityViolations
+		},
+		ExposeObjectMeta: func(obj client.Object) metav1.ObjectMeta {
+			return ray.ExposeObjectMeta(obj.(*dcv1alpha1.RayCluster))
+		},
+		Ingress: func(obj client.Object) *networkingv1.Ingress {
+			return ray.NewIngress(obj.(*dcv1alpha1.RayCluster))
+		},
+		Gateway: func(obj client.Object) *istionetworking.Gateway {
+			return ray.NewGateway(obj.(*dcv1alpha1.RayCluster))
+		},
+		VirtualService: func(obj client.Object) *istionetworking.VirtualService {
+			return ray.NewVirtualService(obj.(*dcv1alpha1.RayCluster))
+		},
+		MonitoringNetworkPolicy: func(obj client.Object) *networkingv1.NetworkPolicy {
+			return ray.NewMonitoringNetworkPolicy(obj.(*dcv1alpha1.RayCluster))
+		},
+		Monitor: func(obj client.Object) *unstructured.Unstructured {
+			return ray.NewMonitor(obj.(*dcv1alpha1.RayCluster))
+		},
+		MonitorReference: func(obj client.Object, kind dcv1alpha1.MonitorKind) *unstructured.Unstructured {
+			return ray.MonitorReference(obj.(*dcv1alpha1.RayCluster), kind)
+		},
+		PodSecurityPolicyRBAC: func(obj client.Object) (*rbacv1.Role, *rbacv1.RoleBinding) {
+			return ray.NewPodSecurityPolicyRBAC(obj.(*dcv1alpha1.RayCluster))
+		},
+		SecurityContextConstraintsRBAC: func(obj client.Object) (*rbacv1.Role, *rbacv1.RoleBinding) {
+			return ray.NewSecurityContextConstraintsRBAC(obj.(*dcv1alpha1.RayCluster))
+		},
+		PodDisruptionBudgets: func(obj client.Object) []*policyv1beta1.PodDisruptionBudget {
+			head, worker := ray.NewPodDisruptionBudgets(obj.(*dcv1alpha1.RayCluster))
+			return []*policyv1beta1.PodDisruptionBudget{head, worker}
+		},
+		HorizontalPodAutoscalerObjectMeta: func(obj client.Object) metav1.ObjectMeta {
+			return ray.HorizontalPodAutoscalerObjectMeta(obj.(*dcv1alpha1.RayCluster))
+		},
+		HorizontalPodAutoscaler: func(obj client.Object) (*autoscalingv2beta2.HorizontalPodAutoscaler, error) {
+			return ray.NewHorizontalPodAutoscaler(obj.(*dcv1alpha1.RayCluster))
+		},
+		ScaledObject: func(obj client.Object) (*unstructured.Unstructured, error) {
+			return ray.NewScaledObject(obj.(*dcv1alpha1.RayCluster))
+		},
+		ScaledObjectReference: func(obj client.Object) *unstructured.Unstructured {
+			return ray.ScaledObjectReference(obj.(*dcv1alpha1.RayCluster))
+		},
+		PodGroup: func(obj client.Object) (*unstructured.Unstructured, error) {
+			return ray.NewPodGroup(obj.(*dcv1alpha1.RayCluster))
+		},
+		PodGroupReference: func(obj client.Object, flavor dcv1alpha1.PodGroupFlavor) *unstructured.Unstructured {
+			return ray.PodGroupReference(obj.(*dcv1alpha1.RayCluster), flavor)
+		},
+		StatefulSets: func(obj client.Object) ([]*appsv1.StatefulSet, error) {
+			rc := obj.(*dcv1alpha1.RayCluster)
+			defaults := r.clusterDefaults()
+
+			var sets []*appsv1.StatefulSet
+			for _, comp := range []ray.Component{ray.ComponentHead, ray.ComponentWorker} {
+				sts, err := ray.NewStatefulSet(rc, comp, defaults)
+				if err != nil {
+					return nil, err
+				}
+				r.addSidecarAnnotations(rc, sts)
+				sets = append(sets, sts)
+			}
 
-		return true, nil
+			return sets, nil
+		},
 	}
-
-	return false, nil
 }
 
-// nolint:dupl
-// reconcileResources manages the creation and updates of resources that
-// collectively comprise a Ray cluster. Each resource is controlled by a parent
-// RayCluster object so that full cleanup occurs during a delete operation.
-func (r *RayClusterReconciler) reconcileResources(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
-	if err := r.reconcileIstio(ctx, rc); err != nil {
-		return err
-	}
-	if err := r.reconcileServiceAccount(ctx, rc); err != nil {
-		return err
-	}
-	if err := r.reconcileServices(ctx, rc); err != nil {
-		return err
-	}
-	if err := r.reconcileNetworkPolicies(ctx, rc); err != nil {
-		return err
-	}
-	if err := r.reconcilePodSecurityPolicyRBAC(ctx, rc); err != nil {
-		return err
-	}
-	if err := r.reconcileAutoscaler(ctx, rc); err != nil {
-		return err
+// rayComponent adapts a method that reconciles part of a Ray cluster to a
+// core.Component.
+func rayComponent(fn func(*core.Context, *dcv1alpha1.RayCluster) error) core.ComponentFunc {
+	return func(ctx *core.Context) error {
+		return fn(ctx, ctx.Object.(*dcv1alpha1.RayCluster))
 	}
+}
 
-	return r.reconcileStatefulSets(ctx, rc)
+// rayStatusModifier adapts a method that modifies the status of a Ray cluster
+// to a core.StatusModifier.
+func rayStatusModifier(fn func(*core.Context, *dcv1alpha1.RayCluster) (bool, error)) core.StatusModifierFunc {
+	return func(ctx *core.Context) (bool, error) {
+		return fn(ctx, ctx.Object.(*dcv1alpha1.RayCluster))
+	}
 }
 
-func (r *RayClusterReconciler) reconcileIstio(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=rayclusters/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=rayclusters/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=services;serviceaccounts,verbs=create;update;patch;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=create;update;patch;list;watch
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies;ingresses,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=networking.istio.io,resources=gateways;virtualservices,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=security.istio.io,resources=peerauthentications;authorizationpolicies,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=scheduling.sigs.k8s.io;scheduling.volcano.sh,resources=podgroups,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=clusterqueues,verbs=list;watch
+//+kubebuilder:rbac:groups="",resources=resourcequotas,verbs=list;watch
+//+kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,verbs=use;get;list;watch
+
+// reconcileIstio optionally creates a peer authentication that sets the mTLS
+// mode for cluster workloads and an authorization policy that mirrors the
+// cluster network policies.
+func (r *RayClusterReconciler) reconcileIstio(ctx *core.Context, rc *dcv1alpha1.RayCluster) error {
 	if !r.IstioEnabled {
 		return nil
 	}
@@ -188,24 +313,34 @@ func (r *RayClusterReconciler) reconcileIstio(ctx context.Context, rc *dcv1alpha
 	})
 
 	if rc.Spec.IstioConfig.MutualTLSMode == "" {
-		return r.deleteIfExists(ctx, peerAuth)
-	}
-	if err := r.createOrUpdateOwnedResource(ctx, rc, peerAuth); err != nil {
+		if err := ctx.DeleteIfExists(peerAuth); err != nil {
+			return err
+		}
+	} else if err := ctx.CreateOrUpdateOwnedResource(peerAuth); err != nil {
 		return fmt.Errorf("failed to reconcile peer authentication: %w", err)
 	}
 
+	authzPolicy := ray.NewAuthorizationPolicy(rc)
+
+	if util.BoolPtrIsNilOrFalse(rc.Spec.NetworkPolicy.Enabled) {
+		return ctx.DeleteIfExists(authzPolicy)
+	}
+	if err := ctx.CreateOrUpdateOwnedResource(authzPolicy); err != nil {
+		return fmt.Errorf("failed to reconcile authorization policy: %w", err)
+	}
+
 	return nil
 }
 
 // reconcileServiceAccount creates a new dedicated service account for a Ray
 // cluster unless a different service account name is provided in the spec.
-func (r *RayClusterReconciler) reconcileServiceAccount(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+func (r *RayClusterReconciler) reconcileServiceAccount(ctx *core.Context, rc *dcv1alpha1.RayCluster) error {
 	if rc.Spec.ServiceAccountName != "" {
 		return nil
 	}
 
 	sa := ray.NewServiceAccount(rc)
-	if err := r.createOrUpdateOwnedResource(ctx, rc, sa); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(sa); err != nil {
 		return fmt.Errorf("failed to reconcile service account: %w", err)
 	}
 
@@ -214,19 +349,19 @@ func (r *RayClusterReconciler) reconcileServiceAccount(ctx context.Context, rc *
 
 // reconcileServices creates services that point to head and worker pods and
 // applies updates when the parent CR changes.
-func (r *RayClusterReconciler) reconcileServices(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+func (r *RayClusterReconciler) reconcileServices(ctx *core.Context, rc *dcv1alpha1.RayCluster) error {
 	svc := ray.NewClientService(rc)
-	if err := r.createOrUpdateOwnedResource(ctx, rc, svc); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(svc); err != nil {
 		return fmt.Errorf("failed to reconcile client service: %w", err)
 	}
 
 	svc = ray.NewHeadlessHeadService(rc)
-	if err := r.createOrUpdateOwnedResource(ctx, rc, svc); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(svc); err != nil {
 		return fmt.Errorf("failed to reconcile headless head service: %w", err)
 	}
 
 	svc = ray.NewHeadlessWorkerService(rc)
-	if err := r.createOrUpdateOwnedResource(ctx, rc, svc); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(svc); err != nil {
 		return fmt.Errorf("failed to reconcile headless worker service: %w", err)
 	}
 
@@ -236,237 +371,355 @@ func (r *RayClusterReconciler) reconcileServices(ctx context.Context, rc *dcv1al
 // reconcileNetworkPolicies optionally creates network policies that control
 // traffic flow between cluster nodes and external clients. Existing network
 // policies will be deleted if enabled is set to false.
-func (r RayClusterReconciler) reconcileNetworkPolicies(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
+func (r *RayClusterReconciler) reconcileNetworkPolicies(ctx *core.Context, rc *dcv1alpha1.RayCluster) error {
 	clusterNetpol := ray.NewClusterNetworkPolicy(rc)
 	clientNetpol := ray.NewHeadClientNetworkPolicy(rc)
 	dashboardNetpol := ray.NewHeadDashboardNetworkPolicy(rc)
+	egressNetpol := ray.NewEgressNetworkPolicy(rc)
 
 	if util.BoolPtrIsNilOrFalse(rc.Spec.NetworkPolicy.Enabled) {
-		return r.deleteIfExists(ctx, dashboardNetpol, clientNetpol, clusterNetpol)
+		return ctx.DeleteIfExists(egressNetpol, dashboardNetpol, clientNetpol, clusterNetpol)
 	}
 
-	if err := r.createOrUpdateOwnedResource(ctx, rc, clusterNetpol); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(clusterNetpol); err != nil {
 		return fmt.Errorf("failed to reconcile cluster network policy: %w", err)
 	}
-	if err := r.createOrUpdateOwnedResource(ctx, rc, clientNetpol); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(clientNetpol); err != nil {
 		return fmt.Errorf("failed to reconcile head client network policy: %w", err)
 	}
-	if err := r.createOrUpdateOwnedResource(ctx, rc, dashboardNetpol); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(dashboardNetpol); err != nil {
 		return fmt.Errorf("failed to reconcile head dashboard network policy: %w", err)
 	}
 
+	if !netpol.EgressEnabled(rc.Spec.NetworkPolicy.Egress) {
+		return ctx.DeleteIfExists(egressNetpol)
+	}
+	if err := ctx.CreateOrUpdateOwnedResource(egressNetpol); err != nil {
+		return fmt.Errorf("failed to reconcile egress network policy: %w", err)
+	}
+
 	return nil
 }
 
-// nolint:dupl
-// reconcilePodSecurityPolicyRBAC optionally creates a role and role binding
-// that allows the Ray pods to "use" the specified pod security policy.
-func (r *RayClusterReconciler) reconcilePodSecurityPolicyRBAC(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
-	role, binding := ray.NewPodSecurityPolicyRBAC(rc)
-
-	if rc.Spec.PodSecurityPolicy == "" {
-		return r.deleteIfExists(ctx, role, binding)
+// reconcileTLS optionally issues the certificate authority used to sign
+// ray node certificates and the node certificate mounted by cluster pods. The
+// operator reissues its own certificate authority before it expires while
+// cert-manager renews the ones it issues.
+func (r *RayClusterReconciler) reconcileTLS(ctx *core.Context, rc *dcv1alpha1.RayCluster) error {
+	secret := &corev1.Secret{ObjectMeta: ray.TLSSecretObjectMeta(rc)}
+	nodeSecret := &corev1.Secret{ObjectMeta: ray.TLSNodeSecretObjectMeta(rc)}
+	var cert client.Object
+	if r.certManager {
+		cert = ray.CertificateReference(rc)
 	}
 
-	err := r.Get(ctx, types.NamespacedName{Name: rc.Spec.PodSecurityPolicy}, &policyv1beta1.PodSecurityPolicy{})
-	if err != nil {
-		return fmt.Errorf("cannot verify pod security policy: %w", err)
+	if !ray.TLSEnabled(rc) {
+		return ctx.DeleteIfExists(cert, nodeSecret, secret)
 	}
 
-	if err := r.createOrUpdateOwnedResource(ctx, rc, role); err != nil {
-		return fmt.Errorf("failed to create role: %w", err)
-	}
-	if err := r.createOrUpdateOwnedResource(ctx, rc, binding); err != nil {
-		return fmt.Errorf("failed to create role binding: %w", err)
+	switch rc.Spec.TLS.Issuer {
+	case dcv1alpha1.RayTLSIssuerCertManager:
+		if !r.certManager {
+			return fmt.Errorf("cannot use %q tls issuer when the cert-manager API is not installed", rc.Spec.TLS.Issuer)
+		}
+		if err := ctx.CreateOrUpdateOwnedResource(ray.NewCertificate(rc)); err != nil {
+			return fmt.Errorf("failed to create certificate: %w", err)
+		}
+	default:
+		if err := ctx.DeleteIfExists(cert); err != nil {
+			return err
+		}
+		if err := r.reconcileTLSSecret(ctx, rc); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return r.reconcileTLSNodeSecret(ctx, rc)
 }
 
-// reconcileAutoscaler optionally creates a horizontal pod autoscaler that
-// targets Ray worker pods.
-func (r *RayClusterReconciler) reconcileAutoscaler(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
-	if rc.Spec.Autoscaling == nil {
-		// calling ray.NewHorizontalPodAutoscaler when autoscaling is nil will
-		// result in error. so we leverage a shallow reference here instead.
-		hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
-			ObjectMeta: ray.HorizontalPodAutoscalerObjectMeta(rc),
+// reconcileTLSSecret issues a new certificate authority when the existing
+// one is missing, invalid or due for renewal.
+func (r *RayClusterReconciler) reconcileTLSSecret(ctx *core.Context, rc *dcv1alpha1.RayCluster) error {
+	now := time.Now()
+
+	found := &corev1.Secret{}
+	err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(&corev1.Secret{ObjectMeta: ray.TLSSecretObjectMeta(rc)}), found)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return err
+	default:
+		renewal, rErr := ray.TLSRenewalTime(rc, found)
+		if rErr == nil && now.Before(renewal) {
+			return nil
 		}
-		return r.deleteIfExists(ctx, hpa)
+
+		ctx.Log.Info("reissuing tls certificate authority", "renewal", renewal, "error", rErr)
 	}
 
-	hpa, err := ray.NewHorizontalPodAutoscaler(rc)
+	secret, err := ray.NewTLSSecret(rc, now)
 	if err != nil {
 		return err
 	}
-	if err = r.createOrUpdateOwnedResource(ctx, rc, hpa); err != nil {
-		return fmt.Errorf("failed to reconcile horizontal pod autoscaler: %w", err)
+	if err = ctx.CreateOrUpdateOwnedResource(secret); err != nil {
+		return fmt.Errorf("failed to create tls secret: %w", err)
 	}
 
 	return nil
 }
 
-// reconcileStatefulSets creates separate Ray head and worker stateful sets
-// that will collectively comprise the execution agents of the cluster.
-func (r *RayClusterReconciler) reconcileStatefulSets(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
-	head, err := ray.NewStatefulSet(rc, ray.ComponentHead)
+// reconcileTLSNodeSecret issues a node certificate signed by the cluster
+// certificate authority when the authority is reissued or the pod IPs change.
+// Pods wait until the certificate includes their IP, so the certificate
+// authority never leaves the operator.
+func (r *RayClusterReconciler) reconcileTLSNodeSecret(ctx *core.Context, rc *dcv1alpha1.RayCluster) error {
+	ca := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: rc.Namespace, Name: ray.TLSSecretName(rc.Name)}
+	if err := ctx.Client.Get(ctx, key, ca); err != nil {
+		// the certificate authority may not be cached or issued yet. another
+		// reconciliation is triggered once it is.
+		return client.IgnoreNotFound(err)
+	}
+
+	podIPs, err := listPodIPs(ctx, ray.SelectorLabels(rc))
 	if err != nil {
 		return err
 	}
-	if err = r.createOrUpdateOwnedResource(ctx, rc, head); err != nil {
-		return fmt.Errorf("failed to create head stateful set: %w", err)
+
+	found := &corev1.Secret{}
+	err = ctx.Client.Get(ctx, client.ObjectKeyFromObject(&corev1.Secret{ObjectMeta: ray.TLSNodeSecretObjectMeta(rc)}), found)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return err
+	case ray.TLSNodeSecretCurrent(found, ca, podIPs):
+		return nil
 	}
 
-	worker, err := ray.NewStatefulSet(rc, ray.ComponentWorker)
+	secret, err := ray.NewTLSNodeSecret(rc, ca, podIPs, time.Now())
 	if err != nil {
 		return err
 	}
-	if err = r.createOrUpdateOwnedResource(ctx, rc, worker); err != nil {
-		return fmt.Errorf("failed to create worker stateful set: %w", err)
+	if err = ctx.CreateOrUpdateOwnedResource(secret); err != nil {
+		return fmt.Errorf("failed to create tls node secret: %w", err)
 	}
 
 	return nil
 }
 
-// createOrUpdateOwnedResource should be used to manage the lifecycle of namespace-scoped objects.
-//
-// The CR will become the "owner" of the "controlled" object and cleanup will
-// occur automatically when the CR is deleted.
-//
-// The controller resource will be created if it's missing.
-// The controller resource will be updated if any changes are applicable.
-// Any unexpected api errors will be reported.
-func (r *RayClusterReconciler) createOrUpdateOwnedResource(ctx context.Context, owner metav1.Object, controlled client.Object) error {
-	if err := ctrl.SetControllerReference(owner, controlled, r.Scheme); err != nil {
-		return err
+// tlsRenewalInterval returns the time remaining until the certificate
+// authority issued by the operator is due for renewal. Zero is returned when
+// the operator does not manage the certificate authority.
+func (r *RayClusterReconciler) tlsRenewalInterval(ctx *core.Context, rc *dcv1alpha1.RayCluster) (time.Duration, error) {
+	if !ray.TLSEnabled(rc) || rc.Spec.TLS.Issuer == dcv1alpha1.RayTLSIssuerCertManager {
+		return 0, nil
 	}
 
-	var gvks []schema.GroupVersionKind
-	gvks, _, err := r.Scheme.ObjectKinds(controlled)
-	if err != nil {
-		return err
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: rc.Namespace, Name: ray.TLSSecretName(rc.Name)}
+	if err := ctx.Client.Get(ctx, key, secret); err != nil {
+		// the secret watch triggers another reconciliation once it is cached
+		return 0, client.IgnoreNotFound(err)
 	}
-	gvk := gvks[0]
-
-	log := r.Log.FromContext(ctx)
 
-	found := controlled.DeepCopyObject().(client.Object)
-	if err = r.Get(ctx, client.ObjectKeyFromObject(controlled), found); err != nil {
-		if !apierrors.IsNotFound(err) {
-			return err
-		}
+	renewal, err := ray.TLSRenewalTime(rc, secret)
+	if err != nil {
+		return 0, err
+	}
+	if interval := time.Until(renewal); interval > 0 {
+		return interval, nil
+	}
 
-		if err = PatchAnnotator.SetLastAppliedAnnotation(controlled); err != nil {
-			return err
-		}
+	return time.Second, nil
+}
 
-		log.Info("creating controlled object", "gvk", gvk, "object", controlled)
-		return r.Create(ctx, controlled)
+// reconcileRedisPassword generates the redis password when it is missing or
+// when a rotation has been requested with an annotation.
+func (r *RayClusterReconciler) reconcileRedisPassword(ctx *core.Context, rc *dcv1alpha1.RayCluster) error {
+	found := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: rc.Namespace, Name: ray.RedisPasswordSecretName(rc.Name)}
+	err := ctx.Client.Get(ctx, key, found)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return err
+	case !ray.RedisPasswordRotationRequested(rc, found):
+		return nil
+	default:
+		ctx.Log.Info("rotating redis password", "request", rc.Annotations[ray.RedisPasswordRotateAnnotation])
 	}
 
-	patchResult, err := PatchMaker.Calculate(found, controlled, PatchCalculateOpts...)
+	secret, err := ray.NewRedisPasswordSecret(rc)
 	if err != nil {
 		return err
 	}
-	if patchResult.IsEmpty() {
-		return nil
+	if err = ctx.CreateOrUpdateOwnedResource(secret); err != nil {
+		return fmt.Errorf("failed to create redis password secret: %w", err)
 	}
 
-	log.V(1).Info("applying patch to object", "gvk", gvk, "object", controlled, "patch", string(patchResult.Patch))
-	if err = PatchAnnotator.SetLastAppliedAnnotation(controlled); err != nil {
+	return nil
+}
+
+// reconcileStatefulSets creates separate Ray head and worker stateful sets
+// that will collectively comprise the execution agents of the cluster.
+func (r *RayClusterReconciler) reconcileStatefulSets(ctx *core.Context, rc *dcv1alpha1.RayCluster) error {
+	defaults := r.clusterDefaults()
+	head, err := ray.NewStatefulSet(rc, ray.ComponentHead, defaults)
+	if err != nil {
+		return err
+	}
+	r.addSidecarAnnotations(rc, head)
+	if err = r.addTLSAnnotations(ctx, rc, head); err != nil {
+		return err
+	}
+	if err = r.addRedisPasswordAnnotations(ctx, rc, head); err != nil {
 		return err
 	}
+	if err = ctx.CreateOrUpdateOwnedResource(head); err != nil {
+		return fmt.Errorf("failed to create head stateful set: %w", err)
+	}
 
-	controlled.SetResourceVersion(found.GetResourceVersion())
-	if modified, ok := controlled.(*corev1.Service); ok {
-		current := found.(*corev1.Service)
-		modified.Spec.ClusterIP = current.Spec.ClusterIP
+	worker, err := ray.NewStatefulSet(rc, ray.ComponentWorker, defaults)
+	if err != nil {
+		return err
+	}
+	r.addSidecarAnnotations(rc, worker)
+	if err = r.addTLSAnnotations(ctx, rc, worker); err != nil {
+		return err
+	}
+	if err = r.addRedisPasswordAnnotations(ctx, rc, worker); err != nil {
+		return err
+	}
+	if err = ctx.CreateOrUpdateOwnedResource(worker); err != nil {
+		return fmt.Errorf("failed to create worker stateful set: %w", err)
 	}
 
-	log.Info("updating controlled object", "gvk", gvk, "object", controlled)
-	return r.Update(ctx, controlled)
+	return nil
 }
 
-// deleteIfExists will delete one or more Kubernetes objects if they exist.
-func (r *RayClusterReconciler) deleteIfExists(ctx context.Context, objs ...client.Object) error {
-	log := r.Log.FromContext(ctx)
+// addSidecarAnnotations tunes the Istio sidecar injected into cluster pods
+// when Istio support is enabled. Annotations provided in the spec take
+// precedence over the generated values.
+func (r *RayClusterReconciler) addSidecarAnnotations(rc *dcv1alpha1.RayCluster, sts *appsv1.StatefulSet) {
+	if !r.IstioEnabled {
+		return
+	}
 
-	for _, obj := range objs {
-		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
-			if apierrors.IsNotFound(err) {
-				continue
-			}
+	sidecar := istio.SidecarAnnotations(ray.SidecarExcludedPorts(rc))
+	sts.Spec.Template.Annotations = util.MergeStringMaps(sts.Spec.Template.Annotations, sidecar)
+}
 
-			return err
-		}
+// addTLSAnnotations records the checksum of the cluster certificate
+// authority on the pod template so that pods are restarted with new node
+// certificates when it is reissued.
+func (r *RayClusterReconciler) addTLSAnnotations(ctx *core.Context, rc *dcv1alpha1.RayCluster, sts *appsv1.StatefulSet) error {
+	if !ray.TLSEnabled(rc) {
+		return nil
+	}
 
-		log.Info("deleting controlled object", "object", obj)
-		if err := r.Delete(ctx, obj); err != nil {
-			return err
-		}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: rc.Namespace, Name: ray.TLSSecretName(rc.Name)}
+	if err := ctx.Client.Get(ctx, key, secret); err != nil {
+		// cert-manager may not have issued the certificate yet. pods will
+		// wait for the secret and are restarted once the checksum is known.
+		return client.IgnoreNotFound(err)
 	}
 
+	sts.Spec.Template.Annotations = util.MergeStringMaps(
+		map[string]string{ray.TLSChecksumAnnotation: ray.TLSChecksum(secret)},
+		sts.Spec.Template.Annotations,
+	)
+
 	return nil
 }
 
-// updateStatus updates the RayCluster status subresource when changes occur.
-func (r *RayClusterReconciler) updateStatus(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
-	mNodes, err := r.modifyStatusNodes(ctx, rc)
-	if err != nil {
-		return fmt.Errorf("cannot modify cluster status nodes: %w", err)
+// addRedisPasswordAnnotations records the checksum of the redis password on
+// the pod template so that pods are restarted when the password is rotated.
+func (r *RayClusterReconciler) addRedisPasswordAnnotations(ctx *core.Context, rc *dcv1alpha1.RayCluster, sts *appsv1.StatefulSet) error {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: rc.Namespace, Name: ray.RedisPasswordSecretName(rc.Name)}
+	if err := ctx.Client.Get(ctx, key, secret); err != nil {
+		// the secret watch triggers another reconciliation once it is cached
+		return client.IgnoreNotFound(err)
 	}
 
-	mWorkedFields, err := r.modifyStatusWorkerFields(ctx, rc)
-	if err != nil {
-		return fmt.Errorf("cannot modify cluster status worker fields: %w", err)
-	}
-
-	if mNodes || mWorkedFields {
-		if err = r.Status().Update(ctx, rc); err != nil {
-			return err
-		}
-	}
+	sts.Spec.Template.Annotations = util.MergeStringMaps(
+		map[string]string{ray.RedisPasswordChecksumAnnotation: ray.RedisPasswordChecksum(secret)},
+		sts.Spec.Template.Annotations,
+	)
 
 	return nil
 }
 
 // modifyStatusNodes will ensure the status contains an accurate list of all the pods in the cluster.
-func (r *RayClusterReconciler) modifyStatusNodes(ctx context.Context, rc *dcv1alpha1.RayCluster) (bool, error) {
-	podList := &corev1.PodList{}
-	listOpts := []client.ListOption{
-		client.InNamespace(rc.Namespace),
-		client.MatchingLabels(ray.MetadataLabels(rc)),
-	}
-	if err := r.List(ctx, podList, listOpts...); err != nil {
+func (r *RayClusterReconciler) modifyStatusNodes(ctx *core.Context, rc *dcv1alpha1.RayCluster) (bool, error) {
+	podNames, err := listPodNames(ctx, ray.MetadataLabels(rc))
+	if err != nil {
 		return false, fmt.Errorf("cannot list ray pods: %w", err)
 	}
 
-	var podNames []string
-	for _, pod := range podList.Items {
-		podNames = append(podNames, pod.Name)
+	if reflect.DeepEqual(podNames, rc.Status.Nodes) {
+		return false, nil
 	}
-	sort.Strings(podNames)
 
-	if reflect.DeepEqual(podNames, rc.Status.Nodes) {
+	ctx.Log.V(1).Info("modifying status", "path", ".status.nodes", "value", podNames)
+	rc.Status.Nodes = podNames
+
+	return true, nil
+}
+
+// modifyStatusURLs publishes the external addresses of exposed endpoints.
+func (r *RayClusterReconciler) modifyStatusURLs(ctx *core.Context, rc *dcv1alpha1.RayCluster) (bool, error) {
+	var address string
+	var nodePorts map[int32]int32
+	switch {
+	case expose.Uses(rc.Spec.Expose, dcv1alpha1.ExposeTypeIngress):
+		ingress := &networkingv1.Ingress{}
+		key := types.NamespacedName{Namespace: rc.Namespace, Name: ray.ExposeObjectMeta(rc).Name}
+		if err := ctx.Client.Get(ctx, key, ingress); client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+		address = expose.LoadBalancerAddress(ingress.Status.LoadBalancer.Ingress)
+	case expose.Uses(rc.Spec.Expose, dcv1alpha1.ExposeTypeLoadBalancer):
+		svc := &corev1.Service{}
+		key := types.NamespacedName{Namespace: rc.Namespace, Name: ray.ClientServiceName(rc.Name)}
+		if err := ctx.Client.Get(ctx, key, svc); client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+		address = expose.LoadBalancerAddress(svc.Status.LoadBalancer.Ingress)
+	case expose.Uses(rc.Spec.Expose, dcv1alpha1.ExposeTypeNodePort):
+		var err error
+		headLabels := ray.SelectorLabelsWithComponent(rc, ray.ComponentHead)
+		address, nodePorts, err = nodePortEndpoint(ctx, ray.ClientServiceName(rc.Name), headLabels)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	dashboardURL, clientURL := ray.ExposedURLs(rc, address, nodePorts)
+	if rc.Status.DashboardURL == dashboardURL && rc.Status.ClientURL == clientURL {
 		return false, nil
 	}
 
-	log := r.Log.FromContext(ctx)
+	log := ctx.Log
+	log.V(1).Info("modifying status", "path", ".status.dashboardURL", "value", dashboardURL)
+	log.V(1).Info("modifying status", "path", ".status.clientURL", "value", clientURL)
 
-	log.V(1).Info("modifying status", "path", ".status.nodes", "value", podNames)
-	rc.Status.Nodes = podNames
+	rc.Status.DashboardURL = dashboardURL
+	rc.Status.ClientURL = clientURL
 
 	return true, nil
 }
 
 // modifyStatusWorkerFields syncs certain worker stateful set fields into the status.
-func (r *RayClusterReconciler) modifyStatusWorkerFields(ctx context.Context, rc *dcv1alpha1.RayCluster) (bool, error) {
-	worker, err := ray.NewStatefulSet(rc, ray.ComponentWorker)
+func (r *RayClusterReconciler) modifyStatusWorkerFields(ctx *core.Context, rc *dcv1alpha1.RayCluster) (bool, error) {
+	defaults := r.clusterDefaults()
+	worker, err := ray.NewStatefulSet(rc, ray.ComponentWorker, defaults)
 	if err != nil {
 		return false, err
 	}
 
-	err = r.Get(ctx, client.ObjectKeyFromObject(worker), worker)
+	err = ctx.Client.Get(ctx, client.ObjectKeyFromObject(worker), worker)
 	if client.IgnoreNotFound(err) != nil {
 		return false, err
 	}
@@ -476,7 +729,7 @@ func (r *RayClusterReconciler) modifyStatusWorkerFields(ctx context.Context, rc
 		return false, err
 	}
 
-	log := r.Log.FromContext(ctx)
+	log := ctx.Log
 
 	var modified bool
 	if rc.Status.WorkerSelector != selector.String() {
@@ -495,36 +748,16 @@ func (r *RayClusterReconciler) modifyStatusWorkerFields(ctx context.Context, rc
 	return modified, nil
 }
 
-// deleteExternalStorage queries for all persistent volume claims belonging to
-// a cluster instance using selector labels. this should find all the claims
-// created by both the head and worker stateful sets.
-func (r *RayClusterReconciler) deleteExternalStorage(ctx context.Context, rc *dcv1alpha1.RayCluster) error {
-	log := r.Log.FromContext(ctx)
-
-	ns := rc.Namespace
-	labels := ray.SelectorLabels(rc)
-	pvcList := &corev1.PersistentVolumeClaimList{}
-	listOpts := []client.ListOption{
-		client.InNamespace(ns),
-		client.MatchingLabels(labels),
-	}
-
-	log.Info("querying for persistent volume claims", "namespace", ns, "labels", labels)
-	if err := r.List(ctx, pvcList, listOpts...); err != nil {
-		log.Error(err, "cannot list persistent volume claims")
-		return err
+// modifyStatusRedisPassword publishes the name of the redis password secret.
+func (r *RayClusterReconciler) modifyStatusRedisPassword(ctx *core.Context, rc *dcv1alpha1.RayCluster) (bool, error) {
+	name := ray.RedisPasswordSecretName(rc.Name)
+	if rc.Status.RedisPasswordSecretName == name {
+		return false, nil
 	}
 
-	for idx := range pvcList.Items {
-		pvc := &pvcList.Items[idx]
-		key := client.ObjectKeyFromObject(pvc)
-
-		log.Info("deleting persistent volume claim", "claim", key)
-		if err := r.Delete(ctx, pvc); err != nil {
-			log.Error(err, "cannot delete persistent volume claim", "claim", key)
-			return err
-		}
-	}
+	log := ctx.Log
+	log.V(1).Info("modifying status", "path", ".status.redisPasswordSecretName", "value", name)
+	rc.Status.RedisPasswordSecretName = name
 
-	return nil
+	return true, nil
 }
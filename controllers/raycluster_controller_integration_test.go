@@ -21,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
 )
 
 var _ = Describe("RayCluster Controller", func() {
@@ -46,6 +47,7 @@ var _ = Describe("RayCluster Controller", func() {
 				{"pod security policy role", "it-ray", &rbacv1.Role{}},
 				{"pod security policy role binding", "it-ray", &rbacv1.RoleBinding{}},
 				{"horizontal pod autoscaler", "it-ray", &autoscalingv2beta2.HorizontalPodAutoscaler{}},
+				{"redis password secret", "it-ray-redis", &corev1.Secret{}},
 				{"head stateful set", "it-ray-head", &appsv1.StatefulSet{}},
 				{"worker stateful set", "it-ray-worker", &appsv1.StatefulSet{}},
 			}
@@ -92,6 +94,8 @@ var _ = Describe("RayCluster Controller", func() {
 				Nodes:          nil,
 				WorkerReplicas: 1,
 				WorkerSelector: "app.kubernetes.io/component=worker,app.kubernetes.io/instance=it,app.kubernetes.io/name=ray",
+
+				RedisPasswordSecretName: "it-ray-redis",
 			}))
 		})
 	})
@@ -133,12 +137,97 @@ var _ = Describe("RayCluster Controller", func() {
 				return k8sClient.Get(ctx, key, autoscaler)
 			}, timeout).ShouldNot(Succeed())
 
+			By("recording an event for the deleted horizontal pod autoscaler")
+			Eventually(func() []string {
+				events := &corev1.EventList{}
+				if err := k8sClient.List(ctx, events, client.InNamespace(cluster.Namespace)); err != nil {
+					return nil
+				}
+
+				var messages []string
+				for _, event := range events.Items {
+					if event.InvolvedObject.UID == cluster.UID && event.Reason == core.EventReasonDeleted {
+						messages = append(messages, event.Message)
+					}
+				}
+				return messages
+			}, timeout).Should(ContainElement("Deleted HorizontalPodAutoscaler update-ray"))
+
 			By("deleting network policies when disabled")
 
 			By("deleting pod security policy rbac resources when disabled")
 		})
 	})
 
+	Describe("Enabling TLS on a RayCluster resource", func() {
+		It("should issue node certificates for pod IPs without mounting the certificate authority", func() {
+			clusterKey, cluster := createCluster(ctx, "tls")
+
+			Eventually(func() error {
+				rc := &dcv1alpha1.RayCluster{}
+				if err := k8sClient.Get(ctx, clusterKey, rc); err != nil {
+					return err
+				}
+
+				rc.Spec.TLS = &dcv1alpha1.RayClusterTLS{
+					Enabled:     pointer.BoolPtr(true),
+					Issuer:      dcv1alpha1.RayTLSIssuerOperator,
+					Duration:    &metav1.Duration{Duration: 24 * time.Hour},
+					RenewBefore: &metav1.Duration{Duration: time.Hour},
+				}
+				return k8sClient.Update(ctx, rc)
+			}, timeout).Should(Succeed())
+
+			By("creating the node certificate secret")
+			nodeKey := types.NamespacedName{Name: "tls-ray-tls", Namespace: cluster.Namespace}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, nodeKey, &corev1.Secret{})
+			}, timeout).Should(Succeed())
+
+			By("reissuing the node certificate when a pod is assigned an IP")
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls-ray-head-0",
+					Namespace: cluster.Namespace,
+					Labels: map[string]string{
+						"app.kubernetes.io/name":     "ray",
+						"app.kubernetes.io/instance": cluster.Name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "ray", Image: "foo:bar"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+			pod.Status.PodIP = "10.1.0.1"
+			Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+
+			Eventually(func() string {
+				secret := &corev1.Secret{}
+				if err := k8sClient.Get(ctx, nodeKey, secret); err != nil {
+					return ""
+				}
+				return string(secret.Data["node-ips"])
+			}, timeout).Should(Equal("10.1.0.1\n"))
+
+			By("mounting only the node certificate into cluster pods")
+			Eventually(func() []string {
+				sts := &appsv1.StatefulSet{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: "tls-ray-head", Namespace: cluster.Namespace}, sts); err != nil {
+					return nil
+				}
+
+				var secrets []string
+				for _, vol := range sts.Spec.Template.Spec.Volumes {
+					if vol.Secret != nil {
+						secrets = append(secrets, vol.Secret.SecretName)
+					}
+				}
+				return secrets
+			}, timeout).Should(And(ContainElement("tls-ray-tls"), Not(ContainElement("tls-ray-ca"))))
+		})
+	})
+
 	Describe("Deleting a RayCluster resource", func() {
 		It("should delete external persistent volume claims created by stateful sets", func() {
 			clusterKey, cluster := createCluster(ctx, "delete")
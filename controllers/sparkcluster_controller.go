@@ -1,17 +1,11 @@
 package controllers
 
 import (
-	"context"
 	"fmt"
 	"reflect"
-	"sort"
-	"strings"
 	"time"
 
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
-
-	"github.com/banzaicloud/k8s-objectmatcher/patch"
-	"github.com/go-logr/logr"
+	istionetworking "istio.io/client-go/pkg/apis/networking/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
@@ -20,220 +14,341 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/admission"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/autoscaler"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/controller/core"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/logging"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/metrics"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/podsecurity"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/expose"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/netpol"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/spark"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
-// LastAppliedConfig is the annotation key used to store object state on owned components.
-const SparkLastAppliedConfig = "distributed-compute-operator.dominodatalab.com/last-applied"
+// SparkFinalizerName was registered on spark clusters by earlier releases. It
+// is replaced with DistributedComputeFinalizer when a cluster is reconciled.
+const SparkFinalizerName = "distributed-compute.dominodatalab.com/dco-finalizer"
 
-var (
-	// SparkPatchAnnotator applies state annotations to owned components.
-	SparkPatchAnnotator = patch.NewAnnotator(SparkLastAppliedConfig)
-	// SparkPatchMaker calculates changes to state annotations on owned components.
-	SparkPatchMaker = patch.NewPatchMaker(SparkPatchAnnotator)
-)
+// sparkMasterScalerInterval is the frequency at which application demand is
+// polled when the "spark-master" autoscaling backend is used.
+const sparkMasterScalerInterval = 30 * time.Second
 
 // SparkClusterReconciler reconciles SparkCluster objects.
 type SparkClusterReconciler struct {
 	client.Client
-	Log    logging.ContextLogger
-	Scheme *runtime.Scheme
+	Log          logging.ContextLogger
+	Scheme       *runtime.Scheme
+	IstioEnabled bool
+	KEDAEnabled  bool
+
+	// Recorder publishes events that describe reconciliation actions on
+	// cluster objects.
+	Recorder record.EventRecorder
+
+	// ServerSideApply manages owned resources with server-side apply instead
+	// of patch-based updates that rely on last-applied annotations.
+	ServerSideApply bool
+
+	// NamespaceSelector restricts reconciliation to clusters in namespaces
+	// whose labels match. Every watched namespace is selected when this is nil.
+	NamespaceSelector labels.Selector
+
+	// ControllerSelector restricts reconciliation to the clusters managed by
+	// this operator instance.
+	ControllerSelector dcv1alpha1.ControllerSelector
+
+	// PodSecurityBackend selects how cluster pods are granted access to the
+	// platform pod security mechanism.
+	PodSecurityBackend podsecurity.Backend
+
+	// MasterScaler is used to scale clusters that select the "spark-master"
+	// autoscaling backend.
+	MasterScaler *autoscaler.SparkMasterScaler
+
+	// OperatorNamespace is the namespace that the operator runs in. Network
+	// policies admit it to the master status endpoint polled by MasterScaler.
+	OperatorNamespace string
+
+	// KEDANamespace is the namespace that KEDA runs in. Network policies admit
+	// it to the master status endpoint polled by the default KEDA trigger.
+	KEDANamespace string
+
+	// Admitter holds new clusters in the "Queued" phase until there is enough
+	// capacity to run them. Clusters are admitted immediately when this is nil.
+	Admitter *admission.Admitter
+
+	// Defaults supplies the operator defaults used to build cluster
+	// resources. The built-in defaults are used when this is nil.
+	Defaults dcv1alpha1.ClusterDefaultsSource
+
+	// shared reconciles the resources that spark clusters manage like every
+	// other cluster kind.
+	shared *core.ClusterComponents
 }
 
-// nolint:dupl
 // SetupWithManager creates and registers this controller with the manager.
 func (r *SparkClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&dcv1alpha1.SparkCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.ServiceAccount{}).
-		Owns(&appsv1.StatefulSet{}).
-		Owns(&rbacv1.Role{}).
-		Owns(&rbacv1.RoleBinding{}).
-		Owns(&networkingv1.NetworkPolicy{}).
-		Owns(&autoscalingv2beta2.HorizontalPodAutoscaler{}).
-		Complete(r)
+	b := core.NewReconciler(mgr, &dcv1alpha1.SparkCluster{}, core.Options{
+		Client:             r.Client,
+		Scheme:             r.Scheme,
+		Log:                r.Log,
+		Recorder:           r.Recorder,
+		ServerSideApply:    r.ServerSideApply,
+		NamespaceSelector:  r.NamespaceSelector,
+		ControllerSelector: r.ControllerSelector,
+	}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	r.shared = &core.ClusterComponents{
+		Resources:          r.clusterResources(),
+		IstioEnabled:       r.IstioEnabled,
+		KEDAEnabled:        r.KEDAEnabled,
+		PodSecurityBackend: r.PodSecurityBackend,
+		OperatorScalers: map[dcv1alpha1.AutoscalingBackend]bool{
+			dcv1alpha1.AutoscalingBackendSparkMaster: r.MasterScaler != nil,
+		},
+	}
+	r.shared.Own(mgr, b)
+
+	b.Finalizer(DistributedComputeFinalizer, core.DeletePersistentVolumeClaims(func(obj client.Object) map[string]string {
+		return spark.SelectorLabels(obj.(*dcv1alpha1.SparkCluster))
+	}), SparkFinalizerName)
+	if r.Admitter != nil {
+		b.Gate("admit", admissionRetryInterval, admissionGate(r.Admitter, func(obj client.Object) admissionStatus {
+			status := &obj.(*dcv1alpha1.SparkCluster).Status
+			return admissionStatus{&status.Phase, &status.QueuePosition, &status.QueueMessage}
+		}))
+	}
+
+	b.Component("reconcileIstio", sparkComponent(r.reconcileIstio)).
+		Component("reconcileServiceAccount", sparkComponent(r.reconcileServiceAccount)).
+		Component("reconcileHeadService", sparkComponent(r.reconcileHeadService)).
+		Component("reconcileHeadlessService", sparkComponent(r.reconcileHeadlessService)).
+		Component("reconcileExpose", core.ComponentFunc(r.shared.ReconcileExpose)).
+		Component("reconcileNetworkPolicies", sparkComponent(r.reconcileNetworkPolicies)).
+		Component("reconcileMonitoring", core.ComponentFunc(r.shared.ReconcileMonitoring)).
+		Component("reconcilePodSecurity", core.ComponentFunc(r.shared.ReconcilePodSecurity)).
+		Component("reconcilePodDisruptionBudgets", core.ComponentFunc(r.shared.ReconcilePodDisruptionBudgets)).
+		Component("reconcileAutoscaler", core.ComponentFunc(r.shared.ReconcileAutoscaler)).
+		Component("reconcilePodGroup", core.ComponentFunc(r.shared.ReconcilePodGroup)).
+		Component("reconcileAuthSecret", sparkComponent(r.reconcileAuthSecret)).
+		Component("reconcileStatefulSets", sparkComponent(r.reconcileStatefulSets))
+
+	b.StatusModifier("nodes", sparkStatusModifier(r.modifyStatusNodes)).
+		StatusModifier("worker fields", sparkStatusModifier(r.modifyStatusWorkerFields)).
+		StatusModifier("urls", sparkStatusModifier(r.modifyStatusURLs)).
+		StatusModifier("pod security violations", core.StatusModifierFunc(r.shared.ModifyStatusPodSecurity)).
+		StatusModifier("auth", sparkStatusModifier(r.modifyStatusAuth))
+
+	if r.MasterScaler != nil {
+		b.Result(func(ctx *core.Context) (ctrl.Result, error) {
+			return r.scaleWorkers(ctx, ctx.Object.(*dcv1alpha1.SparkCluster))
+		})
+		b.OnDelete(r.MasterScaler.Forget)
+	}
+	b.ClusterState(func(ctx *core.Context) (metrics.ClusterState, error) {
+		sc := ctx.Object.(*dcv1alpha1.SparkCluster)
+		return clusterState(ctx, sc.Status.Phase, sc.Spec.Worker.Replicas,
+			spark.InstanceObjectName(sc.Name, spark.ComponentMaster), spark.InstanceObjectName(sc.Name, spark.ComponentWorker))
+	})
+
+	return b.Complete()
 }
 
-const SparkFinalizerName = "distributed-compute.dominodatalab.com/dco-finalizer"
-
-//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=sparkclusters,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=sparkclusters/status,verbs=get;update;patch
-//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=sparkclusters/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=pods,verbs=list;watch
-//+kubebuilder:rbac:groups="",resources=services;serviceaccounts,verbs=create;update;list;watch
-//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=create;update;list;watch
-//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=create;update;delete;list;watch
-//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;update;delete;list;watch
-//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=create;update;delete;list;watch
-
-// Reconcile implements state reconciliation logic for SparkCluster objects.
-func (r *SparkClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	ctx, log := r.setLogger(ctx, r.Log.WithValues("sparkcluster", req.NamespacedName))
-
-	log.V(2).Info("reconciliation loop trigged")
-
-	rc := &dcv1alpha1.SparkCluster{}
-	if err := r.Get(ctx, req.NamespacedName, rc); err != nil {
-		if apierrors.IsNotFound(err) {
-			log.Info("resource not found, assuming object was deleted")
-			return ctrl.Result{}, nil
-		}
-
-		log.Error(err, "failed to retrieve resource")
-		return ctrl.Result{}, err
+// clusterDefaults returns the operator defaults used to build the resources
+// of spark clusters.
+func (r *SparkClusterReconciler) clusterDefaults() dcv1alpha1.SparkClusterDefaults {
+	if r.Defaults == nil {
+		return dcv1alpha1.NewClusterDefaults().Spark
 	}
+	return r.Defaults.ClusterDefaults().Spark
+}
 
-	updated, err := r.processFinalizers(ctx, rc, log)
-	if err != nil {
-		log.Error(err, "failed to process finalizers")
-		return ctrl.Result{}, err
-	} else if updated {
-		return ctrl.Result{Requeue: true}, nil
+// clusterResources returns the builders of the spark resources managed by the
+// components shared with other cluster kinds.
+func (r *SparkClusterReconciler) clusterResources() core.ClusterResources {
+	return core.ClusterResources{
+		Spec: func(obj client.Object) core.ClusterSpec {
+			sc := obj.(*dcv1alpha1.SparkCluster)
+			return core.ClusterSpec{
+				Expose:                     sc.Spec.Expose,
+				NetworkPolicyEnabled:       util.BoolPtrIsTrue(sc.Spec.NetworkPolicy.Enabled),
+				Monitoring:                 sc.Spec.Monitoring,
+				Scheduling:                 sc.Spec.Scheduling,
+				PodDisruptionBudgetEnabled: util.BoolPtrIsTrue(sc.Spec.PodDisruptionBudget.Enabled),
+				Autoscaling:                sc.Spec.Autoscaling,
+				PodSecurityPolicy:          sc.Spec.PodSecurityPolicy,
+				SecurityContextConstraints: sc.Spec.SecurityContextConstraints,
+			}
+		},
+		PodSecurityViolations: func(obj client.Object) *[]string {
+			return &obj.(*dcv1alpha1.SparkCluster).Status.PodSecurityViolations
+		},
+		ExposeObjectMeta: func(obj client.Object) metav1.ObjectMeta {
+			return spark.ExposeObjectMeta(obj.(*dcv1alpha1.SparkCluster))
+		},
+		Ingress: func(obj client.Object) *networkingv1.Ingress {
+			return spark.NewIngress(obj.(*dcv1alpha1.SparkCluster))
+		},
+		Gateway: func(obj client.Object) *istionetworking.Gateway {
+			return spark.NewGateway(obj.(*dcv1alpha1.SparkCluster))
+		},
+		VirtualService: func(obj client.Object) *istionetworking.VirtualService {
+			return spark.NewVirtualService(obj.(*dcv1alpha1.SparkCluster))
+		},
+		MonitoringNetworkPolicy: func(obj client.Object) *networkingv1.NetworkPolicy {
+			return spark.NewMonitoringNetworkPolicy(obj.(*dcv1alpha1.SparkCluster))
+		},
+		Monitor: func(obj client.Object) *unstructured.Unstructured {
+			return spark.NewMonitor(obj.(*dcv1alpha1.SparkCluster))
+		},
+		MonitorReference: func(obj client.Object, kind dcv1alpha1.MonitorKind) *unstructured.Unstructured {
+			return spark.MonitorReference(obj.(*dcv1alpha1.SparkCluster), kind)
+		},
+		PodSecurityPolicyRBAC: func(obj client.Object) (*rbacv1.Role, *rbacv1.RoleBinding) {
+			return spark.NewPodSecurityPolicyRBAC(obj.(*dcv1alpha1.SparkCluster))
+		},
+		SecurityContextConstraintsRBAC: func(obj client.Object) (*rbacv1.Role, *rbacv1.RoleBinding) {
+			return spark.NewSecurityContextConstraintsRBAC(obj.(*dcv1alpha1.SparkCluster))
+		},
+		PodDisruptionBudgets: func(obj client.Object) []*policyv1beta1.PodDisruptionBudget {
+			master, worker := spark.NewPodDisruptionBudgets(obj.(*dcv1alpha1.SparkCluster))
+			return []*policyv1beta1.PodDisruptionBudget{master, worker}
+		},
+		HorizontalPodAutoscalerObjectMeta: func(obj client.Object) metav1.ObjectMeta {
+			return spark.HorizontalPodAutoscalerObjectMeta(obj.(*dcv1alpha1.SparkCluster))
+		},
+		HorizontalPodAutoscaler: func(obj client.Object) (*autoscalingv2beta2.HorizontalPodAutoscaler, error) {
+			return spark.NewHorizontalPodAutoscaler(obj.(*dcv1alpha1.SparkCluster))
+		},
+		ScaledObject: func(obj client.Object) (*unstructured.Unstructured, error) {
+			return spark.NewScaledObject(obj.(*dcv1alpha1.SparkCluster))
+		},
+		ScaledObjectReference: func(obj client.Object) *unstructured.Unstructured {
+			return spark.ScaledObjectReference(obj.(*dcv1alpha1.SparkCluster))
+		},
+		PodGroup: func(obj client.Object) (*unstructured.Unstructured, error) {
+			return spark.NewPodGroup(obj.(*dcv1alpha1.SparkCluster))
+		},
+		PodGroupReference: func(obj client.Object, flavor dcv1alpha1.PodGroupFlavor) *unstructured.Unstructured {
+			return spark.PodGroupReference(obj.(*dcv1alpha1.SparkCluster), flavor)
+		},
+		StatefulSets: func(obj client.Object) ([]*appsv1.StatefulSet, error) {
+			sc := obj.(*dcv1alpha1.SparkCluster)
+			defaults := r.clusterDefaults()
+
+			var sets []*appsv1.StatefulSet
+			for _, comp := range []spark.Component{spark.ComponentMaster, spark.ComponentWorker} {
+				sts, err := spark.NewStatefulSet(sc, comp, defaults)
+				if err != nil {
+					return nil, err
+				}
+				r.addSidecarAnnotations(sc, sts)
+				sets = append(sets, sts)
+			}
+
+			return sets, nil
+		},
 	}
+}
 
-	if err := r.reconcileResources(ctx, rc); err != nil {
-		log.Error(err, "failed to reconcile cluster resources")
-		return ctrl.Result{}, err
+// sparkComponent adapts a method that reconciles part of a Spark cluster to a
+// core.Component.
+func sparkComponent(fn func(*core.Context, *dcv1alpha1.SparkCluster) error) core.ComponentFunc {
+	return func(ctx *core.Context) error {
+		return fn(ctx, ctx.Object.(*dcv1alpha1.SparkCluster))
 	}
+}
 
-	if err := r.updateStatus(ctx, rc); err != nil {
-		if strings.Contains(err.Error(), genericregistry.OptimisticLockErrorMsg) {
-			log.V(1).Info("cannot update status on modified object, requeuing key for reprocessing")
-			return ctrl.Result{RequeueAfter: 500 * time.Millisecond}, nil
-		}
-
-		log.Error(err, "failed to update cluster status")
-		return ctrl.Result{}, err
+// sparkStatusModifier adapts a method that modifies the status of a Spark
+// cluster to a core.StatusModifier.
+func sparkStatusModifier(fn func(*core.Context, *dcv1alpha1.SparkCluster) (bool, error)) core.StatusModifierFunc {
+	return func(ctx *core.Context) (bool, error) {
+		return fn(ctx, ctx.Object.(*dcv1alpha1.SparkCluster))
 	}
-
-	return ctrl.Result{}, nil
 }
 
-func (r *SparkClusterReconciler) processFinalizers(ctx context.Context, sc *dcv1alpha1.SparkCluster, log logr.Logger) (bool, error) {
-	// no finalizer and no deletion timestamp means this is a new object so we're going to set a finalizer
-	containsFinalizer := controllerutil.ContainsFinalizer(sc, SparkFinalizerName)
-	hasDeletionTimestamp := hasDeletionTimestamp(sc)
-	if !containsFinalizer && !hasDeletionTimestamp {
-		controllerutil.AddFinalizer(sc, SparkFinalizerName)
-		err := r.Update(ctx, sc)
-		if err != nil {
-			log.Error(err, "failed to set finalizer")
-			return false, err
-		}
-		return true, nil
-		// if it has finalizer and has a deletion timestamp then we want to delete some stuff
+//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=sparkclusters,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=sparkclusters/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=sparkclusters/finalizers,verbs=update
+//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=sparkclusters/scale,verbs=get;update
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=services;serviceaccounts,verbs=create;update;patch;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=create;update;patch;list;watch
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies;ingresses,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=networking.istio.io,resources=gateways;virtualservices,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=security.istio.io,resources=peerauthentications;authorizationpolicies,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=scheduling.sigs.k8s.io;scheduling.volcano.sh,resources=podgroups,verbs=create;update;patch;delete;list;watch
+//+kubebuilder:rbac:groups=distributed-compute.dominodatalab.com,resources=clusterqueues,verbs=list;watch
+//+kubebuilder:rbac:groups="",resources=resourcequotas,verbs=list;watch
+//+kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,verbs=use;get;list;watch
+
+// reconcileIstio optionally creates a peer authentication that sets the mTLS
+// mode for cluster workloads and an authorization policy that mirrors the
+// cluster network policies.
+func (r *SparkClusterReconciler) reconcileIstio(ctx *core.Context, sc *dcv1alpha1.SparkCluster) error {
+	if !r.IstioEnabled {
+		return nil
 	}
-	if containsFinalizer && hasDeletionTimestamp {
-		log.Info(fmt.Sprintf("%s has finalizer and deletion timestamp. looking for pvcs to delete", sc.Name))
-		pvcs, err := r.getPvcsForDeletion(ctx, sc)
-		if err != nil {
-			return false, err
-		}
 
-		err = r.deletePvcs(ctx, sc, log, pvcs)
-		if err != nil {
-			return false, err
-		}
-
-		controllerutil.RemoveFinalizer(sc, SparkFinalizerName)
-		err = r.Update(ctx, sc)
-		if err != nil {
-			log.Error(err, "unable to remove finalizer from resource")
-			return false, err
-		}
-	}
-	return false, nil
-}
+	peerAuth := istio.NewPeerAuthentication(&istio.PeerAuthInfo{
+		Name:      spark.InstanceObjectName(sc.Name, spark.ComponentNone),
+		Namespace: sc.Namespace,
+		Labels:    spark.MetadataLabels(sc),
+		Selector:  spark.SelectorLabels(sc),
+		Mode:      sc.Spec.IstioConfig.MutualTLSMode,
+	})
 
-func (r *SparkClusterReconciler) deletePvcs(
-	ctx context.Context,
-	sc *dcv1alpha1.SparkCluster,
-	log logr.Logger,
-	pvcsToDelete []client.Object) error {
-	if len(pvcsToDelete) > 0 {
-		log.Info(fmt.Sprintf("deleting %d pvcs associated with %s", len(pvcsToDelete), sc.Name))
-		err := r.deleteIfExists(ctx, pvcsToDelete...)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("unable to delete %s pvcs", sc.Name))
+	if sc.Spec.IstioConfig.MutualTLSMode == "" {
+		if err := ctx.DeleteIfExists(peerAuth); err != nil {
 			return err
 		}
+	} else if err := ctx.CreateOrUpdateOwnedResource(peerAuth); err != nil {
+		return fmt.Errorf("failed to reconcile peer authentication: %w", err)
 	}
-	return nil
-}
-
-func (r *SparkClusterReconciler) getPvcsForDeletion(
-	ctx context.Context,
-	sc *dcv1alpha1.SparkCluster) ([]client.Object, error) {
-	var pvcsToDelete []client.Object
-	claims := &corev1.PersistentVolumeClaimList{}
-	var selectors client.MatchingLabels = spark.SelectorLabels(sc)
-	err := r.List(ctx, claims, client.InNamespace(sc.Namespace), selectors)
-	if err != nil {
-		return pvcsToDelete, err
-	}
-	for _, claim := range claims.Items {
-		// dont bother deleting if its already been deleted
-		if claim.DeletionTimestamp == nil {
-			pvc := claim
-			pvcsToDelete = append(pvcsToDelete, &pvc)
-		}
-	}
-	return pvcsToDelete, err
-}
 
-func hasDeletionTimestamp(sc *dcv1alpha1.SparkCluster) bool {
-	return sc.ObjectMeta.DeletionTimestamp != nil
-}
+	pollerNamespace := spark.MasterStatusPollerNamespace(sc, r.OperatorNamespace, r.KEDANamespace)
+	authzPolicy := spark.NewAuthorizationPolicy(sc, pollerNamespace)
 
-// nolint:dupl
-// reconcileResources manages the creation and updates of resources that
-// collectively comprise a Spark cluster. Each resource is controlled by a parent
-// SparkCluster object so that full cleanup occurs during a delete operation.
-func (r *SparkClusterReconciler) reconcileResources(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
-	if err := r.reconcileServiceAccount(ctx, sc); err != nil {
-		return err
-	}
-	if err := r.reconcileHeadService(ctx, sc); err != nil {
-		return err
-	}
-	if err := r.reconcileHeadlessService(ctx, sc); err != nil {
-		return err
-	}
-	if err := r.reconcileNetworkPolicies(ctx, sc); err != nil {
-		return err
-	}
-	if err := r.reconcilePodSecurityPolicyRBAC(ctx, sc); err != nil {
-		return err
+	if util.BoolPtrIsNilOrFalse(sc.Spec.NetworkPolicy.Enabled) {
+		return ctx.DeleteIfExists(authzPolicy)
 	}
-	if err := r.reconcileAutoscaler(ctx, sc); err != nil {
-		return err
+	if err := ctx.CreateOrUpdateOwnedResource(authzPolicy); err != nil {
+		return fmt.Errorf("failed to reconcile authorization policy: %w", err)
 	}
 
-	return r.reconcileStatefulSets(ctx, sc)
+	return nil
 }
 
 // reconcileServiceAccount creates a new dedicated service account for a Spark
 // cluster unless a different service account name is provided in the spec.
-func (r *SparkClusterReconciler) reconcileServiceAccount(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+func (r *SparkClusterReconciler) reconcileServiceAccount(ctx *core.Context, sc *dcv1alpha1.SparkCluster) error {
 	if sc.Spec.ServiceAccountName != "" {
 		return nil
 	}
 
 	sa := spark.NewServiceAccount(sc)
-	if err := r.createOrUpdateOwnedResource(ctx, sc, sa); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(sa); err != nil {
 		return fmt.Errorf("failed to reconcile service account: %w", err)
 	}
 
@@ -242,9 +357,9 @@ func (r *SparkClusterReconciler) reconcileServiceAccount(ctx context.Context, sc
 
 // reconcileHeadService creates a service that points to the head Spark pod and
 // applies updates when the parent CR changes.
-func (r *SparkClusterReconciler) reconcileHeadService(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+func (r *SparkClusterReconciler) reconcileHeadService(ctx *core.Context, sc *dcv1alpha1.SparkCluster) error {
 	svc := spark.NewMasterService(sc)
-	if err := r.createOrUpdateOwnedResource(ctx, sc, svc); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(svc); err != nil {
 		return fmt.Errorf("failed to reconcile head service: %w", err)
 	}
 
@@ -253,9 +368,9 @@ func (r *SparkClusterReconciler) reconcileHeadService(ctx context.Context, sc *d
 
 // reconcileHeadService creates a service that points to the head Spark pod and
 // applies updates when the parent CR changes.
-func (r *SparkClusterReconciler) reconcileHeadlessService(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+func (r *SparkClusterReconciler) reconcileHeadlessService(ctx *core.Context, sc *dcv1alpha1.SparkCluster) error {
 	svc := spark.NewHeadlessService(sc)
-	if err := r.createOrUpdateOwnedResource(ctx, sc, svc); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(svc); err != nil {
 		return fmt.Errorf("failed to reconcile headless service: %w", err)
 	}
 
@@ -264,71 +379,106 @@ func (r *SparkClusterReconciler) reconcileHeadlessService(ctx context.Context, s
 
 // reconcileNetworkPolicies optionally creates network policies that control
 // traffic flow between cluster nodes and external clients.
-func (r SparkClusterReconciler) reconcileNetworkPolicies(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
+func (r *SparkClusterReconciler) reconcileNetworkPolicies(ctx *core.Context, sc *dcv1alpha1.SparkCluster) error {
 	headNetpol := spark.NewHeadClientNetworkPolicy(sc)
 	clusterNetpol := spark.NewClusterNetworkPolicy(sc)
 	dashboardNetpol := spark.NewHeadDashboardNetworkPolicy(sc)
+	pollerNamespace := spark.MasterStatusPollerNamespace(sc, r.OperatorNamespace, r.KEDANamespace)
+	statusNetpol := spark.NewMasterStatusNetworkPolicy(sc, pollerNamespace)
+	egressNetpol := spark.NewEgressNetworkPolicy(sc)
+
+	executorNetpol := &networkingv1.NetworkPolicy{ObjectMeta: spark.ExecutorNetworkPolicyObjectMeta(sc)}
+	driverNetpol := &networkingv1.NetworkPolicy{ObjectMeta: spark.DriverNetworkPolicyObjectMeta(sc)}
 
 	if !util.BoolPtrIsTrue(sc.Spec.NetworkPolicy.Enabled) {
-		return r.deleteIfExists(ctx, headNetpol, clusterNetpol)
+		return ctx.DeleteIfExists(driverNetpol, executorNetpol, egressNetpol, statusNetpol, dashboardNetpol, headNetpol, clusterNetpol)
 	}
 
-	if err := r.createOrUpdateOwnedResource(ctx, sc, clusterNetpol); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(clusterNetpol); err != nil {
 		return fmt.Errorf("failed to reconcile cluster network policy: %w", err)
 	}
 
-	if err := r.createOrUpdateOwnedResource(ctx, sc, headNetpol); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(headNetpol); err != nil {
 		return fmt.Errorf("failed to reconcile head network policy: %w", err)
 	}
 
-	if err := r.createOrUpdateOwnedResource(ctx, sc, dashboardNetpol); err != nil {
+	if err := ctx.CreateOrUpdateOwnedResource(dashboardNetpol); err != nil {
 		return fmt.Errorf("failed to reconcile dashboard network policy: %w", err)
 	}
 
-	return nil
-}
-
-// nolint:dupl
-// reconcilePodSecurityPolicyRBAC optionally creates a role and role binding
-// that allows the Spark pods to "use" the specified pod security policy.
-func (r *SparkClusterReconciler) reconcilePodSecurityPolicyRBAC(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
-	role, binding := spark.NewPodSecurityPolicyRBAC(sc)
-
-	if sc.Spec.PodSecurityPolicy == "" {
-		return r.deleteIfExists(ctx, role, binding)
+	// a rule without peers would admit every source, so the policy is only
+	// created when the namespace of the poller is known
+	if pollerNamespace == "" {
+		if err := ctx.DeleteIfExists(statusNetpol); err != nil {
+			return err
+		}
+	} else if err := ctx.CreateOrUpdateOwnedResource(statusNetpol); err != nil {
+		return fmt.Errorf("failed to reconcile status network policy: %w", err)
 	}
 
-	err := r.Get(ctx, types.NamespacedName{Name: sc.Spec.PodSecurityPolicy}, &policyv1beta1.PodSecurityPolicy{})
-	if err != nil {
-		return fmt.Errorf("cannot verify pod security policy: %w", err)
+	if !spark.DriverNetworkPolicyEnabled(sc) {
+		if err := ctx.DeleteIfExists(driverNetpol, executorNetpol); err != nil {
+			return err
+		}
+	} else {
+		if err := ctx.CreateOrUpdateOwnedResource(spark.NewExecutorNetworkPolicy(sc)); err != nil {
+			return fmt.Errorf("failed to reconcile executor network policy: %w", err)
+		}
+		if err := ctx.CreateOrUpdateOwnedResource(spark.NewDriverNetworkPolicy(sc)); err != nil {
+			return fmt.Errorf("failed to reconcile driver network policy: %w", err)
+		}
 	}
 
-	if err := r.createOrUpdateOwnedResource(ctx, sc, role); err != nil {
-		return fmt.Errorf("failed to create role: %w", err)
+	if !netpol.EgressEnabled(sc.Spec.NetworkPolicy.Egress) {
+		return ctx.DeleteIfExists(egressNetpol)
 	}
-	if err := r.createOrUpdateOwnedResource(ctx, sc, binding); err != nil {
-		return fmt.Errorf("failed to create role binding: %w", err)
+	if err := ctx.CreateOrUpdateOwnedResource(egressNetpol); err != nil {
+		return fmt.Errorf("failed to reconcile egress network policy: %w", err)
 	}
 
 	return nil
 }
 
-// reconcileAutoscaler optionally creates a horizontal pod autoscaler that
-// targets Spark worker pods.
-func (r *SparkClusterReconciler) reconcileAutoscaler(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
-	if sc.Spec.Autoscaling == nil {
-		hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
-			ObjectMeta: spark.HorizontalPodAutoscalerObjectMeta(sc),
-		}
-		return r.deleteIfExists(ctx, hpa)
+// scaleWorkers adjusts the number of Spark workers using the application
+// demand reported by the master and schedules the next poll. Failures to query
+// the master are expected while the cluster is starting, so they are logged
+// and retried on the next poll instead of being returned.
+func (r *SparkClusterReconciler) scaleWorkers(ctx *core.Context, sc *dcv1alpha1.SparkCluster) (ctrl.Result, error) {
+	if as := sc.Spec.Autoscaling; as == nil || as.Backend != dcv1alpha1.AutoscalingBackendSparkMaster {
+		return ctrl.Result{}, nil
 	}
 
-	hpa, err := spark.NewHorizontalPodAutoscaler(sc)
+	log := ctx.Log
+
+	replicas, err := r.MasterScaler.Scale(ctx, sc)
 	if err != nil {
+		log.Info("cannot scale workers from master demand", "error", err.Error())
+	} else {
+		log.V(1).Info("scaled workers from master demand", "replicas", replicas)
+	}
+
+	return ctrl.Result{RequeueAfter: sparkMasterScalerInterval}, nil
+}
+
+// reconcileAuthSecret generates the shared authentication secret when it is
+// missing. The secret is never rotated because running drivers would lose
+// access to the cluster.
+func (r *SparkClusterReconciler) reconcileAuthSecret(ctx *core.Context, sc *dcv1alpha1.SparkCluster) error {
+	secret := &corev1.Secret{ObjectMeta: spark.AuthSecretObjectMeta(sc)}
+	if !spark.AuthEnabled(sc) {
+		return ctx.DeleteIfExists(secret)
+	}
+
+	err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if secret, err = spark.NewAuthSecret(sc); err != nil {
 		return err
 	}
-	if err = r.createOrUpdateOwnedResource(ctx, sc, hpa); err != nil {
-		return fmt.Errorf("failed to reconcile horizontal pod autoscaler: %w", err)
+	if err = ctx.CreateOrUpdateOwnedResource(secret); err != nil {
+		return fmt.Errorf("failed to create auth secret: %w", err)
 	}
 
 	return nil
@@ -336,178 +486,176 @@ func (r *SparkClusterReconciler) reconcileAutoscaler(ctx context.Context, sc *dc
 
 // reconcileStatefulSets creates separate Spark head and worker statefulsets that
 // will collectively comprise the execution agents of the cluster.
-func (r *SparkClusterReconciler) reconcileStatefulSets(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
-	head, err := spark.NewStatefulSet(sc, spark.ComponentMaster)
+func (r *SparkClusterReconciler) reconcileStatefulSets(ctx *core.Context, sc *dcv1alpha1.SparkCluster) error {
+	defaults := r.clusterDefaults()
+	head, err := spark.NewStatefulSet(sc, spark.ComponentMaster, defaults)
 	if err != nil {
 		return err
 	}
-	if err = r.createOrUpdateOwnedResource(ctx, sc, head); err != nil {
+	r.addSidecarAnnotations(sc, head)
+	if err = r.addAuthAnnotations(ctx, sc, head); err != nil {
+		return err
+	}
+	if err = ctx.CreateOrUpdateOwnedResource(head); err != nil {
 		return fmt.Errorf("failed to create head deployment: %w", err)
 	}
 
-	worker, err := spark.NewStatefulSet(sc, spark.ComponentWorker)
+	worker, err := spark.NewStatefulSet(sc, spark.ComponentWorker, defaults)
 	if err != nil {
 		return err
 	}
-	if err = r.createOrUpdateOwnedResource(ctx, sc, worker); err != nil {
+	r.addSidecarAnnotations(sc, worker)
+	if err = r.addAuthAnnotations(ctx, sc, worker); err != nil {
+		return err
+	}
+	if err = ctx.CreateOrUpdateOwnedResource(worker); err != nil {
 		return fmt.Errorf("failed to create worker deployment: %w", err)
 	}
 
-	selector, err := metav1.LabelSelectorAsSelector(worker.Spec.Selector)
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
-	log := r.getLogger(ctx)
+// addSidecarAnnotations holds spark processes until the sidecar proxy is ready
+// and lets driver traffic bypass it when istio is enabled. Annotations
+// provided in the spec take precedence.
+func (r *SparkClusterReconciler) addSidecarAnnotations(sc *dcv1alpha1.SparkCluster, sts *appsv1.StatefulSet) {
+	if !r.IstioEnabled {
+		return
+	}
 
-	// update autoscaling fields
-	var updateStatus bool
-	if sc.Status.WorkerReplicas != *worker.Spec.Replicas {
-		sc.Status.WorkerReplicas = *worker.Spec.Replicas
-		updateStatus = true
+	sidecar := istio.SidecarAnnotations(spark.SidecarExcludedPorts(sc))
+	sts.Spec.Template.Annotations = util.MergeStringMaps(sts.Spec.Template.Annotations, sidecar)
+}
 
-		log.V(1).Info("updating status", "path", ".status.workerReplicas", "value", sc.Status.WorkerReplicas)
+// addAuthAnnotations records the checksum of the shared authentication
+// secret on the pod template so that pods are restarted when the secret is
+// regenerated.
+func (r *SparkClusterReconciler) addAuthAnnotations(ctx *core.Context, sc *dcv1alpha1.SparkCluster, sts *appsv1.StatefulSet) error {
+	if !spark.AuthEnabled(sc) {
+		return nil
 	}
-	if sc.Status.WorkerSelector != selector.String() {
-		sc.Status.WorkerSelector = selector.String()
-		updateStatus = true
 
-		log.V(1).Info("updating status", "path", ".status.workerSelector", "value", sc.Status.WorkerSelector)
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: sc.Namespace, Name: spark.AuthSecretName(sc.Name)}
+	if err := ctx.Client.Get(ctx, key, secret); err != nil {
+		// the secret watch triggers another reconciliation once it is cached
+		return client.IgnoreNotFound(err)
 	}
 
-	if updateStatus {
-		err = r.Status().Update(ctx, sc)
-	}
+	sts.Spec.Template.Annotations = util.MergeStringMaps(
+		map[string]string{spark.AuthChecksumAnnotation: spark.AuthChecksum(secret)},
+		sts.Spec.Template.Annotations,
+	)
 
-	return err
+	return nil
 }
 
-// createOrUpdateOwnedResource should be used to manage the lifecycle of namespace-scoped objects.
-//
-// The CR will become the "owner" of the "controlled" object and cleanup will
-// occur automatically when the CR is deleted.
-//
-// The controller resource will be created if it's missing.
-// The controller resource will be updated if any changes are applicable.
-// Any unexpected api errors will be reported.
-func (r *SparkClusterReconciler) createOrUpdateOwnedResource(ctx context.Context, owner metav1.Object, controlled client.Object) error {
-	if err := ctrl.SetControllerReference(owner, controlled, r.Scheme); err != nil {
-		return err
-	}
-
-	var gvks []schema.GroupVersionKind
-	gvks, _, err := r.Scheme.ObjectKinds(controlled)
+// modifyStatusNodes will ensure the status contains an accurate list of all the pods in the cluster.
+func (r *SparkClusterReconciler) modifyStatusNodes(ctx *core.Context, sc *dcv1alpha1.SparkCluster) (bool, error) {
+	podNames, err := listPodNames(ctx, spark.MetadataLabels(sc))
 	if err != nil {
-		return err
+		return false, fmt.Errorf("cannot list spark pods: %w", err)
 	}
-	gvk := gvks[0]
 
-	log := r.Log.FromContext(ctx)
+	if reflect.DeepEqual(podNames, sc.Status.Nodes) {
+		return false, nil
+	}
 
-	found := controlled.DeepCopyObject().(client.Object)
-	if err = r.Get(ctx, client.ObjectKeyFromObject(controlled), found); err != nil {
-		if !apierrors.IsNotFound(err) {
-			return err
-		}
+	ctx.Log.V(1).Info("modifying status", "path", ".status.nodes", "value", podNames)
+	sc.Status.Nodes = podNames
 
-		if err = PatchAnnotator.SetLastAppliedAnnotation(controlled); err != nil {
-			return err
-		}
+	return true, nil
+}
 
-		log.Info("creating controlled object", "gvk", gvk, "object", controlled)
-		return r.Create(ctx, controlled)
+// modifyStatusWorkerFields syncs the replicas and selector of the desired
+// worker stateful set into the status used by the scale subresource.
+func (r *SparkClusterReconciler) modifyStatusWorkerFields(ctx *core.Context, sc *dcv1alpha1.SparkCluster) (bool, error) {
+	defaults := r.clusterDefaults()
+	worker, err := spark.NewStatefulSet(sc, spark.ComponentWorker, defaults)
+	if err != nil {
+		return false, err
 	}
 
-	patchResult, err := PatchMaker.Calculate(found, controlled, patch.IgnoreStatusFields())
+	selector, err := metav1.LabelSelectorAsSelector(worker.Spec.Selector)
 	if err != nil {
-		return err
-	}
-	if patchResult.IsEmpty() {
-		return nil
+		return false, err
 	}
 
-	log.V(1).Info("applying patch to object", "gvk", gvk, "object", controlled, "patch", string(patchResult.Patch))
-	if err = PatchAnnotator.SetLastAppliedAnnotation(controlled); err != nil {
-		return err
+	log := ctx.Log
+
+	var modified bool
+	if sc.Status.WorkerReplicas != *worker.Spec.Replicas {
+		sc.Status.WorkerReplicas = *worker.Spec.Replicas
+		modified = true
+
+		log.V(1).Info("modifying status", "path", ".status.workerReplicas", "value", sc.Status.WorkerReplicas)
 	}
+	if sc.Status.WorkerSelector != selector.String() {
+		sc.Status.WorkerSelector = selector.String()
+		modified = true
 
-	controlled.SetResourceVersion(found.GetResourceVersion())
-	if modified, ok := controlled.(*corev1.Service); ok {
-		current := found.(*corev1.Service)
-		modified.Spec.ClusterIP = current.Spec.ClusterIP
+		log.V(1).Info("modifying status", "path", ".status.workerSelector", "value", sc.Status.WorkerSelector)
 	}
 
-	log.Info("updating controlled object", "gvk", gvk, "object", controlled)
-	return r.Update(ctx, controlled)
+	return modified, nil
 }
 
-// deleteIfExists will delete one or more Kubernetes objects if they exist.
-func (r *SparkClusterReconciler) deleteIfExists(ctx context.Context, objs ...client.Object) error {
-	log := r.getLogger(ctx)
-
-	for _, obj := range objs {
-		err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj)
-
-		if apierrors.IsNotFound(err) {
-			return nil
+// modifyStatusURLs publishes the external addresses of exposed endpoints.
+func (r *SparkClusterReconciler) modifyStatusURLs(ctx *core.Context, sc *dcv1alpha1.SparkCluster) (bool, error) {
+	var address string
+	var nodePorts map[int32]int32
+	switch {
+	case expose.Uses(sc.Spec.Expose, dcv1alpha1.ExposeTypeIngress):
+		ingress := &networkingv1.Ingress{}
+		key := types.NamespacedName{Namespace: sc.Namespace, Name: spark.ExposeObjectMeta(sc).Name}
+		if err := ctx.Client.Get(ctx, key, ingress); client.IgnoreNotFound(err) != nil {
+			return false, err
 		}
-		if err != nil {
-			return err
+		address = expose.LoadBalancerAddress(ingress.Status.LoadBalancer.Ingress)
+	case expose.Uses(sc.Spec.Expose, dcv1alpha1.ExposeTypeLoadBalancer):
+		svc := &corev1.Service{}
+		key := types.NamespacedName{Namespace: sc.Namespace, Name: spark.HeadServiceName(sc.Name)}
+		if err := ctx.Client.Get(ctx, key, svc); client.IgnoreNotFound(err) != nil {
+			return false, err
 		}
-
-		log.Info("deleting controlled object", "object", obj)
-		if err = r.Delete(ctx, obj); err != nil {
-			return err
+		address = expose.LoadBalancerAddress(svc.Status.LoadBalancer.Ingress)
+	case expose.Uses(sc.Spec.Expose, dcv1alpha1.ExposeTypeNodePort):
+		var err error
+		headLabels := spark.SelectorLabelsWithComponent(sc, spark.ComponentMaster)
+		address, nodePorts, err = nodePortEndpoint(ctx, spark.HeadServiceName(sc.Name), headLabels)
+		if err != nil {
+			return false, err
 		}
 	}
 
-	return nil
-}
-
-// updateStatus with a list of pods from both the head and worker deployments.
-func (r *SparkClusterReconciler) updateStatus(ctx context.Context, sc *dcv1alpha1.SparkCluster) error {
-	podList := &corev1.PodList{}
-	listOpts := []client.ListOption{
-		client.InNamespace(sc.Namespace),
-		client.MatchingLabels(spark.MetadataLabels(sc)),
-	}
-	if err := r.List(ctx, podList, listOpts...); err != nil {
-		return fmt.Errorf("cannot list spark pods: %w", err)
+	dashboardURL, clientURL := spark.ExposedURLs(sc, address, nodePorts)
+	if sc.Status.DashboardURL == dashboardURL && sc.Status.ClientURL == clientURL {
+		return false, nil
 	}
 
-	var podNames []string
-	for _, pod := range podList.Items {
-		podNames = append(podNames, pod.Name)
-	}
-	sort.Strings(podNames)
+	log := ctx.Log
+	log.V(1).Info("modifying status", "path", ".status.dashboardURL", "value", dashboardURL)
+	log.V(1).Info("modifying status", "path", ".status.clientURL", "value", clientURL)
 
-	if reflect.DeepEqual(podNames, sc.Status.Nodes) {
-		return nil
-	}
-	sc.Status.Nodes = podNames
-
-	if err := r.Status().Update(ctx, sc); err != nil {
-		return fmt.Errorf("cannot update spark status nodes: %w", err)
-	}
+	sc.Status.DashboardURL = dashboardURL
+	sc.Status.ClientURL = clientURL
 
-	return nil
+	return true, nil
 }
 
-type loggerKeyType int
-
-const loggerKey loggerKeyType = iota
-
-func (r *SparkClusterReconciler) setLogger(ctx context.Context, logger logr.Logger) (context.Context, logr.Logger) {
-	return context.WithValue(ctx, loggerKey, logger), logger
-}
-
-func (r *SparkClusterReconciler) getLogger(ctx context.Context) logr.Logger {
-	if ctx == nil {
-		return r.Log
+// modifyStatusAuth publishes the name of the shared authentication secret.
+func (r *SparkClusterReconciler) modifyStatusAuth(ctx *core.Context, sc *dcv1alpha1.SparkCluster) (bool, error) {
+	var name string
+	if spark.AuthEnabled(sc) {
+		name = spark.AuthSecretName(sc.Name)
 	}
-	if ctxLogger, ok := ctx.Value(loggerKey).(logr.Logger); ok {
-		return ctxLogger
+	if sc.Status.AuthSecretName == name {
+		return false, nil
 	}
 
-	return r.Log
+	log := ctx.Log
+	log.V(1).Info("modifying status", "path", ".status.authSecretName", "value", name)
+	sc.Status.AuthSecretName = name
+
+	return true, nil
 }
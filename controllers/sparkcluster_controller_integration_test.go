@@ -101,10 +101,25 @@ var _ = Describe("SparkCluster Controller", func() {
 					Namespace: "default",
 					Name:      name,
 				}, &cluster)).To(Succeed())
-				return len(cluster.Finalizers) == 1 && cluster.Finalizers[0] == SparkFinalizerName
+				return len(cluster.Finalizers) == 1 && cluster.Finalizers[0] == DistributedComputeFinalizer
 			}, timeout).Should(BeTrue())
 		})
 
+		It("should replace the legacy finalizer", func() {
+			ctx := context.Background()
+			name := "finalizer-legacy"
+			timeout := time.Second * 10
+			createAndBasicTest(ctx, name, SparkFinalizerName)
+			cluster := dcv1alpha1.SparkCluster{}
+			Eventually(func() []string {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Namespace: "default",
+					Name:      name,
+				}, &cluster)).To(Succeed())
+				return cluster.Finalizers
+			}, timeout).Should(Equal([]string{DistributedComputeFinalizer}))
+		})
+
 		It("should delete the finalizer", func() {
 			ctx := context.Background()
 			name := "finalizer-delete"
@@ -141,7 +156,7 @@ var _ = Describe("SparkCluster Controller", func() {
 	})
 })
 
-func createAndBasicTest(ctx context.Context, name string) {
+func createAndBasicTest(ctx context.Context, name string, finalizers ...string) {
 	psp := &policyv1beta1.PodSecurityPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
@@ -163,8 +178,9 @@ func createAndBasicTest(ctx context.Context, name string) {
 	}
 	sparkCluster := &dcv1alpha1.SparkCluster{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: "default",
+			Name:       name,
+			Namespace:  "default",
+			Finalizers: finalizers,
 		},
 		Spec: dcv1alpha1.SparkClusterSpec{
 			Image: &dcv1alpha1.OCIImageDefinition{
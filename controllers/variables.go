@@ -2,25 +2,15 @@ package controllers
 
 import (
 	"path"
-
-	"github.com/banzaicloud/k8s-objectmatcher/patch"
+	"time"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
 
-var (
-	// DistributedComputeFinalizer is the custom identifier used to mark
-	// controller-managed resources that require pre-delete hook logic.
-	DistributedComputeFinalizer = path.Join(dcv1alpha1.GroupVersion.Group, "finalizer")
+// admissionRetryInterval is the frequency at which queued clusters are
+// re-evaluated for admission.
+const admissionRetryInterval = 30 * time.Second
 
-	// PatchAnnotator applies state annotations to owned components.
-	PatchAnnotator = patch.NewAnnotator(path.Join(dcv1alpha1.GroupVersion.Group, "last-applied"))
-	// PatchMaker calculates changes to state annotations on owned components.
-	PatchMaker = patch.NewPatchMaker(PatchAnnotator)
-	// PatchCalculateOpts define the exclusion rules used when calculating the
-	// difference between two k8s resources.
-	PatchCalculateOpts = []patch.CalculateOption{
-		patch.IgnoreStatusFields(),
-		patch.IgnoreVolumeClaimTemplateTypeMetaAndStatus(),
-	}
-)
+// DistributedComputeFinalizer is the custom identifier used to mark
+// controller-managed resources that require pre-delete hook logic.
+var DistributedComputeFinalizer = path.Join(dcv1alpha1.GroupVersion.Group, "finalizer")
@@ -0,0 +1,257 @@
+{{/*
+Rules granting the manager access to the resources of the clusters it reconciles
+*/}}
+{{- define "dco.rbac.clusterRules" }}
+- apiGroups:
+  - distributed-compute.dominodatalab.com
+  resources:
+  - rayclusters
+  - sparkclusters
+  verbs:
+  - update
+  - list
+  - watch
+- apiGroups:
+  - distributed-compute.dominodatalab.com
+  resources:
+  - clusterqueues
+  verbs:
+  - list
+  - watch
+- apiGroups:
+  - distributed-compute.dominodatalab.com
+  resources:
+  - computepolicies
+  verbs:
+  - list
+- apiGroups:
+  - distributed-compute.dominodatalab.com
+  resources:
+  - rayclusters/status
+  - sparkclusters/status
+  verbs:
+  - update
+- apiGroups:
+  - distributed-compute.dominodatalab.com
+  resources:
+  - rayclusters/finalizers
+  - sparkclusters/finalizers
+  verbs:
+  - update
+- apiGroups:
+  - distributed-compute.dominodatalab.com
+  resources:
+  - sparkclusters/scale
+  verbs:
+  - get
+  - update
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - list
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - services
+  - serviceaccounts
+  verbs:
+  - create
+  - update
+  - patch
+  - list
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - resourcequotas
+  verbs:
+  - list
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - events
+  verbs:
+  - create
+  - patch
+- apiGroups:
+  - ""
+  resources:
+  - persistentvolumeclaims
+  verbs:
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - apps
+  resources:
+  - statefulsets
+  verbs:
+  - create
+  - update
+  - patch
+  - list
+  - watch
+- apiGroups:
+  - autoscaling
+  resources:
+  - horizontalpodautoscalers
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - cert-manager.io
+  resources:
+  - certificates
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - monitoring.coreos.com
+  resources:
+  - podmonitors
+  - servicemonitors
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - keda.sh
+  resources:
+  - scaledobjects
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - networking.k8s.io
+  resources:
+  - networkpolicies
+  - ingresses
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - policy
+  resources:
+  - poddisruptionbudgets
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - rbac.authorization.k8s.io
+  resources:
+  - roles
+  - rolebindings
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - networking.istio.io
+  resources:
+  - gateways
+  - virtualservices
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - security.istio.io
+  resources:
+  - peerauthentications
+  - authorizationpolicies
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - scheduling.sigs.k8s.io
+  - scheduling.volcano.sh
+  resources:
+  - podgroups
+  verbs:
+  - create
+  - update
+  - patch
+  - delete
+  - list
+  - watch
+{{- end -}}
+
+{{/*
+Rules granting the manager access to its leader election lock
+*/}}
+{{- define "dco.rbac.leaderElectionRules" }}
+- apiGroups:
+  - ""
+  resources:
+  - configmaps
+  verbs:
+  - get
+  - create
+  - update
+- apiGroups:
+  - coordination.k8s.io
+  resources:
+  - leases
+  verbs:
+  - get
+  - create
+  - update
+{{- end -}}
+
+{{/*
+Returns true when the manager reconciles clusters outside of the release
+namespace and its cluster rules must be granted with a ClusterRole
+*/}}
+{{- define "dco.rbac.clusterScoped" -}}
+{{- if or .Values.watch.clusterWide .Values.watch.namespaces -}}
+true
+{{- end -}}
+{{- end -}}
@@ -0,0 +1,237 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/ray"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/spark"
+)
+
+const (
+	rayClusterKind   = "RayCluster"
+	sparkClusterKind = "SparkCluster"
+)
+
+// Admitter decides whether compute clusters have enough capacity to create
+// their resources.
+//
+// Clusters that reference a ClusterQueue share the capacity of that queue.
+// All other clusters in a namespace share the headroom of its resource
+// quotas. Quota usage only reflects pods that have been created, so clusters
+// admitted in quick succession may still exceed the quota when their pods
+// have not started yet.
+type Admitter struct {
+	Client client.Client
+
+	// Log records the clusters that are skipped because their demand cannot
+	// be computed. Nothing is logged when it is nil.
+	Log logr.Logger
+}
+
+// cluster captures the admission attributes of a compute cluster.
+type cluster struct {
+	candidate         Candidate
+	queueName         string
+	priorityClassName string
+	head              *appsv1.StatefulSet
+	deleting          bool
+}
+
+// Evaluate returns the admission decision for a RayCluster or SparkCluster.
+// Clusters whose head stateful set already exists were created before
+// queueing was enabled and are always admitted.
+func (a *Admitter) Evaluate(ctx context.Context, obj client.Object) (Decision, error) {
+	target, err := newCluster(obj)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	err = a.Client.Get(ctx, client.ObjectKeyFromObject(target.head), &appsv1.StatefulSet{})
+	if err == nil {
+		return Decision{Admit: true}, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return Decision{}, err
+	}
+
+	candidates, err := a.candidates(ctx, obj.GetNamespace(), target)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	var fits FitFunc
+	if target.queueName == "" {
+		quotas := &corev1.ResourceQuotaList{}
+		if err = a.Client.List(ctx, quotas, client.InNamespace(obj.GetNamespace())); err != nil {
+			return Decision{}, fmt.Errorf("cannot list resource quotas: %w", err)
+		}
+		fits = QuotaFits(quotas.Items)
+	} else {
+		queue := &dcv1alpha1.ClusterQueue{}
+		key := client.ObjectKey{Namespace: obj.GetNamespace(), Name: target.queueName}
+		if err = a.Client.Get(ctx, key, queue); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return Decision{}, err
+			}
+
+			return Decision{
+				Position: 1,
+				Message:  fmt.Sprintf("cluster queue %q not found", target.queueName),
+			}, nil
+		}
+		fits = CapacityFits(queue.Spec.Capacity, Usage(candidates))
+	}
+
+	return Evaluate(target.candidate.Kind, target.candidate.Name, candidates, fits), nil
+}
+
+// candidates returns every cluster in the namespace that shares capacity
+// with the target. Clusters that are being deleted are not queued, but their
+// demand still counts against the queue until they are gone. Clusters whose
+// resources cannot be built are skipped.
+func (a *Admitter) candidates(ctx context.Context, namespace string, target *cluster) ([]Candidate, error) {
+	rayList := &dcv1alpha1.RayClusterList{}
+	if err := a.Client.List(ctx, rayList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("cannot list ray clusters: %w", err)
+	}
+	sparkList := &dcv1alpha1.SparkClusterList{}
+	if err := a.Client.List(ctx, sparkList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("cannot list spark clusters: %w", err)
+	}
+
+	var objs []client.Object
+	for idx := range rayList.Items {
+		objs = append(objs, &rayList.Items[idx])
+	}
+	for idx := range sparkList.Items {
+		objs = append(objs, &sparkList.Items[idx])
+	}
+
+	priorities := map[string]int32{}
+	candidates := []Candidate{target.candidate}
+
+	for _, obj := range objs {
+		c, err := newCluster(obj)
+		if err != nil {
+			// a malformed cluster must not block the admission of others
+			a.log().Error(err, "skipping cluster with unknown demand",
+				"namespace", namespace, "name", obj.GetName())
+			continue
+		}
+		if c.queueName != target.queueName {
+			continue
+		}
+		if c.candidate.Kind == target.candidate.Kind && c.candidate.Name == target.candidate.Name {
+			continue
+		}
+		if c.deleting && !c.candidate.Admitted {
+			continue
+		}
+
+		if c.candidate.Priority, err = a.priority(ctx, priorities, c.priorityClassName); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c.candidate)
+	}
+
+	var err error
+	if candidates[0].Priority, err = a.priority(ctx, priorities, target.priorityClassName); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+func (a *Admitter) log() logr.Logger {
+	if a.Log == nil {
+		return logr.Discard()
+	}
+
+	return a.Log
+}
+
+// priority resolves the value of a priority class. Missing classes are
+// treated as the default priority of zero.
+func (a *Admitter) priority(ctx context.Context, cache map[string]int32, name string) (int32, error) {
+	if name == "" {
+		return 0, nil
+	}
+	if value, ok := cache[name]; ok {
+		return value, nil
+	}
+
+	pc := &schedulingv1.PriorityClass{}
+	if err := a.Client.Get(ctx, client.ObjectKey{Name: name}, pc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("cannot get priority class %q: %w", name, err)
+		}
+		pc.Value = 0
+	}
+	cache[name] = pc.Value
+
+	return pc.Value, nil
+}
+
+// newCluster captures the admission attributes of a compute cluster. The
+// built-in operator defaults are used to build its stateful sets since they
+// do not affect resource demand.
+func newCluster(obj client.Object) (*cluster, error) {
+	var (
+		defaults     = dcv1alpha1.NewClusterDefaults()
+		c            = &cluster{deleting: obj.GetDeletionTimestamp() != nil}
+		scheduling   *dcv1alpha1.SchedulingConfig
+		phase        dcv1alpha1.ClusterPhase
+		head, worker *appsv1.StatefulSet
+		err          error
+	)
+
+	switch cr := obj.(type) {
+	case *dcv1alpha1.RayCluster:
+		c.candidate.Kind = rayClusterKind
+		c.queueName = cr.Spec.QueueName
+		scheduling = cr.Spec.Scheduling
+		phase = cr.Status.Phase
+
+		if head, err = ray.NewStatefulSet(cr, ray.ComponentHead, defaults.Ray); err != nil {
+			return nil, err
+		}
+		if worker, err = ray.NewStatefulSet(cr, ray.ComponentWorker, defaults.Ray); err != nil {
+			return nil, err
+		}
+	case *dcv1alpha1.SparkCluster:
+		c.candidate.Kind = sparkClusterKind
+		c.queueName = cr.Spec.QueueName
+		scheduling = cr.Spec.Scheduling
+		phase = cr.Status.Phase
+
+		if head, err = spark.NewStatefulSet(cr, spark.ComponentMaster, defaults.Spark); err != nil {
+			return nil, err
+		}
+		if worker, err = spark.NewStatefulSet(cr, spark.ComponentWorker, defaults.Spark); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("cannot admit object of type %T", obj)
+	}
+
+	if scheduling != nil {
+		c.priorityClassName = scheduling.PriorityClassName
+	}
+
+	c.head = head
+	c.candidate.Name = obj.GetName()
+	c.candidate.CreationTimestamp = obj.GetCreationTimestamp()
+	c.candidate.Admitted = phase == dcv1alpha1.ClusterPhaseAdmitted
+	c.candidate.Demand = Demand(head, worker)
+
+	return c, nil
+}
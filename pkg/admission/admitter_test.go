@@ -0,0 +1,65 @@
+package admission
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func sparkCluster(name string) *dcv1alpha1.SparkCluster {
+	return &dcv1alpha1.SparkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec: dcv1alpha1.SparkClusterSpec{
+			Image: &dcv1alpha1.OCIImageDefinition{
+				Repository: "bitnami/spark",
+				Tag:        "3.0.2",
+			},
+			ClusterPort:   7077,
+			DashboardPort: 8080,
+			Worker: dcv1alpha1.SparkClusterWorker{
+				Replicas: pointer.Int32Ptr(1),
+			},
+		},
+	}
+}
+
+func TestAdmitterEvaluate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, dcv1alpha1.AddToScheme(scheme))
+
+	t.Run("malformed_neighbor", func(t *testing.T) {
+		target := sparkCluster("target")
+		malformed := sparkCluster("malformed")
+		malformed.Spec.Image.Repository = "INVALID//repo"
+
+		a := &Admitter{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(target, malformed).Build(),
+		}
+
+		decision, err := a.Evaluate(context.Background(), target)
+		require.NoError(t, err)
+		assert.True(t, decision.Admit)
+	})
+
+	t.Run("malformed_target", func(t *testing.T) {
+		target := sparkCluster("target")
+		target.Spec.Image.Repository = "INVALID//repo"
+
+		a := &Admitter{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(target).Build(),
+		}
+
+		_, err := a.Evaluate(context.Background(), target)
+		assert.Error(t, err)
+	})
+}
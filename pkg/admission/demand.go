@@ -0,0 +1,107 @@
+package admission
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// quotaRequestAliases are the standard resources that a resource quota
+// tracks under both a bare and a "requests." prefixed name.
+var quotaRequestAliases = []corev1.ResourceName{
+	corev1.ResourceCPU,
+	corev1.ResourceMemory,
+	corev1.ResourceEphemeralStorage,
+}
+
+// Demand returns the resources consumed by all of the pods created by the
+// provided stateful sets. The result uses the key format of resource quotas,
+// e.g. "requests.cpu", "limits.memory" and "pods", so that it can be compared
+// with quota and queue capacity directly.
+func Demand(sets ...*appsv1.StatefulSet) corev1.ResourceList {
+	demand := corev1.ResourceList{}
+
+	var pods int64
+	for _, sts := range sets {
+		replicas := int64(1)
+		if sts.Spec.Replicas != nil {
+			replicas = int64(*sts.Spec.Replicas)
+		}
+		pods += replicas
+
+		requests, limits := podResources(&sts.Spec.Template.Spec)
+		addScaled(demand, requests, "requests.", replicas)
+		addScaled(demand, limits, "limits.", replicas)
+	}
+
+	for _, name := range quotaRequestAliases {
+		if q, ok := demand["requests."+name]; ok {
+			demand[name] = q.DeepCopy()
+		}
+	}
+	demand[corev1.ResourcePods] = *resource.NewQuantity(pods, resource.DecimalSI)
+
+	return demand
+}
+
+// podResources calculates the effective requests and limits of a pod. Like
+// the scheduler, the larger of the sum of all app containers and the largest
+// init container is used for each resource. Requests default to limits when
+// they are not specified.
+func podResources(spec *corev1.PodSpec) (requests, limits corev1.ResourceList) {
+	requests = corev1.ResourceList{}
+	limits = corev1.ResourceList{}
+
+	for idx := range spec.Containers {
+		res := spec.Containers[idx].Resources
+		addTo(requests, containerRequests(res))
+		addTo(limits, res.Limits)
+	}
+	for idx := range spec.InitContainers {
+		res := spec.InitContainers[idx].Resources
+		maxInto(requests, containerRequests(res))
+		maxInto(limits, res.Limits)
+	}
+
+	return requests, limits
+}
+
+func containerRequests(res corev1.ResourceRequirements) corev1.ResourceList {
+	requests := res.Requests.DeepCopy()
+	if requests == nil {
+		requests = corev1.ResourceList{}
+	}
+	for name, q := range res.Limits {
+		if _, ok := requests[name]; !ok {
+			requests[name] = q.DeepCopy()
+		}
+	}
+
+	return requests
+}
+
+func addTo(dst, src corev1.ResourceList) {
+	for name, q := range src {
+		sum := dst[name]
+		sum.Add(q)
+		dst[name] = sum
+	}
+}
+
+func maxInto(dst, src corev1.ResourceList) {
+	for name, q := range src {
+		if cur, ok := dst[name]; !ok || q.Cmp(cur) > 0 {
+			dst[name] = q.DeepCopy()
+		}
+	}
+}
+
+func addScaled(dst, src corev1.ResourceList, prefix string, factor int64) {
+	for name, q := range src {
+		key := corev1.ResourceName(prefix + string(name))
+
+		sum := dst[key]
+		sum.Add(*resource.NewMilliQuantity(q.MilliValue()*factor, q.Format))
+		dst[key] = sum
+	}
+}
@@ -0,0 +1,111 @@
+package admission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+)
+
+func statefulSet(replicas int32, spec corev1.PodSpec) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32Ptr(replicas),
+			Template: corev1.PodTemplateSpec{Spec: spec},
+		},
+	}
+}
+
+func resources(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func assertQuantity(t *testing.T, expected string, list corev1.ResourceList, name corev1.ResourceName) {
+	t.Helper()
+
+	actual, ok := list[name]
+	if assert.True(t, ok, "missing resource %q", name) {
+		want := resource.MustParse(expected)
+		assert.Zero(t, want.Cmp(actual), "resource %q: expected %s, got %s", name, expected, actual.String())
+	}
+}
+
+func TestDemand(t *testing.T) {
+	t.Run("replicas", func(t *testing.T) {
+		head := statefulSet(1, corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{Requests: resources("500m", "1Gi")}},
+			},
+		})
+		worker := statefulSet(3, corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{
+					Requests: resources("1", "2Gi"),
+					Limits:   resources("2", "4Gi"),
+				}},
+			},
+		})
+
+		demand := Demand(head, worker)
+
+		assertQuantity(t, "3500m", demand, "requests.cpu")
+		assertQuantity(t, "3500m", demand, corev1.ResourceCPU)
+		assertQuantity(t, "7Gi", demand, "requests.memory")
+		assertQuantity(t, "7Gi", demand, corev1.ResourceMemory)
+		assertQuantity(t, "6", demand, "limits.cpu")
+		assertQuantity(t, "12Gi", demand, "limits.memory")
+		assertQuantity(t, "4", demand, corev1.ResourcePods)
+	})
+
+	t.Run("requests_default_to_limits", func(t *testing.T) {
+		sts := statefulSet(2, corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{Limits: resources("1", "1Gi")}},
+			},
+		})
+
+		demand := Demand(sts)
+
+		assertQuantity(t, "2", demand, "requests.cpu")
+		assertQuantity(t, "2Gi", demand, "requests.memory")
+	})
+
+	t.Run("init_containers", func(t *testing.T) {
+		sts := statefulSet(1, corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{Requests: resources("2", "100Mi")}},
+			},
+			Containers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{Requests: resources("500m", "1Gi")}},
+				{Resources: corev1.ResourceRequirements{Requests: resources("500m", "1Gi")}},
+			},
+		})
+
+		demand := Demand(sts)
+
+		assertQuantity(t, "2", demand, "requests.cpu")
+		assertQuantity(t, "2Gi", demand, "requests.memory")
+	})
+
+	t.Run("extended_resources", func(t *testing.T) {
+		gpu := corev1.ResourceName("nvidia.com/gpu")
+		sts := statefulSet(2, corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{gpu: resource.MustParse("1")},
+				}},
+			},
+		})
+
+		demand := Demand(sts)
+
+		assertQuantity(t, "2", demand, "requests.nvidia.com/gpu")
+		assert.NotContains(t, demand, gpu)
+	})
+}
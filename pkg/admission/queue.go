@@ -0,0 +1,164 @@
+package admission
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Candidate describes a cluster that shares admission capacity with others.
+type Candidate struct {
+	// Kind and Name identify the cluster within its namespace.
+	Kind string
+	Name string
+
+	// Priority of the cluster. Higher priority clusters are admitted first.
+	Priority int32
+	// CreationTimestamp orders clusters with equal priority.
+	CreationTimestamp metav1.Time
+	// Admitted is set when the cluster has already been granted capacity.
+	Admitted bool
+	// Demand is the resources requested by the cluster.
+	Demand corev1.ResourceList
+}
+
+// Decision is the result of an admission evaluation.
+type Decision struct {
+	// Admit is set when the cluster may create its resources.
+	Admit bool
+	// Position of the cluster in the queue when it is not admitted.
+	Position int32
+	// Message explains why the cluster was not admitted.
+	Message string
+}
+
+// FitFunc reports whether the given demand fits the available capacity. A
+// message describing the shortage is returned when it does not.
+type FitFunc func(demand corev1.ResourceList) (bool, string)
+
+// Order sorts candidates by descending priority and then by age, oldest
+// first. Kind and name are used to break ties so the order is stable.
+func Order(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+}
+
+// Usage returns the total demand of all admitted candidates.
+func Usage(candidates []Candidate) corev1.ResourceList {
+	used := corev1.ResourceList{}
+	for _, c := range candidates {
+		if c.Admitted {
+			addTo(used, c.Demand)
+		}
+	}
+
+	return used
+}
+
+// Evaluate decides whether the candidate identified by kind and name can be
+// admitted. Queued candidates are admitted strictly in order so that a large
+// cluster at the front of the queue is not starved by smaller ones behind it.
+func Evaluate(kind, name string, candidates []Candidate, fits FitFunc) Decision {
+	var queued []Candidate
+	for _, c := range candidates {
+		if !c.Admitted {
+			queued = append(queued, c)
+		}
+	}
+	Order(queued)
+
+	for idx, c := range queued {
+		if c.Kind != kind || c.Name != name {
+			continue
+		}
+
+		position := int32(idx + 1)
+		if position > 1 {
+			return Decision{
+				Position: position,
+				Message:  fmt.Sprintf("waiting for %d cluster(s) ahead in the queue", idx),
+			}
+		}
+		if ok, msg := fits(c.Demand); !ok {
+			return Decision{Position: position, Message: msg}
+		}
+
+		return Decision{Admit: true}
+	}
+
+	return Decision{Admit: true}
+}
+
+// CapacityFits returns a FitFunc that compares demand with the capacity
+// remaining after the used resources are subtracted. Only the resources
+// listed in the capacity are constrained.
+func CapacityFits(capacity, used corev1.ResourceList) FitFunc {
+	return func(demand corev1.ResourceList) (bool, string) {
+		if name, ok := exceeds(demand, capacity, used); ok {
+			return false, fmt.Sprintf("insufficient %s capacity in queue", name)
+		}
+
+		return true, ""
+	}
+}
+
+// QuotaFits returns a FitFunc that compares demand with the headroom left in
+// each of the provided resource quotas. Quotas with scopes only apply to a
+// subset of pods and are ignored.
+func QuotaFits(quotas []corev1.ResourceQuota) FitFunc {
+	return func(demand corev1.ResourceList) (bool, string) {
+		for idx := range quotas {
+			quota := &quotas[idx]
+			if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+				continue
+			}
+
+			if name, ok := exceeds(demand, quota.Spec.Hard, quota.Status.Used); ok {
+				return false, fmt.Sprintf("insufficient %s in resource quota %q", name, quota.Name)
+			}
+		}
+
+		return true, ""
+	}
+}
+
+// exceeds returns the first resource for which used plus demand is greater
+// than the hard limit.
+func exceeds(demand, hard, used corev1.ResourceList) (corev1.ResourceName, bool) {
+	names := make([]string, 0, len(hard))
+	for name := range hard {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		name := corev1.ResourceName(n)
+
+		want, ok := demand[name]
+		if !ok {
+			continue
+		}
+
+		total := used[name].DeepCopy()
+		total.Add(want)
+		if limit := hard[name]; total.Cmp(limit) > 0 {
+			return name, true
+		}
+	}
+
+	return "", false
+}
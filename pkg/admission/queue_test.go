@@ -0,0 +1,152 @@
+package admission
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var epoch = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func candidate(name string, priority int32, age time.Duration, cpu string) Candidate {
+	return Candidate{
+		Kind:              "RayCluster",
+		Name:              name,
+		Priority:          priority,
+		CreationTimestamp: metav1.NewTime(epoch.Add(-age)),
+		Demand:            corev1.ResourceList{"requests.cpu": resource.MustParse(cpu)},
+	}
+}
+
+func alwaysFits(corev1.ResourceList) (bool, string) {
+	return true, ""
+}
+
+func TestOrder(t *testing.T) {
+	candidates := []Candidate{
+		candidate("young", 0, time.Minute, "1"),
+		candidate("old", 0, time.Hour, "1"),
+		candidate("important", 100, time.Second, "1"),
+		candidate("b-tie", 0, time.Minute, "1"),
+	}
+
+	Order(candidates)
+
+	var names []string
+	for _, c := range candidates {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"important", "old", "b-tie", "young"}, names)
+}
+
+func TestUsage(t *testing.T) {
+	a := candidate("a", 0, 0, "2")
+	a.Admitted = true
+	b := candidate("b", 0, 0, "3")
+	b.Admitted = true
+	c := candidate("c", 0, 0, "5")
+
+	used := Usage([]Candidate{a, b, c})
+
+	assertQuantity(t, "5", used, "requests.cpu")
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Run("front_of_queue", func(t *testing.T) {
+		candidates := []Candidate{
+			candidate("first", 0, time.Hour, "1"),
+			candidate("second", 0, time.Minute, "1"),
+		}
+
+		assert.Equal(t, Decision{Admit: true}, Evaluate("RayCluster", "first", candidates, alwaysFits))
+	})
+
+	t.Run("behind_others", func(t *testing.T) {
+		candidates := []Candidate{
+			candidate("first", 0, time.Hour, "1"),
+			candidate("second", 0, time.Minute, "1"),
+			candidate("third", 0, time.Second, "1"),
+		}
+
+		decision := Evaluate("RayCluster", "third", candidates, alwaysFits)
+		assert.False(t, decision.Admit)
+		assert.Equal(t, int32(3), decision.Position)
+		assert.Equal(t, "waiting for 2 cluster(s) ahead in the queue", decision.Message)
+	})
+
+	t.Run("admitted_are_not_queued", func(t *testing.T) {
+		admitted := candidate("first", 0, time.Hour, "1")
+		admitted.Admitted = true
+		candidates := []Candidate{admitted, candidate("second", 0, time.Minute, "1")}
+
+		assert.True(t, Evaluate("RayCluster", "second", candidates, alwaysFits).Admit)
+	})
+
+	t.Run("does_not_fit", func(t *testing.T) {
+		candidates := []Candidate{candidate("first", 0, time.Hour, "1")}
+		fits := func(corev1.ResourceList) (bool, string) {
+			return false, "no room"
+		}
+
+		assert.Equal(t, Decision{Position: 1, Message: "no room"}, Evaluate("RayCluster", "first", candidates, fits))
+	})
+}
+
+func TestCapacityFits(t *testing.T) {
+	capacity := corev1.ResourceList{
+		"requests.cpu": resource.MustParse("10"),
+		"pods":         resource.MustParse("5"),
+	}
+	used := corev1.ResourceList{"requests.cpu": resource.MustParse("6")}
+	fits := CapacityFits(capacity, used)
+
+	ok, msg := fits(corev1.ResourceList{"requests.cpu": resource.MustParse("4"), "requests.memory": resource.MustParse("1Ti")})
+	assert.True(t, ok)
+	assert.Empty(t, msg)
+
+	ok, msg = fits(corev1.ResourceList{"requests.cpu": resource.MustParse("4500m")})
+	assert.False(t, ok)
+	assert.Equal(t, "insufficient requests.cpu capacity in queue", msg)
+}
+
+func TestQuotaFits(t *testing.T) {
+	quota := func(name, hard, used string) corev1.ResourceQuota {
+		return corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.ResourceQuotaSpec{
+				Hard: corev1.ResourceList{"requests.cpu": resource.MustParse(hard)},
+			},
+			Status: corev1.ResourceQuotaStatus{
+				Used: corev1.ResourceList{"requests.cpu": resource.MustParse(used)},
+			},
+		}
+	}
+	demand := corev1.ResourceList{"requests.cpu": resource.MustParse("2")}
+
+	t.Run("headroom", func(t *testing.T) {
+		ok, _ := QuotaFits([]corev1.ResourceQuota{quota("compute", "10", "8")})(demand)
+		assert.True(t, ok)
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		ok, msg := QuotaFits([]corev1.ResourceQuota{
+			quota("large", "100", "0"),
+			quota("small", "10", "9"),
+		})(demand)
+
+		assert.False(t, ok)
+		assert.Equal(t, `insufficient requests.cpu in resource quota "small"`, msg)
+	})
+
+	t.Run("scoped_quotas_ignored", func(t *testing.T) {
+		scoped := quota("best-effort", "1", "1")
+		scoped.Spec.Scopes = []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort}
+
+		ok, _ := QuotaFits([]corev1.ResourceQuota{scoped})(demand)
+		assert.True(t, ok)
+	})
+}
@@ -0,0 +1,101 @@
+package autoscaler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// MasterStatusAlive is reported by a spark master that is accepting work.
+	MasterStatusAlive = "ALIVE"
+	// WorkerStateAlive is reported by registered workers that can run executors.
+	WorkerStateAlive = "ALIVE"
+	// AppStateWaiting is reported by applications waiting for executor resources.
+	AppStateWaiting = "WAITING"
+	// AppStateRunning is reported by applications that have been granted executors.
+	AppStateRunning = "RUNNING"
+)
+
+// MasterStatus is the subset of the spark master JSON status document used to
+// compute application demand.
+type MasterStatus struct {
+	Status       string             `json:"status"`
+	AliveWorkers int32              `json:"aliveworkers"`
+	Cores        int32              `json:"cores"`
+	CoresUsed    int32              `json:"coresused"`
+	Workers      []MasterWorkerInfo `json:"workers"`
+	ActiveApps   []MasterAppInfo    `json:"activeapps"`
+}
+
+// MasterWorkerInfo describes a worker registered with the spark master.
+type MasterWorkerInfo struct {
+	ID        string `json:"id"`
+	State     string `json:"state"`
+	Cores     int32  `json:"cores"`
+	CoresUsed int32  `json:"coresused"`
+}
+
+// MasterAppInfo describes an application submitted to the spark master.
+type MasterAppInfo struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+	Cores int32  `json:"cores"`
+}
+
+// WaitingApps returns the number of applications waiting for resources.
+func (s *MasterStatus) WaitingApps() (count int32) {
+	for _, app := range s.ActiveApps {
+		if app.State == AppStateWaiting {
+			count++
+		}
+	}
+	return
+}
+
+// CoresPerWorker returns the largest core count advertised by an alive
+// worker. Zero is returned when no workers are alive.
+func (s *MasterStatus) CoresPerWorker() (cores int32) {
+	for _, w := range s.Workers {
+		if w.State == WorkerStateAlive && w.Cores > cores {
+			cores = w.Cores
+		}
+	}
+	return
+}
+
+// CoresRequested estimates the total number of cores required by active
+// applications. The master does not report the core limit of a waiting
+// application, so each one is assumed to require a single worker's worth of
+// cores in addition to the cores that are already in use.
+func (s *MasterStatus) CoresRequested(coresPerWorker int32) int32 {
+	return s.CoresUsed + s.WaitingApps()*coresPerWorker
+}
+
+// FetchMasterStatus retrieves and decodes the JSON status document served by
+// the spark master web UI at the given url.
+func FetchMasterStatus(ctx context.Context, client *http.Client, url string) (*MasterStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot query spark master: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("spark master returned unexpected status %q", resp.Status)
+	}
+
+	status := &MasterStatus{}
+	if err = json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, fmt.Errorf("cannot decode spark master status: %w", err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,77 @@
+package autoscaler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const masterStatusFixture = `{
+  "url": "spark://test-id-spark-master:7077",
+  "workers": [
+    {"id": "worker-1", "host": "10.0.0.1", "cores": 4, "coresused": 4, "state": "ALIVE"},
+    {"id": "worker-2", "host": "10.0.0.2", "cores": 4, "coresused": 2, "state": "ALIVE"},
+    {"id": "worker-3", "host": "10.0.0.3", "cores": 8, "coresused": 0, "state": "DEAD"}
+  ],
+  "aliveworkers": 2,
+  "cores": 8,
+  "coresused": 6,
+  "activeapps": [
+    {"id": "app-1", "name": "etl", "cores": 6, "state": "RUNNING"},
+    {"id": "app-2", "name": "report", "cores": 0, "state": "WAITING"},
+    {"id": "app-3", "name": "train", "cores": 0, "state": "WAITING"}
+  ],
+  "completedapps": [],
+  "activedrivers": [],
+  "status": "ALIVE"
+}`
+
+func newMasterServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestFetchMasterStatus(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		srv := newMasterServer(t, masterStatusFixture)
+
+		status, err := FetchMasterStatus(context.Background(), srv.Client(), srv.URL+"/json/")
+		require.NoError(t, err)
+
+		assert.Equal(t, MasterStatusAlive, status.Status)
+		assert.Equal(t, int32(2), status.AliveWorkers)
+		assert.Equal(t, int32(6), status.CoresUsed)
+		assert.Equal(t, int32(2), status.WaitingApps())
+		assert.Equal(t, int32(4), status.CoresPerWorker())
+		assert.Equal(t, int32(14), status.CoresRequested(4))
+	})
+
+	t.Run("bad_status", func(t *testing.T) {
+		srv := newMasterServer(t, masterStatusFixture)
+
+		_, err := FetchMasterStatus(context.Background(), srv.Client(), srv.URL+"/missing")
+		assert.Error(t, err)
+	})
+
+	t.Run("bad_body", func(t *testing.T) {
+		srv := newMasterServer(t, "<html></html>")
+
+		_, err := FetchMasterStatus(context.Background(), srv.Client(), srv.URL+"/json/")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,41 @@
+package autoscaler
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// ScaleWriter updates the replica count of a resource.
+type ScaleWriter interface {
+	SetReplicas(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string, replicas int32) error
+}
+
+// SubresourceScaleWriter writes replica counts through the scale subresource
+// of the target resource.
+type SubresourceScaleWriter struct {
+	Client dynamic.Interface
+}
+
+// SetReplicas implements ScaleWriter.
+func (w *SubresourceScaleWriter) SetReplicas(
+	ctx context.Context,
+	gvr schema.GroupVersionResource,
+	namespace, name string,
+	replicas int32) error {
+	ri := w.Client.Resource(gvr).Namespace(namespace)
+
+	scale, err := ri.Get(ctx, name, metav1.GetOptions{}, "scale")
+	if err != nil {
+		return err
+	}
+	if err = unstructured.SetNestedField(scale.Object, int64(replicas), "spec", "replicas"); err != nil {
+		return err
+	}
+
+	_, err = ri.Update(ctx, scale, metav1.UpdateOptions{}, "scale")
+	return err
+}
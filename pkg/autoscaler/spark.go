@@ -0,0 +1,152 @@
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/spark"
+)
+
+const (
+	defaultMinReplicas          int32 = 1
+	defaultScaleUpWindow              = 0 * time.Second
+	defaultScaleDownWindow            = 300 * time.Second
+	defaultMasterRequestTimeout       = 10 * time.Second
+)
+
+var sparkClusterGVR = dcv1alpha1.GroupVersion.WithResource("sparkclusters")
+
+// SparkMasterScaler computes the desired number of workers for a SparkCluster
+// from the application demand reported by its spark master and writes the
+// result through the scale subresource of the cluster.
+type SparkMasterScaler struct {
+	HTTPClient *http.Client
+	Scales     ScaleWriter
+	Stabilizer *Stabilizer
+
+	// MasterURL returns the master status endpoint for a cluster. The
+	// in-cluster service address is used when this is nil.
+	MasterURL func(sc *dcv1alpha1.SparkCluster) string
+}
+
+// NewSparkMasterScaler returns a scaler that updates clusters using the
+// provided dynamic client.
+func NewSparkMasterScaler(dc dynamic.Interface) *SparkMasterScaler {
+	return &SparkMasterScaler{
+		HTTPClient: &http.Client{Timeout: defaultMasterRequestTimeout},
+		Scales:     &SubresourceScaleWriter{Client: dc},
+		Stabilizer: NewStabilizer(),
+	}
+}
+
+// Scale queries the spark master for application demand and applies a
+// stabilized worker replica count to the cluster. The applied replica count is
+// returned.
+func (s *SparkMasterScaler) Scale(ctx context.Context, sc *dcv1alpha1.SparkCluster) (int32, error) {
+	as := sc.Spec.Autoscaling
+	if as == nil {
+		return 0, fmt.Errorf("cannot scale cluster without autoscaling config")
+	}
+
+	urlFn := s.MasterURL
+	if urlFn == nil {
+		urlFn = spark.MasterStatusURL
+	}
+
+	status, err := FetchMasterStatus(ctx, s.HTTPClient, urlFn(sc))
+	if err != nil {
+		return 0, err
+	}
+	if status.Status != MasterStatusAlive {
+		return 0, fmt.Errorf("spark master is not ready: status %q", status.Status)
+	}
+
+	current := defaultMinReplicas
+	if sc.Spec.Worker.Replicas != nil {
+		current = *sc.Spec.Worker.Replicas
+	}
+
+	desired := DesiredWorkerReplicas(status, as, workerCores(sc))
+	replicas := s.Stabilizer.Stabilize(
+		client.ObjectKeyFromObject(sc).String(),
+		current,
+		desired,
+		windowDuration(as.ScaleUpStabilizationWindowSeconds, defaultScaleUpWindow),
+		windowDuration(as.ScaleDownStabilizationWindowSeconds, defaultScaleDownWindow),
+	)
+	if replicas == current {
+		return current, nil
+	}
+
+	if err = s.Scales.SetReplicas(ctx, sparkClusterGVR, sc.Namespace, sc.Name, replicas); err != nil {
+		return current, fmt.Errorf("cannot update worker replicas: %w", err)
+	}
+
+	return replicas, nil
+}
+
+// Forget discards the recommendation history recorded for a cluster.
+func (s *SparkMasterScaler) Forget(key client.ObjectKey) {
+	s.Stabilizer.Forget(key.String())
+}
+
+// DesiredWorkerReplicas calculates the number of workers required to satisfy
+// the cores requested by active applications, bounded by the autoscaling
+// limits. The fallback core count is used when no alive workers are reported.
+func DesiredWorkerReplicas(status *MasterStatus, as *dcv1alpha1.Autoscaling, fallbackCores int32) int32 {
+	coresPerWorker := status.CoresPerWorker()
+	if coresPerWorker == 0 {
+		coresPerWorker = fallbackCores
+	}
+	if coresPerWorker < 1 {
+		coresPerWorker = 1
+	}
+
+	requested := status.CoresRequested(coresPerWorker)
+	desired := (requested + coresPerWorker - 1) / coresPerWorker
+
+	minReplicas := defaultMinReplicas
+	if as.MinReplicas != nil {
+		minReplicas = *as.MinReplicas
+	}
+	if desired < minReplicas {
+		desired = minReplicas
+	}
+	if desired > as.MaxReplicas {
+		desired = as.MaxReplicas
+	}
+
+	return desired
+}
+
+// workerCores returns the number of whole cores requested by a worker pod.
+// Fractional requests are rounded down so that worker capacity is never
+// overestimated, but every worker provides at least one core.
+func workerCores(sc *dcv1alpha1.SparkCluster) int32 {
+	cpu, ok := sc.Spec.Worker.Resources.Requests[corev1.ResourceCPU]
+	if !ok {
+		return 1
+	}
+
+	cores := int32(cpu.MilliValue() / 1000)
+	if cores < 1 {
+		return 1
+	}
+
+	return cores
+}
+
+func windowDuration(seconds *int32, def time.Duration) time.Duration {
+	if seconds == nil {
+		return def
+	}
+
+	return time.Duration(*seconds) * time.Second
+}
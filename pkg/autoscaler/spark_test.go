@@ -0,0 +1,176 @@
+package autoscaler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+type fakeScaleWriter struct {
+	gvr       schema.GroupVersionResource
+	namespace string
+	name      string
+	replicas  *int32
+}
+
+func (w *fakeScaleWriter) SetReplicas(_ context.Context, gvr schema.GroupVersionResource, namespace, name string, replicas int32) error {
+	w.gvr = gvr
+	w.namespace = namespace
+	w.name = name
+	w.replicas = pointer.Int32Ptr(replicas)
+
+	return nil
+}
+
+func sparkClusterFixture() *dcv1alpha1.SparkCluster {
+	return &dcv1alpha1.SparkCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-id",
+			Namespace: "fake-ns",
+		},
+		Spec: dcv1alpha1.SparkClusterSpec{
+			Worker: dcv1alpha1.SparkClusterWorker{
+				Replicas: pointer.Int32Ptr(2),
+			},
+			Autoscaling: &dcv1alpha1.Autoscaling{
+				Backend:                             dcv1alpha1.AutoscalingBackendSparkMaster,
+				MaxReplicas:                         10,
+				ScaleDownStabilizationWindowSeconds: pointer.Int32Ptr(0),
+			},
+		},
+	}
+}
+
+func TestSparkMasterScaler_Scale(t *testing.T) {
+	newScaler := func(t *testing.T, body string) (*SparkMasterScaler, *fakeScaleWriter) {
+		srv := newMasterServer(t, body)
+		writer := &fakeScaleWriter{}
+
+		return &SparkMasterScaler{
+			HTTPClient: srv.Client(),
+			Scales:     writer,
+			Stabilizer: NewStabilizer(),
+			MasterURL: func(*dcv1alpha1.SparkCluster) string {
+				return srv.URL + "/json/"
+			},
+		}, writer
+	}
+
+	t.Run("scale_up", func(t *testing.T) {
+		scaler, writer := newScaler(t, masterStatusFixture)
+		sc := sparkClusterFixture()
+
+		replicas, err := scaler.Scale(context.Background(), sc)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(4), replicas)
+		require.NotNil(t, writer.replicas)
+		assert.Equal(t, int32(4), *writer.replicas)
+		assert.Equal(t, "sparkclusters", writer.gvr.Resource)
+		assert.Equal(t, "fake-ns", writer.namespace)
+		assert.Equal(t, "test-id", writer.name)
+	})
+
+	t.Run("max_replicas", func(t *testing.T) {
+		scaler, writer := newScaler(t, masterStatusFixture)
+		sc := sparkClusterFixture()
+		sc.Spec.Autoscaling.MaxReplicas = 3
+
+		_, err := scaler.Scale(context.Background(), sc)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(3), *writer.replicas)
+	})
+
+	t.Run("idle", func(t *testing.T) {
+		scaler, writer := newScaler(t, `{"status": "ALIVE", "coresused": 0, "activeapps": []}`)
+		sc := sparkClusterFixture()
+		sc.Spec.Autoscaling.MinReplicas = pointer.Int32Ptr(1)
+
+		replicas, err := scaler.Scale(context.Background(), sc)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(1), replicas)
+		assert.Equal(t, int32(1), *writer.replicas)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		scaler, writer := newScaler(t, `{"status": "ALIVE", "workers": [{"state": "ALIVE", "cores": 2}], "coresused": 4}`)
+		sc := sparkClusterFixture()
+
+		replicas, err := scaler.Scale(context.Background(), sc)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), replicas)
+		assert.Nil(t, writer.replicas)
+	})
+
+	t.Run("master_not_alive", func(t *testing.T) {
+		scaler, writer := newScaler(t, `{"status": "RECOVERING"}`)
+
+		_, err := scaler.Scale(context.Background(), sparkClusterFixture())
+		assert.Error(t, err)
+		assert.Nil(t, writer.replicas)
+	})
+
+	t.Run("no_autoscaling", func(t *testing.T) {
+		scaler, _ := newScaler(t, masterStatusFixture)
+		sc := sparkClusterFixture()
+		sc.Spec.Autoscaling = nil
+
+		_, err := scaler.Scale(context.Background(), sc)
+		assert.Error(t, err)
+	})
+}
+
+func TestDesiredWorkerReplicas(t *testing.T) {
+	as := &dcv1alpha1.Autoscaling{MaxReplicas: 10}
+
+	t.Run("fallback_cores", func(t *testing.T) {
+		status := &MasterStatus{
+			ActiveApps: []MasterAppInfo{{State: AppStateWaiting}},
+		}
+
+		assert.Equal(t, int32(1), DesiredWorkerReplicas(status, as, 2))
+	})
+
+	t.Run("partial_worker", func(t *testing.T) {
+		status := &MasterStatus{
+			CoresUsed: 5,
+			Workers:   []MasterWorkerInfo{{State: WorkerStateAlive, Cores: 2}},
+		}
+
+		assert.Equal(t, int32(3), DesiredWorkerReplicas(status, as, 1))
+	})
+
+	t.Run("worker_cores_from_requests", func(t *testing.T) {
+		for cpu, expected := range map[string]int32{"2": 2, "1500m": 1, "2999m": 2, "500m": 1} {
+			sc := sparkClusterFixture()
+			sc.Spec.Worker.Resources.Requests = corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse(cpu),
+			}
+
+			assert.Equal(t, expected, workerCores(sc), cpu)
+		}
+	})
+
+	t.Run("fractional_worker_cores", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		sc.Spec.Worker.Resources.Requests = corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("1500m"),
+		}
+		status := &MasterStatus{CoresUsed: 3}
+
+		assert.Equal(t, int32(3), DesiredWorkerReplicas(status, as, workerCores(sc)),
+			"three 1.5 cpu workers are needed for three whole cores")
+	})
+}
@@ -0,0 +1,90 @@
+package autoscaler
+
+import (
+	"sync"
+	"time"
+)
+
+type recommendation struct {
+	replicas  int32
+	timestamp time.Time
+}
+
+// Stabilizer dampens replica recommendations using the same approach as the
+// horizontal pod autoscaler. A scale up only occurs when every recommendation
+// within the scale up window exceeds the current replica count, and a scale
+// down only occurs when every recommendation within the scale down window is
+// below it.
+type Stabilizer struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	history map[string][]recommendation
+}
+
+// NewStabilizer returns a stabilizer that uses the system clock.
+func NewStabilizer() *Stabilizer {
+	return &Stabilizer{
+		now:     time.Now,
+		history: map[string][]recommendation{},
+	}
+}
+
+// Stabilize records the desired replica recommendation for key and returns
+// the replica count that should be applied given the current count and the
+// scale up/down windows. The current count seeds the history of new keys.
+func (s *Stabilizer) Stabilize(key string, current, desired int32, upWindow, downWindow time.Duration) int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	maxWindow := upWindow
+	if downWindow > maxWindow {
+		maxWindow = downWindow
+	}
+
+	// without history, e.g. after an operator restart, the current count is
+	// assumed to have been recommended just now so that scaling still waits
+	// for the windows to pass.
+	if len(s.history[key]) == 0 {
+		s.history[key] = []recommendation{{replicas: current, timestamp: now}}
+	}
+
+	upRecommendation := desired
+	downRecommendation := desired
+
+	history := []recommendation{{replicas: desired, timestamp: now}}
+	for _, rec := range s.history[key] {
+		age := now.Sub(rec.timestamp)
+		if age >= maxWindow {
+			continue
+		}
+		history = append(history, rec)
+
+		if age < upWindow && rec.replicas < upRecommendation {
+			upRecommendation = rec.replicas
+		}
+		if age < downWindow && rec.replicas > downRecommendation {
+			downRecommendation = rec.replicas
+		}
+	}
+	s.history[key] = history
+
+	replicas := current
+	if replicas < upRecommendation {
+		replicas = upRecommendation
+	}
+	if replicas > downRecommendation {
+		replicas = downRecommendation
+	}
+
+	return replicas
+}
+
+// Forget removes all recorded recommendations for key.
+func (s *Stabilizer) Forget(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.history, key)
+}
@@ -0,0 +1,91 @@
+package autoscaler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.t
+}
+
+func (c *fakeClock) Step(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func newTestStabilizer() (*Stabilizer, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	s := NewStabilizer()
+	s.now = clock.Now
+
+	return s, clock
+}
+
+func TestStabilizer(t *testing.T) {
+	t.Run("no_windows", func(t *testing.T) {
+		s, _ := newTestStabilizer()
+
+		assert.Equal(t, int32(5), s.Stabilize("key", 1, 5, 0, 0))
+		assert.Equal(t, int32(2), s.Stabilize("key", 5, 2, 0, 0))
+	})
+
+	t.Run("scale_up_window", func(t *testing.T) {
+		s, clock := newTestStabilizer()
+		up := 60 * time.Second
+
+		assert.Equal(t, int32(1), s.Stabilize("key", 1, 1, up, 0))
+
+		clock.Step(30 * time.Second)
+		assert.Equal(t, int32(1), s.Stabilize("key", 1, 4, up, 0), "scaled up inside window")
+
+		clock.Step(31 * time.Second)
+		assert.Equal(t, int32(4), s.Stabilize("key", 1, 4, up, 0))
+	})
+
+	t.Run("scale_down_window", func(t *testing.T) {
+		s, clock := newTestStabilizer()
+		down := 300 * time.Second
+
+		assert.Equal(t, int32(4), s.Stabilize("key", 4, 4, 0, down))
+
+		clock.Step(120 * time.Second)
+		assert.Equal(t, int32(4), s.Stabilize("key", 4, 1, 0, down), "scaled down inside window")
+
+		clock.Step(181 * time.Second)
+		assert.Equal(t, int32(1), s.Stabilize("key", 4, 1, 0, down))
+	})
+
+	t.Run("keys_are_isolated", func(t *testing.T) {
+		s, _ := newTestStabilizer()
+		down := 300 * time.Second
+
+		s.Stabilize("a", 4, 4, 0, down)
+		assert.Equal(t, int32(1), s.Stabilize("b", 1, 1, 0, down))
+	})
+
+	t.Run("seeds_history_with_current", func(t *testing.T) {
+		s, clock := newTestStabilizer()
+		down := 300 * time.Second
+
+		assert.Equal(t, int32(4), s.Stabilize("key", 4, 1, 0, down), "scaled down without history")
+
+		clock.Step(301 * time.Second)
+		assert.Equal(t, int32(1), s.Stabilize("key", 4, 1, 0, down))
+	})
+
+	t.Run("forget", func(t *testing.T) {
+		s, _ := newTestStabilizer()
+		down := 300 * time.Second
+
+		s.Stabilize("key", 4, 4, 0, down)
+		s.Forget("key")
+		assert.Equal(t, int32(1), s.Stabilize("key", 1, 1, 0, down))
+	})
+}
@@ -0,0 +1,170 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+const (
+	certificateBlockType = "CERTIFICATE"
+	privateKeyBlockType  = "EC PRIVATE KEY"
+
+	// clockSkew backdates certificates so that they are valid on hosts
+	// whose clocks lag slightly behind the operator.
+	clockSkew = 5 * time.Minute
+)
+
+var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// KeyPair holds a PEM-encoded certificate and private key.
+type KeyPair struct {
+	Certificate []byte
+	PrivateKey  []byte
+}
+
+// NewCA generates a self-signed certificate authority that is valid from now
+// until the provided duration has elapsed.
+func NewCA(commonName string, validity time.Duration, now time.Time) (*KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate private key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate serial number: %w", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal private key: %w", err)
+	}
+
+	return &KeyPair{
+		Certificate: pem.EncodeToMemory(&pem.Block{Type: certificateBlockType, Bytes: der}),
+		PrivateKey:  pem.EncodeToMemory(&pem.Block{Type: privateKeyBlockType, Bytes: keyDER}),
+	}, nil
+}
+
+// NewSignedCertificate generates a key pair for a certificate that is valid
+// for both server and client authentication with the given names and IP
+// addresses. The certificate is signed by the certificate authority and
+// expires with it.
+func NewSignedCertificate(ca *KeyPair, commonName string, dnsNames []string, ips []net.IP, now time.Time) (*KeyPair, error) {
+	caCert, err := parseCertificate(ca.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse ca certificate: %w", err)
+	}
+	caKey, err := parsePrivateKey(ca.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse ca private key: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate private key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate serial number: %w", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		IPAddresses:  ips,
+		NotBefore:    now.Add(-clockSkew),
+		NotAfter:     caCert.NotAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal private key: %w", err)
+	}
+
+	return &KeyPair{
+		Certificate: pem.EncodeToMemory(&pem.Block{Type: certificateBlockType, Bytes: der}),
+		PrivateKey:  pem.EncodeToMemory(&pem.Block{Type: privateKeyBlockType, Bytes: keyDER}),
+	}, nil
+}
+
+// NotAfter returns the expiry time of the first certificate in the PEM data.
+func NotAfter(certPEM []byte) (time.Time, error) {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return cert.NotAfter, nil
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != certificateBlockType {
+		return nil, errors.New("no certificate found in PEM data")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// parsePrivateKey decodes the EC, PKCS #1 and PKCS #8 keys that are written
+// by this package and cert-manager.
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no private key found in PEM data")
+	}
+
+	switch block.Type {
+	case privateKeyBlockType:
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key block type %q", block.Type)
+	}
+}
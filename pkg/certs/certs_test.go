@@ -0,0 +1,116 @@
+package certs
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCA(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	kp, err := NewCA("test-ca", 24*time.Hour, now)
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(kp.Certificate)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	assert.True(t, cert.IsCA)
+	assert.Equal(t, "test-ca", cert.Subject.CommonName)
+	assert.Equal(t, now.Add(24*time.Hour), cert.NotAfter)
+	assert.NoError(t, cert.CheckSignatureFrom(cert))
+
+	block, _ = pem.Decode(kp.PrivateKey)
+	require.NotNil(t, block)
+	_, err = x509.ParseECPrivateKey(block.Bytes)
+	assert.NoError(t, err)
+}
+
+func TestNotAfter(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	kp, err := NewCA("test-ca", time.Hour, now)
+	require.NoError(t, err)
+
+	actual, err := NotAfter(kp.Certificate)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), actual)
+
+	_, err = NotAfter([]byte("garbage"))
+	assert.Error(t, err)
+
+	_, err = NotAfter(kp.PrivateKey)
+	assert.Error(t, err)
+}
+
+func TestNewSignedCertificate(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	ips := []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("10.0.0.1")}
+
+	verify := func(t *testing.T, ca, kp *KeyPair) {
+		caCert, err := parseCertificate(ca.Certificate)
+		require.NoError(t, err)
+		cert, err := parseCertificate(kp.Certificate)
+		require.NoError(t, err)
+
+		assert.False(t, cert.IsCA)
+		assert.Equal(t, "test-node", cert.Subject.CommonName)
+		assert.Equal(t, []string{"localhost"}, cert.DNSNames)
+		assert.True(t, cert.IPAddresses[1].Equal(net.ParseIP("10.0.0.1")))
+		assert.Equal(t, caCert.NotAfter, cert.NotAfter)
+		assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+		assert.NoError(t, cert.CheckSignatureFrom(caCert))
+
+		_, err = parsePrivateKey(kp.PrivateKey)
+		assert.NoError(t, err)
+	}
+
+	t.Run("ec_ca", func(t *testing.T) {
+		ca, err := NewCA("test-ca", 24*time.Hour, now)
+		require.NoError(t, err)
+
+		kp, err := NewSignedCertificate(ca, "test-node", []string{"localhost"}, ips, now)
+		require.NoError(t, err)
+		verify(t, ca, kp)
+	})
+
+	t.Run("rsa_ca", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		tmpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "test-ca"},
+			NotBefore:             now,
+			NotAfter:              now.Add(time.Hour),
+			KeyUsage:              x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+		require.NoError(t, err)
+
+		ca := &KeyPair{
+			Certificate: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			PrivateKey:  pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		}
+		kp, err := NewSignedCertificate(ca, "test-node", []string{"localhost"}, ips, now)
+		require.NoError(t, err)
+		verify(t, ca, kp)
+	})
+
+	t.Run("invalid_ca", func(t *testing.T) {
+		_, err := NewSignedCertificate(&KeyPair{}, "test-node", nil, nil, now)
+		assert.Error(t, err)
+	})
+}
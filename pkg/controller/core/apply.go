@@ -0,0 +1,145 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/metrics"
+)
+
+// FieldManager identifies the operator as the owner of the fields it sets on
+// resources that are managed with server-side apply.
+const FieldManager = "distributed-compute-operator"
+
+// lastAppliedAnnotations are written to owned resources by patch-based
+// updates and are removed once a resource is managed with server-side apply.
+var lastAppliedAnnotations = []string{LastAppliedAnnotation, legacyLastAppliedAnnotation}
+
+// serverSideApply applies the desired state of a controlled object using the
+// operator field manager.
+//
+// Objects that were previously managed with patch-based updates have their
+// last-applied annotations removed and the operator forcibly takes ownership
+// of its fields. Afterwards, conflicts indicate that another field manager
+// changed fields owned by the operator. They are corrected by reapplying the
+// desired state with force and recorded as drift.
+//
+// The event reason that describes the change is returned, or an empty string
+// when the object was not modified.
+func serverSideApply(
+	ctx context.Context,
+	c client.Client,
+	log logr.Logger,
+	clusterKind string,
+	gvk schema.GroupVersionKind,
+	controlled client.Object,
+) (string, error) {
+	key := client.ObjectKeyFromObject(controlled)
+
+	found := controlled.DeepCopyObject().(client.Object)
+	err := c.Get(ctx, key, found)
+	if client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+	exists := err == nil
+
+	var migrated bool
+	if exists {
+		if migrated, err = removeLastAppliedAnnotations(ctx, c, found); err != nil {
+			return "", fmt.Errorf("cannot remove last-applied annotations: %w", err)
+		}
+	}
+
+	desired, err := applyConfiguration(gvk, controlled)
+	if err != nil {
+		return "", err
+	}
+	// the request body is measured up front because the patch replaces the
+	// desired content with the full object returned by the server
+	data, err := desired.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+
+	opts := []client.PatchOption{client.FieldOwner(FieldManager)}
+	if migrated {
+		log.Info("migrating controlled object to server-side apply", "gvk", gvk, "object", key)
+		opts = append(opts, client.ForceOwnership)
+	}
+
+	err = c.Patch(ctx, desired, client.Apply, opts...)
+	if apierrors.IsConflict(err) {
+		log.Info("correcting drift in controlled object", "gvk", gvk, "object", key, "conflict", err.Error())
+		metrics.RecordDriftCorrection(clusterKind, gvk.Kind)
+
+		err = c.Patch(ctx, desired, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case !exists:
+		log.Info("created controlled object", "gvk", gvk, "object", key)
+		return EventReasonCreated, nil
+	case desired.GetResourceVersion() != found.GetResourceVersion():
+		metrics.RecordPatch(clusterKind, gvk.Kind, len(data))
+
+		log.Info("updated controlled object", "gvk", gvk, "object", key)
+		return EventReasonUpdated, nil
+	default:
+		return "", nil
+	}
+}
+
+// applyConfiguration converts a controlled object into the content sent with
+// a server-side apply request. Status and server-populated metadata are
+// dropped so that the operator does not claim ownership of them.
+func applyConfiguration(gvk schema.GroupVersionKind, obj client.Object) (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	content := map[string]interface{}{}
+	if err = json.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	u.SetResourceVersion("")
+	u.SetManagedFields(nil)
+	unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(u.Object, "status")
+
+	return u, nil
+}
+
+// removeLastAppliedAnnotations patches an object to remove the annotations
+// written by patch-based updates. It returns true when the object had any.
+func removeLastAppliedAnnotations(ctx context.Context, c client.Client, obj client.Object) (bool, error) {
+	original := obj.DeepCopyObject()
+
+	annotations := obj.GetAnnotations()
+	var removed bool
+	for _, key := range lastAppliedAnnotations {
+		if _, ok := annotations[key]; ok {
+			delete(annotations, key)
+			removed = true
+		}
+	}
+	if !removed {
+		return false, nil
+	}
+
+	obj.SetAnnotations(annotations)
+	return true, c.Patch(ctx, obj, client.MergeFrom(original))
+}
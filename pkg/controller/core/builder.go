@@ -0,0 +1,180 @@
+package core
+
+import (
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/logging"
+)
+
+// Options configure the dependencies of a Reconciler. Fields that are not set
+// default to the ones provided by the manager.
+type Options struct {
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Log      logging.ContextLogger
+	Recorder record.EventRecorder
+
+	// ServerSideApply manages owned resources with server-side apply instead
+	// of patch-based updates that rely on last-applied annotations.
+	ServerSideApply bool
+
+	// NamespaceSelector restricts reconciliation to clusters in namespaces
+	// whose labels match. Namespaces are watched so that clusters are
+	// reconciled as soon as their namespace is selected.
+	NamespaceSelector labels.Selector
+
+	// ControllerSelector restricts reconciliation to the clusters managed by
+	// this operator instance. Events for other clusters and the resources
+	// they own are filtered out of the watches.
+	ControllerSelector dcv1alpha1.ControllerSelector
+}
+
+// Builder registers the components and hooks of a cluster kind and creates a
+// controller that reconciles it.
+type Builder struct {
+	ctrl       *builder.Builder
+	reconciler *Reconciler
+	err        error
+}
+
+// NewReconciler returns a Builder for a controller that reconciles objects of
+// the same kind as obj.
+func NewReconciler(mgr ctrl.Manager, obj client.Object, opts Options, forOpts ...builder.ForOption) *Builder {
+	if opts.Scheme == nil {
+		opts.Scheme = mgr.GetScheme()
+	}
+
+	var gvk schema.GroupVersionKind
+	gvks, _, err := opts.Scheme.ObjectKinds(obj)
+	if err == nil {
+		gvk = gvks[0]
+	}
+	kind := gvk.Kind
+
+	if opts.Client == nil {
+		opts.Client = mgr.GetClient()
+	}
+	if opts.Log == nil {
+		opts.Log = logging.New(ctrl.Log.WithName("controllers").WithName(kind))
+	}
+	if opts.Recorder == nil {
+		opts.Recorder = mgr.GetEventRecorderFor(strings.ToLower(kind) + "-controller")
+	}
+
+	r := &Reconciler{
+		client:            opts.Client,
+		scheme:            opts.Scheme,
+		log:               opts.Log,
+		recorder:          opts.Recorder,
+		serverSideApply:   opts.ServerSideApply,
+		namespaceSelector: opts.NamespaceSelector,
+		selector:          opts.ControllerSelector,
+		kind:              kind,
+		listKind:          gvk.GroupVersion().WithKind(kind + "List"),
+		prototype:         obj,
+	}
+
+	forOpts = append(forOpts, builder.WithPredicates(r.clusterPredicate()))
+	b := ctrl.NewControllerManagedBy(mgr).For(obj, forOpts...)
+	if opts.NamespaceSelector != nil {
+		b = b.Watches(
+			&source.Kind{Type: &corev1.Namespace{}},
+			handler.EnqueueRequestsFromMapFunc(r.namespaceRequests),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		)
+	}
+
+	return &Builder{ctrl: b, reconciler: r, err: err}
+}
+
+// Owns watches objects that are controlled by the cluster objects. Events are
+// ignored when the controlling cluster is not selected.
+func (b *Builder) Owns(objs ...client.Object) *Builder {
+	for _, obj := range objs {
+		b.ctrl = b.ctrl.Owns(obj, builder.WithPredicates(predicate.NewPredicateFuncs(b.reconciler.ownerSelected)))
+	}
+	return b
+}
+
+// WatchesPodAddresses reconciles the cluster returned by fn whenever a pod is
+// created or deleted, or its IP changes. Pods are controlled by the stateful
+// sets of a cluster, so they are not covered by Owns.
+func (b *Builder) WatchesPodAddresses(fn PodClusterFunc) *Builder {
+	b.ctrl = b.ctrl.Watches(
+		&source.Kind{Type: &corev1.Pod{}},
+		handler.EnqueueRequestsFromMapFunc(podRequests(fn)),
+		builder.WithPredicates(podAddressChanged()),
+	)
+	return b
+}
+
+// Component appends a component to the ordered list of components that are
+// reconciled for every cluster. The name labels metrics and events.
+func (b *Builder) Component(name string, c Component) *Builder {
+	b.reconciler.components = append(b.reconciler.components, namedComponent{name: name, component: c})
+	return b
+}
+
+// StatusModifier appends a modifier that publishes observed state in the
+// status of every cluster.
+func (b *Builder) StatusModifier(name string, m StatusModifier) *Builder {
+	b.reconciler.modifiers = append(b.reconciler.modifiers, namedModifier{name: name, modifier: m})
+	return b
+}
+
+// Finalizer registers a finalizer that runs fn before a cluster is deleted.
+// Legacy names used by earlier releases are migrated to the new name.
+func (b *Builder) Finalizer(name string, fn FinalizerFunc, legacy ...string) *Builder {
+	b.reconciler.finalizer = &finalizer{name: name, legacy: legacy, fn: fn}
+	return b
+}
+
+// Gate appends a gate that must be passed before any component is reconciled.
+// Clusters that are held back are reconciled again after requeueAfter.
+func (b *Builder) Gate(name string, requeueAfter time.Duration, fn GateFunc) *Builder {
+	b.reconciler.gates = append(b.reconciler.gates, gate{name: name, requeueAfter: requeueAfter, fn: fn})
+	return b
+}
+
+// Result appends a func that schedules the next reconciliation of a cluster.
+func (b *Builder) Result(fn ResultFunc) *Builder {
+	b.reconciler.results = append(b.reconciler.results, fn)
+	return b
+}
+
+// ClusterState sets the func used to report cluster lifecycle metrics.
+func (b *Builder) ClusterState(fn ClusterStateFunc) *Builder {
+	b.reconciler.state = fn
+	return b
+}
+
+// OnDelete appends a func that is called once a cluster no longer exists or
+// is no longer selected.
+func (b *Builder) OnDelete(fn DeleteFunc) *Builder {
+	b.reconciler.onDelete = append(b.reconciler.onDelete, fn)
+	return b
+}
+
+// Complete builds the reconciler and registers its controller with the
+// manager.
+func (b *Builder) Complete() error {
+	if b.err != nil {
+		return b.err
+	}
+
+	return b.ctrl.Complete(b.reconciler)
+}
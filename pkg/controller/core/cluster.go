@@ -0,0 +1,448 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+
+	istionetworking "istio.io/client-go/pkg/apis/networking/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/podsecurity"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/expose"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/keda"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/pdb"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+)
+
+// ClusterSpec holds the settings that every cluster kind supports.
+type ClusterSpec struct {
+	Expose                     *dcv1alpha1.ExposeConfig
+	NetworkPolicyEnabled       bool
+	Monitoring                 *dcv1alpha1.MonitoringConfig
+	Scheduling                 *dcv1alpha1.SchedulingConfig
+	PodDisruptionBudgetEnabled bool
+	Autoscaling                *dcv1alpha1.Autoscaling
+	PodSecurityPolicy          string
+	SecurityContextConstraints string
+}
+
+// ClusterResources builds the resources managed by ClusterComponents for a
+// single cluster kind. Every func receives the cluster being reconciled.
+type ClusterResources struct {
+	// Spec returns the settings of the cluster.
+	Spec func(client.Object) ClusterSpec
+	// PodSecurityViolations references the pod security violations recorded
+	// in the status of the cluster.
+	PodSecurityViolations func(client.Object) *[]string
+
+	ExposeObjectMeta func(client.Object) metav1.ObjectMeta
+	Ingress          func(client.Object) *networkingv1.Ingress
+	Gateway          func(client.Object) *istionetworking.Gateway
+	VirtualService   func(client.Object) *istionetworking.VirtualService
+
+	MonitoringNetworkPolicy func(client.Object) *networkingv1.NetworkPolicy
+	Monitor                 func(client.Object) *unstructured.Unstructured
+	MonitorReference        func(client.Object, dcv1alpha1.MonitorKind) *unstructured.Unstructured
+
+	PodSecurityPolicyRBAC          func(client.Object) (*rbacv1.Role, *rbacv1.RoleBinding)
+	SecurityContextConstraintsRBAC func(client.Object) (*rbacv1.Role, *rbacv1.RoleBinding)
+
+	PodDisruptionBudgets func(client.Object) []*policyv1beta1.PodDisruptionBudget
+
+	HorizontalPodAutoscalerObjectMeta func(client.Object) metav1.ObjectMeta
+	HorizontalPodAutoscaler           func(client.Object) (*autoscalingv2beta2.HorizontalPodAutoscaler, error)
+	ScaledObject                      func(client.Object) (*unstructured.Unstructured, error)
+	ScaledObjectReference             func(client.Object) *unstructured.Unstructured
+
+	PodGroup          func(client.Object) (*unstructured.Unstructured, error)
+	PodGroupReference func(client.Object, dcv1alpha1.PodGroupFlavor) *unstructured.Unstructured
+
+	// StatefulSets returns the stateful sets of the cluster as they would be
+	// reconciled. Their pod templates are checked against the Pod Security
+	// Standards.
+	StatefulSets func(client.Object) ([]*appsv1.StatefulSet, error)
+}
+
+// ClusterComponents reconciles the resources that every cluster kind manages
+// in the same way. Kinds register these components and status modifiers with
+// their own builder and supply the kind-specific resources.
+type ClusterComponents struct {
+	Resources ClusterResources
+
+	IstioEnabled       bool
+	KEDAEnabled        bool
+	PodSecurityBackend podsecurity.Backend
+
+	// OperatorScalers records the autoscaling backends that are implemented
+	// by the operator instead of a HorizontalPodAutoscaler or KEDA, and
+	// whether their scaler is running.
+	OperatorScalers map[dcv1alpha1.AutoscalingBackend]bool
+
+	// policyV1 is set when the API server supports policy/v1 disruption budgets.
+	policyV1 bool
+	// podGroupFlavors records the gang scheduler APIs installed in the cluster.
+	podGroupFlavors map[dcv1alpha1.PodGroupFlavor]bool
+	// monitorKinds records the Prometheus Operator APIs installed in the cluster.
+	monitorKinds map[dcv1alpha1.MonitorKind]bool
+}
+
+// Own discovers the optional APIs installed in the cluster and watches the
+// resources owned by every cluster kind.
+func (c *ClusterComponents) Own(mgr ctrl.Manager, b *Builder) {
+	mapper := mgr.GetRESTMapper()
+	c.policyV1 = pdb.V1Available(mapper)
+
+	b.Owns(
+		&corev1.Service{},
+		&corev1.ServiceAccount{},
+		&corev1.Secret{},
+		&appsv1.StatefulSet{},
+		&rbacv1.Role{},
+		&rbacv1.RoleBinding{},
+		&networkingv1.NetworkPolicy{},
+		&networkingv1.Ingress{},
+		&autoscalingv2beta2.HorizontalPodAutoscaler{},
+		pdb.NewReference("", "", c.policyV1),
+	)
+
+	if c.KEDAEnabled {
+		b.Owns(keda.NewScaledObjectReference("", ""))
+	}
+	if c.IstioEnabled {
+		b.Owns(&istionetworking.Gateway{}, &istionetworking.VirtualService{})
+	}
+
+	c.podGroupFlavors = map[dcv1alpha1.PodGroupFlavor]bool{}
+	for _, flavor := range podgroup.Flavors {
+		if podgroup.Available(mapper, flavor) {
+			c.podGroupFlavors[flavor] = true
+			b.Owns(podgroup.NewPodGroupReference("", "", flavor))
+		}
+	}
+
+	c.monitorKinds = map[dcv1alpha1.MonitorKind]bool{}
+	for _, kind := range monitoring.Kinds {
+		if monitoring.Available(mapper, kind) {
+			c.monitorKinds[kind] = true
+			b.Owns(monitoring.NewMonitorReference("", "", kind))
+		}
+	}
+}
+
+// ReconcileExpose optionally creates an ingress, or an Istio gateway and
+// virtual service, that route external traffic to the cluster head node.
+// Objects belonging to an inactive expose type are removed.
+func (c *ClusterComponents) ReconcileExpose(ctx *Context) error {
+	res := c.Resources
+	meta := res.ExposeObjectMeta(ctx.Object)
+	ingress := &networkingv1.Ingress{ObjectMeta: meta}
+	var gateway, vs client.Object
+	if c.IstioEnabled {
+		gateway = &istionetworking.Gateway{ObjectMeta: meta}
+		vs = &istionetworking.VirtualService{ObjectMeta: meta}
+	}
+
+	cfg := res.Spec(ctx.Object).Expose
+	switch {
+	case expose.Uses(cfg, dcv1alpha1.ExposeTypeIngress):
+		if err := ctx.DeleteIfExists(gateway, vs); err != nil {
+			return err
+		}
+		if err := ctx.CreateOrUpdateOwnedResource(res.Ingress(ctx.Object)); err != nil {
+			return fmt.Errorf("failed to reconcile ingress: %w", err)
+		}
+	case expose.Uses(cfg, dcv1alpha1.ExposeTypeIstioGateway):
+		if !c.IstioEnabled {
+			return fmt.Errorf("cannot use %q expose type when Istio support is disabled", cfg.Type)
+		}
+		if err := ctx.DeleteIfExists(ingress); err != nil {
+			return err
+		}
+		if err := ctx.CreateOrUpdateOwnedResource(res.Gateway(ctx.Object)); err != nil {
+			return fmt.Errorf("failed to reconcile gateway: %w", err)
+		}
+		if err := ctx.CreateOrUpdateOwnedResource(res.VirtualService(ctx.Object)); err != nil {
+			return fmt.Errorf("failed to reconcile virtual service: %w", err)
+		}
+	default:
+		return ctx.DeleteIfExists(ingress, gateway, vs)
+	}
+
+	return nil
+}
+
+// ReconcileMonitoring creates a ServiceMonitor or PodMonitor that scrapes the
+// cluster metrics when monitoring is enabled. Nothing is created when the
+// Prometheus Operator API is not installed. A network policy that admits the
+// monitoring pods is created when network policies are enabled.
+func (c *ClusterComponents) ReconcileMonitoring(ctx *Context) error {
+	res := c.Resources
+	spec := res.Spec(ctx.Object)
+	enabled := monitoring.Enabled(spec.Monitoring)
+
+	metricsNetpol := res.MonitoringNetworkPolicy(ctx.Object)
+	if enabled && spec.NetworkPolicyEnabled {
+		if err := ctx.CreateOrUpdateOwnedResource(metricsNetpol); err != nil {
+			return fmt.Errorf("failed to reconcile monitoring network policy: %w", err)
+		}
+	} else if err := ctx.DeleteIfExists(metricsNetpol); err != nil {
+		return err
+	}
+
+	var stale []client.Object
+	for kind := range c.monitorKinds {
+		if !enabled || kind != spec.Monitoring.Kind {
+			stale = append(stale, res.MonitorReference(ctx.Object, kind))
+		}
+	}
+	if err := ctx.DeleteIfExists(stale...); err != nil {
+		return err
+	}
+
+	if !enabled || !c.monitorKinds[spec.Monitoring.Kind] {
+		return nil
+	}
+	if err := ctx.CreateOrUpdateOwnedResource(res.Monitor(ctx.Object)); err != nil {
+		return fmt.Errorf("failed to reconcile monitor: %w", err)
+	}
+
+	return nil
+}
+
+// ReconcilePodSecurity grants the cluster pods access to the pod security
+// mechanism provided by the configured backend. Pod Security Admission does
+// not require any RBAC so stale resources from other backends are removed.
+func (c *ClusterComponents) ReconcilePodSecurity(ctx *Context) error {
+	res := c.Resources
+	spec := res.Spec(ctx.Object)
+
+	switch c.PodSecurityBackend {
+	case podsecurity.BackendPodSecurityAdmission:
+		role, binding := res.PodSecurityPolicyRBAC(ctx.Object)
+		return ctx.DeleteIfExists(role, binding)
+	case podsecurity.BackendSecurityContextConstraints:
+		role, binding := res.SecurityContextConstraintsRBAC(ctx.Object)
+		if spec.SecurityContextConstraints == "" {
+			return ctx.DeleteIfExists(role, binding)
+		}
+
+		key := types.NamespacedName{Name: spec.SecurityContextConstraints}
+		if err := verifyPodSecurityObject(ctx, key, podsecurity.NewSecurityContextConstraints(),
+			EventReasonSCCNotFound, "security context constraints"); err != nil {
+			return err
+		}
+
+		return createPodSecurityRBAC(ctx, role, binding)
+	default:
+		role, binding := res.PodSecurityPolicyRBAC(ctx.Object)
+		if spec.PodSecurityPolicy == "" {
+			return ctx.DeleteIfExists(role, binding)
+		}
+
+		key := types.NamespacedName{Name: spec.PodSecurityPolicy}
+		if err := verifyPodSecurityObject(ctx, key, &policyv1beta1.PodSecurityPolicy{},
+			EventReasonPodSecurityPolicyNotFound, "pod security policy"); err != nil {
+			return err
+		}
+
+		return createPodSecurityRBAC(ctx, role, binding)
+	}
+}
+
+// verifyPodSecurityObject ensures the cluster-scoped object that the cluster
+// pods are granted access to exists. A missing object is reported with the
+// given event reason.
+func verifyPodSecurityObject(ctx *Context, key types.NamespacedName, obj client.Object, reason, desc string) error {
+	err := ctx.Client.Get(ctx, key, obj)
+	if apierrors.IsNotFound(err) {
+		return &ReasonError{Reason: reason, Err: fmt.Errorf("cannot verify %s: %w", desc, err)}
+	}
+	if err != nil {
+		return fmt.Errorf("cannot verify %s: %w", desc, err)
+	}
+
+	return nil
+}
+
+func createPodSecurityRBAC(ctx *Context, role *rbacv1.Role, binding *rbacv1.RoleBinding) error {
+	if err := ctx.CreateOrUpdateOwnedResource(role); err != nil {
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+	if err := ctx.CreateOrUpdateOwnedResource(binding); err != nil {
+		return fmt.Errorf("failed to create role binding: %w", err)
+	}
+
+	return nil
+}
+
+// ReconcilePodDisruptionBudgets optionally creates disruption budgets that
+// protect the cluster pods from voluntary evictions. Existing budgets will be
+// deleted if enabled is set to false.
+func (c *ClusterComponents) ReconcilePodDisruptionBudgets(ctx *Context) error {
+	res := c.Resources
+
+	var budgets []client.Object
+	for _, budget := range res.PodDisruptionBudgets(ctx.Object) {
+		obj, err := pdb.ForAPIVersion(budget, c.policyV1)
+		if err != nil {
+			return err
+		}
+		budgets = append(budgets, obj)
+	}
+
+	if !res.Spec(ctx.Object).PodDisruptionBudgetEnabled {
+		return ctx.DeleteIfExists(budgets...)
+	}
+
+	for _, budget := range budgets {
+		if err := ctx.CreateOrUpdateOwnedResource(budget); err != nil {
+			return fmt.Errorf("failed to reconcile pod disruption budget: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ReconcileAutoscaler optionally creates a horizontal pod autoscaler or KEDA
+// scaled object that targets the cluster worker pods. Objects belonging to an
+// inactive backend are removed.
+func (c *ClusterComponents) ReconcileAutoscaler(ctx *Context) error {
+	res := c.Resources
+
+	// building the autoscaler when autoscaling is nil will result in error.
+	// so we leverage a shallow reference here instead.
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: res.HorizontalPodAutoscalerObjectMeta(ctx.Object),
+	}
+	var so client.Object
+	if c.KEDAEnabled {
+		so = res.ScaledObjectReference(ctx.Object)
+	}
+
+	as := res.Spec(ctx.Object).Autoscaling
+	running, operatorScaled := false, false
+	if as != nil {
+		running, operatorScaled = c.OperatorScalers[as.Backend]
+	}
+
+	switch {
+	case as == nil:
+		return ctx.DeleteIfExists(hpa, so)
+	case operatorScaled:
+		if !running {
+			return fmt.Errorf("cannot use %q autoscaling backend when its scaler is not running", as.Backend)
+		}
+		return ctx.DeleteIfExists(hpa, so)
+	case as.Backend == dcv1alpha1.AutoscalingBackendKEDA:
+		if !c.KEDAEnabled {
+			return fmt.Errorf("cannot use %q autoscaling backend when KEDA support is disabled", as.Backend)
+		}
+		if err := ctx.DeleteIfExists(hpa); err != nil {
+			return err
+		}
+
+		scaledObject, err := res.ScaledObject(ctx.Object)
+		if err != nil {
+			return err
+		}
+		if err = ctx.CreateOrUpdateOwnedResource(scaledObject); err != nil {
+			return fmt.Errorf("failed to reconcile scaled object: %w", err)
+		}
+	default:
+		if err := ctx.DeleteIfExists(so); err != nil {
+			return err
+		}
+
+		autoscaler, err := res.HorizontalPodAutoscaler(ctx.Object)
+		if err != nil {
+			return err
+		}
+		if err = ctx.CreateOrUpdateOwnedResource(autoscaler); err != nil {
+			return fmt.Errorf("failed to reconcile horizontal pod autoscaler: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ReconcilePodGroup optionally creates a pod group that gang schedules the
+// cluster head and minimum number of workers. Pod groups belonging to an
+// inactive flavor are removed.
+func (c *ClusterComponents) ReconcilePodGroup(ctx *Context) error {
+	res := c.Resources
+	scheduling := res.Spec(ctx.Object).Scheduling
+	enabled := podgroup.Enabled(scheduling)
+	flavor := podgroup.Flavor(scheduling)
+
+	var stale []client.Object
+	for f := range c.podGroupFlavors {
+		if !enabled || f != flavor {
+			stale = append(stale, res.PodGroupReference(ctx.Object, f))
+		}
+	}
+	if err := ctx.DeleteIfExists(stale...); err != nil {
+		return err
+	}
+
+	if !enabled {
+		return nil
+	}
+	if !c.podGroupFlavors[flavor] {
+		return fmt.Errorf("cannot use %q gang scheduling when the PodGroup API is not installed", flavor)
+	}
+
+	pg, err := res.PodGroup(ctx.Object)
+	if err != nil {
+		return err
+	}
+	if err = ctx.CreateOrUpdateOwnedResource(pg); err != nil {
+		return fmt.Errorf("failed to reconcile pod group: %w", err)
+	}
+
+	return nil
+}
+
+// ModifyStatusPodSecurity reports the checks that the generated pod templates
+// fail under the Pod Security Standards level enforced on the namespace.
+func (c *ClusterComponents) ModifyStatusPodSecurity(ctx *Context) (bool, error) {
+	res := c.Resources
+
+	var violations []string
+	if c.PodSecurityBackend == podsecurity.BackendPodSecurityAdmission {
+		ns := &corev1.Namespace{}
+		if err := ctx.Client.Get(ctx, types.NamespacedName{Name: ctx.Object.GetNamespace()}, ns); err != nil {
+			return false, err
+		}
+
+		sets, err := res.StatefulSets(ctx.Object)
+		if err != nil {
+			return false, err
+		}
+
+		violations = podsecurity.Violations(podsecurity.NamespaceLevel(ns), sets...)
+	}
+
+	status := res.PodSecurityViolations(ctx.Object)
+	if reflect.DeepEqual(violations, *status) {
+		return false, nil
+	}
+
+	log := ctx.Log
+	log.V(1).Info("modifying status", "path", ".status.podSecurityViolations", "value", violations)
+	*status = violations
+
+	return true, nil
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/podsecurity"
+)
+
+func testClusterComponents(t *testing.T, spec *ClusterSpec, objs ...client.Object) (*ClusterComponents, *Context) {
+	t.Helper()
+
+	cluster := testCluster()
+	r, _ := testReconciler(t, append(objs, cluster)...)
+	ctx := &Context{
+		Context:  context.Background(),
+		Log:      logf.NullLogger{},
+		Client:   r.client,
+		Scheme:   r.scheme,
+		Recorder: r.recorder,
+		Object:   cluster,
+		Kind:     r.kind,
+	}
+
+	meta := func(suffix string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: testKey.Name + "-" + suffix, Namespace: testKey.Namespace}
+	}
+	c := &ClusterComponents{
+		Resources: ClusterResources{
+			Spec: func(client.Object) ClusterSpec { return *spec },
+			PodSecurityViolations: func(obj client.Object) *[]string {
+				return &obj.(*dcv1alpha1.RayCluster).Status.PodSecurityViolations
+			},
+			PodSecurityPolicyRBAC: func(client.Object) (*rbacv1.Role, *rbacv1.RoleBinding) {
+				return &rbacv1.Role{ObjectMeta: meta("psp")}, &rbacv1.RoleBinding{ObjectMeta: meta("psp")}
+			},
+			HorizontalPodAutoscalerObjectMeta: func(client.Object) metav1.ObjectMeta { return meta("hpa") },
+			ScaledObjectReference:             func(client.Object) *unstructured.Unstructured { return nil },
+			StatefulSets: func(client.Object) ([]*appsv1.StatefulSet, error) {
+				sts := &appsv1.StatefulSet{ObjectMeta: meta("head")}
+				sts.Spec.Template.Spec.HostNetwork = true
+				return []*appsv1.StatefulSet{sts}, nil
+			},
+		},
+	}
+
+	return c, ctx
+}
+
+func TestClusterComponentsPodSecurityPolicy(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: testKey.Name + "-psp", Namespace: testKey.Namespace}
+	role := &rbacv1.Role{ObjectMeta: meta}
+	spec := &ClusterSpec{PodSecurityPolicy: "restricted"}
+	c, ctx := testClusterComponents(t, spec, role, &rbacv1.RoleBinding{ObjectMeta: meta})
+
+	err := c.ReconcilePodSecurity(ctx)
+	var rErr *ReasonError
+	require.True(t, errors.As(err, &rErr))
+	assert.Equal(t, EventReasonPodSecurityPolicyNotFound, rErr.Reason)
+
+	spec.PodSecurityPolicy = ""
+	require.NoError(t, c.ReconcilePodSecurity(ctx))
+	assert.True(t, apierrors.IsNotFound(ctx.Client.Get(ctx, client.ObjectKeyFromObject(role), role)))
+}
+
+func TestClusterComponentsOperatorScaler(t *testing.T) {
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: testKey.Name + "-hpa", Namespace: testKey.Namespace},
+	}
+	spec := &ClusterSpec{Autoscaling: &dcv1alpha1.Autoscaling{Backend: dcv1alpha1.AutoscalingBackendSparkMaster}}
+	c, ctx := testClusterComponents(t, spec, hpa)
+
+	c.OperatorScalers = map[dcv1alpha1.AutoscalingBackend]bool{dcv1alpha1.AutoscalingBackendSparkMaster: false}
+	assert.Error(t, c.ReconcileAutoscaler(ctx))
+
+	c.OperatorScalers[dcv1alpha1.AutoscalingBackendSparkMaster] = true
+	require.NoError(t, c.ReconcileAutoscaler(ctx))
+	err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(hpa), &autoscalingv2beta2.HorizontalPodAutoscaler{})
+	assert.True(t, apierrors.IsNotFound(err), "autoscaler replaced by the operator scaler should be deleted")
+}
+
+func TestClusterComponentsModifyStatusPodSecurity(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   testKey.Namespace,
+		Labels: map[string]string{podsecurity.EnforceLevelLabel: string(podsecurity.LevelBaseline)},
+	}}
+	c, ctx := testClusterComponents(t, &ClusterSpec{}, ns)
+	status := c.Resources.PodSecurityViolations(ctx.Object)
+
+	modified, err := c.ModifyStatusPodSecurity(ctx)
+	require.NoError(t, err)
+	assert.False(t, modified, "violations are only reported with pod security admission")
+
+	c.PodSecurityBackend = podsecurity.BackendPodSecurityAdmission
+	modified, err = c.ModifyStatusPodSecurity(ctx)
+	require.NoError(t, err)
+	assert.True(t, modified)
+	assert.NotEmpty(t, *status)
+
+	modified, err = c.ModifyStatusPodSecurity(ctx)
+	require.NoError(t, err)
+	assert.False(t, modified)
+}
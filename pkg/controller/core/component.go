@@ -0,0 +1,55 @@
+package core
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/metrics"
+)
+
+// Component manages a group of resources that comprise part of a cluster,
+// e.g. its services or stateful sets. Components are reconciled in the order
+// in which they are registered and reconciliation stops at the first error.
+type Component interface {
+	Reconcile(ctx *Context) error
+}
+
+// ComponentFunc adapts an ordinary function to a Component.
+type ComponentFunc func(ctx *Context) error
+
+// Reconcile calls f(ctx).
+func (f ComponentFunc) Reconcile(ctx *Context) error {
+	return f(ctx)
+}
+
+// StatusModifier modifies the status of a cluster object in place. It returns
+// true when a change was made so that the status subresource is updated once
+// all modifiers have run.
+type StatusModifier interface {
+	Modify(ctx *Context) (bool, error)
+}
+
+// StatusModifierFunc adapts an ordinary function to a StatusModifier.
+type StatusModifierFunc func(ctx *Context) (bool, error)
+
+// Modify calls f(ctx).
+func (f StatusModifierFunc) Modify(ctx *Context) (bool, error) {
+	return f(ctx)
+}
+
+// FinalizerFunc performs cleanup before a cluster object is deleted.
+type FinalizerFunc func(ctx *Context) error
+
+// GateFunc reports whether reconciliation of a cluster should continue past
+// the gate. Clusters that are held back are reconciled again later.
+type GateFunc func(ctx *Context) (bool, error)
+
+// ResultFunc schedules the next reconciliation of a cluster once all of its
+// components and status modifiers succeed.
+type ResultFunc func(ctx *Context) (ctrl.Result, error)
+
+// ClusterStateFunc reports the lifecycle state of a cluster for metrics.
+type ClusterStateFunc func(ctx *Context) (metrics.ClusterState, error)
+
+// DeleteFunc releases process-local state that belongs to a deleted cluster.
+type DeleteFunc func(key types.NamespacedName)
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Context carries the state of a single reconciliation of a cluster object.
+// It is passed to every component, status modifier and hook registered with
+// a Reconciler.
+type Context struct {
+	context.Context
+
+	Log      logr.Logger
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Object is the cluster being reconciled. It owns every resource managed
+	// through this context.
+	Object client.Object
+	// Kind is the kind of the cluster object and labels recorded metrics.
+	Kind string
+	// ServerSideApply manages owned resources with server-side apply instead
+	// of patch-based updates that rely on last-applied annotations.
+	ServerSideApply bool
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+// Reasons used for events recorded on compute clusters.
+const (
+	EventReasonCreated              = "Created"
+	EventReasonUpdated              = "Updated"
+	EventReasonDeleted              = "Deleted"
+	EventReasonReconcileFailed      = "ReconcileFailed"
+	EventReasonInvalidImage         = "InvalidImage"
+	EventReasonStorageCleanupFailed = "StorageCleanupFailed"
+
+	EventReasonPodSecurityPolicyNotFound = "PodSecurityPolicyNotFound"
+	EventReasonSCCNotFound               = "SecurityContextConstraintsNotFound"
+)
+
+// ReasonError attaches a specific event reason to a reconciliation failure.
+type ReasonError struct {
+	Reason string
+	Err    error
+}
+
+func (e *ReasonError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ReasonError) Unwrap() error {
+	return e.Err
+}
+
+// recordFailure publishes a warning event describing a failed reconciliation
+// component. Errors that are caused by an invalid spec use a dedicated reason.
+func recordFailure(recorder record.EventRecorder, owner runtime.Object, step string, err error) {
+	var rErr *ReasonError
+	switch {
+	case errors.As(err, &rErr):
+		recorder.Event(owner, corev1.EventTypeWarning, rErr.Reason, err.Error())
+	case errors.Is(err, util.ErrInvalidImage):
+		recorder.Event(owner, corev1.EventTypeWarning, EventReasonInvalidImage, err.Error())
+	default:
+		recorder.Eventf(owner, corev1.EventTypeWarning, EventReasonReconcileFailed, "%s failed: %v", step, err)
+	}
+}
+
+// recordObjectEvent publishes a normal event describing a change made to a
+// controlled object.
+func recordObjectEvent(recorder record.EventRecorder, scheme *runtime.Scheme, owner runtime.Object, reason string, obj client.Object) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvks, _, err := scheme.ObjectKinds(obj); err == nil {
+		kind = gvks[0].Kind
+	}
+
+	recorder.Eventf(owner, corev1.EventTypeNormal, reason, "%s %s %s", reason, kind, obj.GetName())
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// namespaceSelected reports whether clusters in the given namespace should be
+// reconciled. Namespace labels are evaluated on every reconciliation so that
+// namespaces can be added to and removed from the selection at any time.
+func (r *Reconciler) namespaceSelected(ctx context.Context, namespace string) (bool, error) {
+	if r.namespaceSelector == nil {
+		return true, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	return r.namespaceSelector.Matches(labels.Set(ns.Labels)), nil
+}
+
+// namespaceRequests returns a request for every cluster in a namespace so that
+// its clusters are reconciled as soon as the namespace labels change.
+func (r *Reconciler) namespaceRequests(obj client.Object) []ctrl.Request {
+	log := r.log.WithValues("namespace", obj.GetName())
+
+	list, err := r.scheme.New(r.listKind)
+	if err != nil {
+		log.Error(err, "cannot create cluster list")
+		return nil
+	}
+
+	clusters := list.(client.ObjectList)
+	if err = r.client.List(context.Background(), clusters, client.InNamespace(obj.GetName())); err != nil {
+		log.Error(err, "cannot list clusters")
+		return nil
+	}
+
+	items, err := meta.ExtractList(clusters)
+	if err != nil {
+		log.Error(err, "cannot extract clusters")
+		return nil
+	}
+
+	requests := make([]ctrl.Request, 0, len(items))
+	for _, item := range items {
+		requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(item.(client.Object))})
+	}
+
+	return requests
+}
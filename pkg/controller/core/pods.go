@@ -0,0 +1,53 @@
+package core
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// PodClusterFunc returns the name of the cluster that a pod belongs to, or
+// false when the pod does not belong to a cluster of the reconciled kind.
+type PodClusterFunc func(pod *corev1.Pod) (string, bool)
+
+// podRequests returns a map function that enqueues the cluster of a pod.
+func podRequests(fn PodClusterFunc) handler.MapFunc {
+	return func(obj client.Object) []ctrl.Request {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return nil
+		}
+		name, ok := fn(pod)
+		if !ok {
+			return nil
+		}
+
+		return []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: name}}}
+	}
+}
+
+// podAddressChanged passes pod creations and deletions, and updates that
+// change the pod IP.
+func podAddressChanged() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, ok := e.ObjectOld.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+			newPod, ok := e.ObjectNew.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+
+			return oldPod.Status.PodIP != newPod.Status.PodIP
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return false
+		},
+	}
+}
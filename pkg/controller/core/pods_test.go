@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestPodRequests(t *testing.T) {
+	fn := podRequests(func(pod *corev1.Pod) (string, bool) {
+		name, ok := pod.Labels["cluster"]
+		return name, ok
+	})
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-0",
+		Namespace: "ns",
+		Labels:    map[string]string{"cluster": "test"},
+	}}
+	assert.Equal(t, []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "test"}}}, fn(pod))
+
+	pod.Labels = nil
+	assert.Empty(t, fn(pod))
+	assert.Empty(t, fn(&corev1.Service{}))
+}
+
+func TestPodAddressChanged(t *testing.T) {
+	p := podAddressChanged()
+	pod := func(ip string) *corev1.Pod {
+		return &corev1.Pod{Status: corev1.PodStatus{PodIP: ip}}
+	}
+
+	assert.True(t, p.Create(event.CreateEvent{Object: pod("")}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: pod("10.0.0.1")}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: pod(""), ObjectNew: pod("10.0.0.1")}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: pod("10.0.0.1"), ObjectNew: pod("10.0.0.1")}))
+	assert.False(t, p.Generic(event.GenericEvent{Object: pod("10.0.0.1")}))
+}
@@ -0,0 +1,345 @@
+// Package core implements the reconciliation flow shared by all compute
+// cluster kinds.
+//
+// Each kind registers an ordered list of components that manage its owned
+// resources and a list of status modifiers that publish observed state. The
+// framework takes care of finalization, admission gates, events, metrics and
+// status updates so that adding a new framework only requires a resource
+// package and a small registration.
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/logging"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/metrics"
+)
+
+// statusConflictRetryInterval is the delay before a cluster is reconciled
+// again after its status could not be updated because it was modified.
+const statusConflictRetryInterval = 500 * time.Millisecond
+
+type namedComponent struct {
+	name      string
+	component Component
+}
+
+type namedModifier struct {
+	name     string
+	modifier StatusModifier
+}
+
+type gate struct {
+	name         string
+	requeueAfter time.Duration
+	fn           GateFunc
+}
+
+type finalizer struct {
+	name   string
+	legacy []string
+	fn     FinalizerFunc
+}
+
+// Reconciler implements state reconciliation logic for a single cluster kind
+// using the components and hooks registered with a Builder.
+type Reconciler struct {
+	client          client.Client
+	scheme          *runtime.Scheme
+	log             logging.ContextLogger
+	recorder        record.EventRecorder
+	serverSideApply bool
+
+	// namespaceSelector restricts reconciliation to clusters in namespaces
+	// whose labels match. Every namespace is selected when this is nil.
+	namespaceSelector labels.Selector
+	// selector restricts reconciliation to the clusters managed by this
+	// operator instance.
+	selector dcv1alpha1.ControllerSelector
+
+	kind      string
+	listKind  schema.GroupVersionKind
+	prototype client.Object
+
+	finalizer  *finalizer
+	gates      []gate
+	components []namedComponent
+	modifiers  []namedModifier
+	results    []ResultFunc
+	state      ClusterStateFunc
+	onDelete   []DeleteFunc
+}
+
+// Reconcile implements state reconciliation logic for cluster objects.
+//
+// Deleted clusters that still hold the finalizer are finalized first, even
+// when they are no longer selected, so that their deletion never hangs.
+// Otherwise clusters managed by other operator instances and clusters in
+// namespaces that are not selected are ignored. Finalizers are registered
+// before anything else. Gates may then hold the cluster back, after which
+// every component is reconciled in order, status modifiers are applied and
+// the next reconciliation is scheduled.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, log := r.log.NewContext(ctx, strings.ToLower(r.kind), req.NamespacedName)
+
+	log.V(2).Info("reconciliation loop triggered")
+
+	obj := r.prototype.DeepCopyObject().(client.Object)
+	if err := r.client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("resource not found, assuming object was deleted")
+			r.forget(req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, "failed to retrieve resource")
+		return ctrl.Result{}, err
+	}
+
+	cctx := &Context{
+		Context:         ctx,
+		Log:             log,
+		Client:          r.client,
+		Scheme:          r.scheme,
+		Recorder:        r.recorder,
+		Object:          obj,
+		Kind:            r.kind,
+		ServerSideApply: r.serverSideApply,
+	}
+
+	if r.pendingFinalization(obj) {
+		_, err := r.manageFinalization(cctx)
+		return ctrl.Result{}, err
+	}
+
+	if !r.selected(obj) {
+		log.V(1).Info("skipping reconciliation of object managed by another controller class")
+		r.forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+	if selected, err := r.namespaceSelected(ctx, req.Namespace); err != nil {
+		log.Error(err, "failed to evaluate namespace selector")
+		return ctrl.Result{}, err
+	} else if !selected {
+		log.V(1).Info("skipping reconciliation of object in unselected namespace")
+		r.forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	defer r.recordMetrics(cctx)
+
+	if updated, err := r.manageFinalization(cctx); err != nil {
+		return ctrl.Result{}, err
+	} else if updated {
+		return ctrl.Result{Requeue: true}, nil
+	}
+	if !obj.GetDeletionTimestamp().IsZero() {
+		log.V(1).Info("skipping reconciliation of deleted object")
+		return ctrl.Result{}, nil
+	}
+
+	for _, g := range r.gates {
+		open, err := g.fn(cctx)
+		if err != nil {
+			log.Error(err, "failed to evaluate gate", "gate", g.name)
+			metrics.RecordReconcileError(r.kind, g.name)
+			return ctrl.Result{}, err
+		}
+		if !open {
+			return ctrl.Result{RequeueAfter: g.requeueAfter}, nil
+		}
+	}
+
+	if err := r.reconcileComponents(cctx); err != nil {
+		log.Error(err, "failed to reconcile cluster resources")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.updateStatus(cctx); err != nil {
+		if strings.Contains(err.Error(), genericregistry.OptimisticLockErrorMsg) {
+			log.V(1).Info("cannot update status on modified object, requeuing key for reprocessing")
+			return ctrl.Result{RequeueAfter: statusConflictRetryInterval}, nil
+		}
+
+		log.Error(err, "failed to update cluster status")
+		metrics.RecordReconcileError(r.kind, "updateStatus")
+		return ctrl.Result{}, err
+	}
+
+	return r.result(cctx)
+}
+
+// manageFinalization will add the finalizer to new cluster objects if it's
+// absent and remove it during a delete request after performing the required
+// finalization steps. Legacy finalizer names are replaced or removed as well.
+func (r *Reconciler) manageFinalization(ctx *Context) (bool, error) {
+	f := r.finalizer
+	if f == nil {
+		return false, nil
+	}
+
+	obj := ctx.Object
+	registered := controllerutil.ContainsFinalizer(obj, f.name)
+	var legacy bool
+	for _, name := range f.legacy {
+		legacy = legacy || controllerutil.ContainsFinalizer(obj, name)
+	}
+
+	if obj.GetDeletionTimestamp().IsZero() {
+		if registered && !legacy {
+			return false, nil
+		}
+
+		ctx.Log.V(1).Info("registering finalizer", "name", f.name)
+		controllerutil.AddFinalizer(obj, f.name)
+		f.removeLegacy(obj)
+
+		if err := ctx.Client.Update(ctx, obj); err != nil {
+			ctx.Log.Error(err, "failed to register finalizer")
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	if !registered && !legacy {
+		return false, nil
+	}
+
+	ctx.Log.Info("executing finalization steps")
+	if err := f.fn(ctx); err != nil {
+		ctx.Log.Error(err, "failed to execute finalization steps")
+		return false, err
+	}
+
+	ctx.Log.V(1).Info("removing finalizer", "name", f.name)
+	controllerutil.RemoveFinalizer(obj, f.name)
+	f.removeLegacy(obj)
+
+	if err := ctx.Client.Update(ctx, obj); err != nil {
+		ctx.Log.Error(err, "failed to remove finalizer")
+		return false, err
+	}
+
+	return true, nil
+}
+
+// pendingFinalization returns true when a deleted object still holds the
+// finalizer or one of its legacy names.
+func (r *Reconciler) pendingFinalization(obj client.Object) bool {
+	f := r.finalizer
+	if f == nil || obj.GetDeletionTimestamp().IsZero() {
+		return false
+	}
+
+	if controllerutil.ContainsFinalizer(obj, f.name) {
+		return true
+	}
+	for _, name := range f.legacy {
+		if controllerutil.ContainsFinalizer(obj, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *finalizer) removeLegacy(obj client.Object) {
+	for _, name := range f.legacy {
+		controllerutil.RemoveFinalizer(obj, name)
+	}
+}
+
+// reconcileComponents manages the creation and updates of resources that
+// collectively comprise a cluster. Failures are recorded as metrics and
+// warning events on the cluster object.
+func (r *Reconciler) reconcileComponents(ctx *Context) error {
+	for _, c := range r.components {
+		if err := c.component.Reconcile(ctx); err != nil {
+			metrics.RecordReconcileError(r.kind, c.name)
+			recordFailure(r.recorder, ctx.Object, c.name, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateStatus applies every status modifier and updates the status
+// subresource when any of them made a change.
+func (r *Reconciler) updateStatus(ctx *Context) error {
+	var modified bool
+	for _, m := range r.modifiers {
+		changed, err := m.modifier.Modify(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot modify cluster status %s: %w", m.name, err)
+		}
+		modified = modified || changed
+	}
+
+	if !modified {
+		return nil
+	}
+
+	return ctx.Client.Status().Update(ctx, ctx.Object)
+}
+
+// result combines the results of every registered result func. The cluster
+// is reconciled again after the shortest requested interval.
+func (r *Reconciler) result(ctx *Context) (ctrl.Result, error) {
+	var result ctrl.Result
+	for _, fn := range r.results {
+		res, err := fn(ctx)
+		if err != nil {
+			ctx.Log.Error(err, "failed to schedule next reconciliation")
+			return ctrl.Result{}, err
+		}
+
+		result.Requeue = result.Requeue || res.Requeue
+		if res.RequeueAfter > 0 && (result.RequeueAfter == 0 || res.RequeueAfter < result.RequeueAfter) {
+			result.RequeueAfter = res.RequeueAfter
+		}
+	}
+
+	return result, nil
+}
+
+// recordMetrics reports the lifecycle state of the cluster. Failures are
+// logged and never interrupt reconciliation.
+func (r *Reconciler) recordMetrics(ctx *Context) {
+	if r.state == nil {
+		return
+	}
+
+	state, err := r.state(ctx)
+	if err != nil {
+		ctx.Log.Error(err, "cannot record cluster metrics")
+		return
+	}
+
+	metrics.RecordCluster(r.kind, client.ObjectKeyFromObject(ctx.Object), state)
+}
+
+// forget releases all state held for a cluster that was deleted or is no
+// longer selected.
+func (r *Reconciler) forget(key types.NamespacedName) {
+	metrics.ForgetCluster(r.kind, key)
+	for _, fn := range r.onDelete {
+		fn(key)
+	}
+}
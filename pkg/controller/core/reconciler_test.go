@@ -0,0 +1,338 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/logging"
+)
+
+const testFinalizer = "test.dominodatalab.com/finalizer"
+
+var testKey = types.NamespacedName{Namespace: "ns", Name: "test"}
+
+func testReconciler(t *testing.T, objs ...client.Object) (*Reconciler, *record.FakeRecorder) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, dcv1alpha1.AddToScheme(scheme))
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		scheme:    scheme,
+		log:       logging.New(logf.NullLogger{}),
+		recorder:  recorder,
+		kind:      "RayCluster",
+		listKind:  dcv1alpha1.GroupVersion.WithKind("RayClusterList"),
+		prototype: &dcv1alpha1.RayCluster{},
+	}
+
+	return r, recorder
+}
+
+func testCluster(finalizers ...string) *dcv1alpha1.RayCluster {
+	return &dcv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testKey.Name,
+			Namespace:  testKey.Namespace,
+			Finalizers: finalizers,
+		},
+	}
+}
+
+func reconcile(t *testing.T, r *Reconciler) (ctrl.Result, error) {
+	t.Helper()
+	return r.Reconcile(context.Background(), ctrl.Request{NamespacedName: testKey})
+}
+
+func TestReconcilerComponents(t *testing.T) {
+	r, _ := testReconciler(t, testCluster(testFinalizer))
+	r.finalizer = &finalizer{name: testFinalizer, fn: func(*Context) error { return nil }}
+
+	var order []string
+	for _, name := range []string{"first", "second", "third"} {
+		name := name
+		r.components = append(r.components, namedComponent{name: name, component: ComponentFunc(func(*Context) error {
+			order = append(order, name)
+			return nil
+		})})
+	}
+	r.modifiers = append(r.modifiers, namedModifier{name: "nodes", modifier: StatusModifierFunc(func(ctx *Context) (bool, error) {
+		ctx.Object.(*dcv1alpha1.RayCluster).Status.Nodes = []string{"node"}
+		return true, nil
+	})})
+
+	result, err := reconcile(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	assert.Equal(t, []string{"first", "second", "third"}, order)
+
+	rc := &dcv1alpha1.RayCluster{}
+	require.NoError(t, r.client.Get(context.Background(), testKey, rc))
+	assert.Equal(t, []string{"node"}, rc.Status.Nodes)
+}
+
+func TestReconcilerComponentFailure(t *testing.T) {
+	r, recorder := testReconciler(t, testCluster())
+
+	var reconciled bool
+	r.components = []namedComponent{
+		{name: "failing", component: ComponentFunc(func(*Context) error { return errors.New("boom") })},
+		{name: "skipped", component: ComponentFunc(func(*Context) error {
+			reconciled = true
+			return nil
+		})},
+	}
+
+	_, err := reconcile(t, r)
+	assert.EqualError(t, err, "boom")
+	assert.False(t, reconciled)
+	assert.Equal(t, "Warning ReconcileFailed failing failed: boom", <-recorder.Events)
+}
+
+func TestReconcilerFinalizer(t *testing.T) {
+	t.Run("register", func(t *testing.T) {
+		r, _ := testReconciler(t, testCluster("legacy"))
+		r.finalizer = &finalizer{name: testFinalizer, legacy: []string{"legacy"}}
+
+		result, err := reconcile(t, r)
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+
+		rc := &dcv1alpha1.RayCluster{}
+		require.NoError(t, r.client.Get(context.Background(), testKey, rc))
+		assert.Equal(t, []string{testFinalizer}, rc.Finalizers)
+	})
+
+	t.Run("finalize", func(t *testing.T) {
+		rc := testCluster(testFinalizer, "other")
+		rc.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+		r, _ := testReconciler(t, rc)
+		var finalized bool
+		r.finalizer = &finalizer{name: testFinalizer, fn: func(*Context) error {
+			finalized = true
+			return nil
+		}}
+		r.components = []namedComponent{{name: "never", component: ComponentFunc(func(*Context) error {
+			t.Fatal("components must not be reconciled for deleted clusters")
+			return nil
+		})}}
+
+		_, err := reconcile(t, r)
+		require.NoError(t, err)
+		assert.True(t, finalized)
+
+		_, err = reconcile(t, r)
+		require.NoError(t, err)
+
+		require.NoError(t, r.client.Get(context.Background(), testKey, rc))
+		assert.Equal(t, []string{"other"}, rc.Finalizers)
+	})
+}
+
+func TestReconcilerFinalizeUnselected(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		class    string
+		nsLabels map[string]string
+	}{
+		{name: "controller_class", class: "experimental", nsLabels: map[string]string{"managed": "true"}},
+		{name: "namespace", nsLabels: map[string]string{"managed": "false"}},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			rc := testCluster(testFinalizer)
+			rc.Spec.ControllerClass = tc.class
+			rc.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testKey.Namespace, Labels: tc.nsLabels}}
+
+			r, _ := testReconciler(t, ns, rc)
+			r.namespaceSelector = labels.SelectorFromSet(labels.Set{"managed": "true"})
+
+			var finalized bool
+			r.finalizer = &finalizer{name: testFinalizer, fn: func(*Context) error {
+				finalized = true
+				return nil
+			}}
+			assert.True(t, r.pendingFinalization(rc))
+
+			_, err := reconcile(t, r)
+			require.NoError(t, err)
+			assert.True(t, finalized)
+
+			current := &dcv1alpha1.RayCluster{}
+			err = r.client.Get(context.Background(), testKey, current)
+			if err == nil {
+				assert.Empty(t, current.Finalizers)
+			} else {
+				assert.True(t, apierrors.IsNotFound(err))
+			}
+		})
+	}
+}
+
+func TestReconcilerGate(t *testing.T) {
+	r, _ := testReconciler(t, testCluster())
+	r.gates = []gate{{name: "admit", requeueAfter: time.Minute, fn: func(*Context) (bool, error) { return false, nil }}}
+	r.components = []namedComponent{{name: "never", component: ComponentFunc(func(*Context) error {
+		t.Fatal("components must not be reconciled before the gate opens")
+		return nil
+	})}}
+
+	result, err := reconcile(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: time.Minute}, result)
+}
+
+func TestReconcilerResult(t *testing.T) {
+	r, _ := testReconciler(t, testCluster())
+	for _, d := range []time.Duration{0, time.Hour, time.Minute} {
+		d := d
+		r.results = append(r.results, func(*Context) (ctrl.Result, error) {
+			return ctrl.Result{RequeueAfter: d}, nil
+		})
+	}
+
+	result, err := reconcile(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: time.Minute}, result)
+}
+
+func TestReconcilerNamespaceSelector(t *testing.T) {
+	selector := labels.SelectorFromSet(labels.Set{"managed": "true"})
+
+	for _, tc := range []struct {
+		name     string
+		labels   map[string]string
+		expected bool
+	}{
+		{name: "selected", labels: map[string]string{"managed": "true"}, expected: true},
+		{name: "unselected", labels: map[string]string{"managed": "false"}, expected: false},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testKey.Namespace, Labels: tc.labels}}
+			r, _ := testReconciler(t, ns, testCluster())
+			r.namespaceSelector = selector
+
+			var reconciled, forgotten bool
+			r.components = []namedComponent{{name: "component", component: ComponentFunc(func(*Context) error {
+				reconciled = true
+				return nil
+			})}}
+			r.onDelete = []DeleteFunc{func(types.NamespacedName) { forgotten = true }}
+
+			_, err := reconcile(t, r)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, reconciled)
+			assert.Equal(t, !tc.expected, forgotten, "unselected clusters must be forgotten")
+		})
+	}
+}
+
+func TestReconcilerControllerSelector(t *testing.T) {
+	rc := testCluster()
+	rc.Spec.ControllerClass = "experimental"
+
+	r, _ := testReconciler(t, rc)
+	r.components = []namedComponent{{name: "never", component: ComponentFunc(func(*Context) error {
+		t.Fatal("clusters of other controller classes must not be reconciled")
+		return nil
+	})}}
+
+	var forgotten types.NamespacedName
+	r.onDelete = []DeleteFunc{func(key types.NamespacedName) { forgotten = key }}
+
+	_, err := reconcile(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, testKey, forgotten, "unselected clusters must be forgotten")
+}
+
+func TestReconcilerClusterPredicate(t *testing.T) {
+	r, _ := testReconciler(t)
+	r.finalizer = &finalizer{name: testFinalizer}
+	p := r.clusterPredicate()
+
+	selected := testCluster()
+	unselected := testCluster()
+	unselected.Spec.ControllerClass = "experimental"
+	deleting := unselected.DeepCopy()
+	deleting.Finalizers = []string{testFinalizer}
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	assert.True(t, p.Create(event.CreateEvent{Object: selected}))
+	assert.False(t, p.Create(event.CreateEvent{Object: unselected}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: selected, ObjectNew: unselected}), "deselection must be observed")
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: unselected, ObjectNew: unselected}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: unselected, ObjectNew: deleting}), "finalization must be observed")
+}
+
+func TestReconcilerOwnerSelected(t *testing.T) {
+	experimental := testCluster()
+	experimental.Name = "experimental"
+	experimental.Spec.ControllerClass = "experimental"
+
+	r, _ := testReconciler(t, testCluster(), experimental)
+
+	owned := func(owner string) *corev1.Service {
+		svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: testKey.Namespace}}
+		require.NoError(t, controllerutil.SetControllerReference(&dcv1alpha1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: owner, Namespace: testKey.Namespace, UID: types.UID(owner)},
+		}, svc, r.scheme))
+		return svc
+	}
+
+	assert.True(t, r.ownerSelected(owned(testKey.Name)))
+	assert.False(t, r.ownerSelected(owned("experimental")))
+	assert.False(t, r.ownerSelected(owned("missing")))
+	assert.False(t, r.ownerSelected(&corev1.Service{}))
+}
+
+func TestReconcilerNamespaceRequests(t *testing.T) {
+	other := testCluster()
+	other.Name = "other"
+	foreign := testCluster()
+	foreign.Namespace = "foreign"
+
+	r, _ := testReconciler(t, testCluster(), other, foreign)
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testKey.Namespace}}
+
+	assert.ElementsMatch(t, []ctrl.Request{
+		{NamespacedName: testKey},
+		{NamespacedName: types.NamespacedName{Namespace: testKey.Namespace, Name: "other"}},
+	}, r.namespaceRequests(ns))
+}
+
+func TestReconcilerDeleted(t *testing.T) {
+	r, _ := testReconciler(t)
+
+	var forgotten types.NamespacedName
+	r.onDelete = []DeleteFunc{func(key types.NamespacedName) { forgotten = key }}
+
+	_, err := reconcile(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, testKey, forgotten)
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"path"
+
+	"github.com/banzaicloud/k8s-objectmatcher/patch"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/metrics"
+)
+
+// legacyLastAppliedAnnotation was written to owned components of spark
+// clusters before every kind shared the same annotation.
+const legacyLastAppliedAnnotation = "distributed-compute-operator.dominodatalab.com/last-applied"
+
+var (
+	// LastAppliedAnnotation stores the state of owned components that are
+	// managed with patch-based updates.
+	LastAppliedAnnotation = path.Join(dcv1alpha1.GroupVersion.Group, "last-applied")
+	// PatchAnnotator applies state annotations to owned components.
+	PatchAnnotator = patch.NewAnnotator(LastAppliedAnnotation)
+	// PatchMaker calculates changes to state annotations on owned components.
+	PatchMaker = patch.NewPatchMaker(PatchAnnotator)
+	// PatchCalculateOpts define the exclusion rules used when calculating the
+	// difference between two k8s resources.
+	PatchCalculateOpts = []patch.CalculateOption{
+		patch.IgnoreStatusFields(),
+		patch.IgnoreVolumeClaimTemplateTypeMetaAndStatus(),
+	}
+)
+
+// CreateOrUpdateOwnedResource makes the cluster object the controller of an
+// owned resource and ensures that the resource matches its desired state.
+func (c *Context) CreateOrUpdateOwnedResource(controlled client.Object) error {
+	if err := ctrl.SetControllerReference(c.Object, controlled, c.Scheme); err != nil {
+		return err
+	}
+
+	gvks, _, err := c.Scheme.ObjectKinds(controlled)
+	if err != nil {
+		return err
+	}
+	gvk := gvks[0]
+
+	if c.ServerSideApply {
+		var reason string
+		if reason, err = serverSideApply(c, c.Client, c.Log, c.Kind, gvk, controlled); err != nil {
+			return err
+		}
+		if reason != "" {
+			recordObjectEvent(c.Recorder, c.Scheme, c.Object, reason, controlled)
+		}
+
+		return nil
+	}
+
+	found := controlled.DeepCopyObject().(client.Object)
+	if err = c.Client.Get(c, client.ObjectKeyFromObject(controlled), found); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		if err = PatchAnnotator.SetLastAppliedAnnotation(controlled); err != nil {
+			return err
+		}
+
+		c.Log.Info("creating controlled object", "gvk", gvk, "object", controlled)
+		if err = c.Client.Create(c, controlled); err != nil {
+			return err
+		}
+		recordObjectEvent(c.Recorder, c.Scheme, c.Object, EventReasonCreated, controlled)
+
+		return nil
+	}
+
+	patchResult, err := PatchMaker.Calculate(found, controlled, PatchCalculateOpts...)
+	if err != nil {
+		return err
+	}
+	if patchResult.IsEmpty() {
+		return nil
+	}
+
+	c.Log.V(1).Info("applying patch to object", "gvk", gvk, "object", controlled, "patch", string(patchResult.Patch))
+	if err = PatchAnnotator.SetLastAppliedAnnotation(controlled); err != nil {
+		return err
+	}
+
+	controlled.SetResourceVersion(found.GetResourceVersion())
+	if modified, ok := controlled.(*corev1.Service); ok {
+		preserveServiceAllocations(modified, found.(*corev1.Service))
+	}
+
+	c.Log.Info("updating controlled object", "gvk", gvk, "object", controlled)
+	if err = c.Client.Update(c, controlled); err != nil {
+		return err
+	}
+	metrics.RecordPatch(c.Kind, gvk.Kind, len(patchResult.Patch))
+	recordObjectEvent(c.Recorder, c.Scheme, c.Object, EventReasonUpdated, controlled)
+
+	return nil
+}
+
+// DeleteIfExists deletes owned resources that are no longer desired. Nil
+// objects, objects that do not exist and objects whose API is not installed
+// are skipped.
+func (c *Context) DeleteIfExists(objs ...client.Object) error {
+	for _, obj := range objs {
+		if obj == nil {
+			continue
+		}
+		if err := c.Client.Get(c, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+
+			return err
+		}
+
+		c.Log.Info("deleting controlled object", "object", obj)
+		if err := c.Client.Delete(c, obj); err != nil {
+			return err
+		}
+		recordObjectEvent(c.Recorder, c.Scheme, c.Object, EventReasonDeleted, obj)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// selected returns true when a cluster is managed by this operator instance.
+func (r *Reconciler) selected(obj client.Object) bool {
+	return r.selector.Matches(obj)
+}
+
+// clusterPredicate passes events of selected clusters and of deleted clusters
+// that still need to be finalized. Updates that deselect a cluster are passed
+// as well so that the state held for it is released.
+func (r *Reconciler) clusterPredicate() predicate.Funcs {
+	watched := func(obj client.Object) bool {
+		return r.selected(obj) || r.pendingFinalization(obj)
+	}
+
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return watched(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return watched(e.Object) },
+		GenericFunc: func(e event.GenericEvent) bool { return watched(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return watched(e.ObjectNew) || r.selected(e.ObjectOld)
+		},
+	}
+}
+
+// ownerSelected returns true when an owned object is controlled by a cluster
+// that is managed by this operator instance. Objects whose cluster cannot be
+// retrieved are passed on so that reconciliation can handle the failure.
+func (r *Reconciler) ownerSelected(obj client.Object) bool {
+	ref := metav1.GetControllerOf(obj)
+	if ref == nil || ref.Kind != r.kind {
+		return false
+	}
+
+	owner := r.prototype.DeepCopyObject().(client.Object)
+	key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: ref.Name}
+	if err := r.client.Get(context.Background(), key, owner); err != nil {
+		return !apierrors.IsNotFound(err)
+	}
+
+	return r.selected(owner)
+}
@@ -0,0 +1,24 @@
+package core
+
+import corev1 "k8s.io/api/core/v1"
+
+// preserveServiceAllocations copies values assigned by the API server to a
+// service onto its desired state so that updates do not release them.
+func preserveServiceAllocations(desired, current *corev1.Service) {
+	desired.Spec.ClusterIP = current.Spec.ClusterIP
+
+	if desired.Spec.Type != corev1.ServiceTypeNodePort && desired.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return
+	}
+
+	nodePorts := map[string]int32{}
+	for _, port := range current.Spec.Ports {
+		nodePorts[port.Name] = port.NodePort
+	}
+	for idx := range desired.Spec.Ports {
+		port := &desired.Spec.Ports[idx]
+		if port.NodePort == 0 {
+			port.NodePort = nodePorts[port.Name]
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeletePersistentVolumeClaims returns a finalizer that deletes the claims
+// created for the stateful sets of a cluster. Claims are selected using the
+// labels returned by selector.
+func DeletePersistentVolumeClaims(selector func(client.Object) map[string]string) FinalizerFunc {
+	return func(ctx *Context) error {
+		if err := deletePersistentVolumeClaims(ctx, selector(ctx.Object)); err != nil {
+			ctx.Recorder.Eventf(ctx.Object, corev1.EventTypeWarning, EventReasonStorageCleanupFailed,
+				"Failed to delete persistent volume claims: %v", err)
+			return err
+		}
+
+		return nil
+	}
+}
+
+func deletePersistentVolumeClaims(ctx *Context, labels map[string]string) error {
+	ns := ctx.Object.GetNamespace()
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(ns),
+		client.MatchingLabels(labels),
+	}
+
+	ctx.Log.Info("querying for persistent volume claims", "namespace", ns, "labels", labels)
+	if err := ctx.Client.List(ctx, pvcList, listOpts...); err != nil {
+		ctx.Log.Error(err, "cannot list persistent volume claims")
+		return err
+	}
+
+	for idx := range pvcList.Items {
+		pvc := &pvcList.Items[idx]
+		if pvc.DeletionTimestamp != nil {
+			continue
+		}
+		key := client.ObjectKeyFromObject(pvc)
+
+		ctx.Log.Info("deleting persistent volume claim", "claim", key)
+		if err := ctx.Client.Delete(ctx, pvc); client.IgnoreNotFound(err) != nil {
+			ctx.Log.Error(err, "cannot delete persistent volume claim", "claim", key)
+			return err
+		}
+		recordObjectEvent(ctx.Recorder, ctx.Scheme, ctx.Object, EventReasonDeleted, pvc)
+	}
+
+	return nil
+}
@@ -1,14 +1,28 @@
 package manager
 
-import "sigs.k8s.io/controller-runtime/pkg/log/zap"
+import (
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/podsecurity"
+)
 
 // Config options for the controller manager.
 type Config struct {
-	Namespace            string
+	ConfigFile           string
+	Namespaces           []string
+	NamespaceSelector    string
+	ControllerClass      string
+	WatchLabelSelector   string
 	MetricsAddr          string
 	HealthProbeAddr      string
 	WebhookServerPort    int
 	EnableLeaderElection bool
 	IstioEnabled         bool
+	KEDAEnabled          bool
+	KEDANamespace        string
+	OperatorNamespace    string
+	QueueingEnabled      bool
+	PodSecurityBackend   podsecurity.Backend
+	ServerSideApply      bool
 	ZapOptions           zap.Options
 }
@@ -0,0 +1,192 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/yaml"
+
+	configv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/config/v1alpha1"
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+var configScheme = runtime.NewScheme()
+
+// LoadConfigFile reads the operator configuration file at path. Cluster
+// defaults that are not set in the file are set to the built-in defaults.
+func LoadConfigFile(path string) (*configv1alpha1.OperatorConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read config file: %w", err)
+	}
+
+	return parseConfig(data)
+}
+
+// parseConfig decodes and validates a versioned operator configuration.
+// Unknown fields are rejected so that misspelled settings are not ignored and
+// files without a version are rejected so that partially written files are
+// not mistaken for empty ones.
+func parseConfig(data []byte) (*configv1alpha1.OperatorConfig, error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+		return nil, fmt.Errorf("cannot decode config file: %w", err)
+	}
+	if typeMeta.APIVersion == "" || typeMeta.Kind == "" {
+		return nil, fmt.Errorf("config file must set apiVersion and kind")
+	}
+
+	codecs := serializer.NewCodecFactory(configScheme, serializer.EnableStrict)
+
+	cfg := &configv1alpha1.OperatorConfig{}
+	obj, gvk, err := codecs.UniversalDeserializer().Decode(data, nil, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode config file: %w", err)
+	}
+	if obj != cfg {
+		return nil, fmt.Errorf("unsupported config file kind %s", gvk)
+	}
+
+	cfg.Defaults.SetDefaults()
+	if err = cfg.Defaults.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// defaultsFile supplies the cluster defaults of the operator configuration
+// file and reloads them when the file changes. The current defaults are kept
+// when the file becomes invalid. Changes to other settings are logged since
+// they only take effect after a restart.
+type defaultsFile struct {
+	path    string
+	log     logr.Logger
+	startup *configv1alpha1.OperatorConfig
+
+	mu       sync.RWMutex
+	defaults dcv1alpha1.ClusterDefaults
+}
+
+func newDefaultsFile(path string, log logr.Logger) (*defaultsFile, error) {
+	cfg, err := LoadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &defaultsFile{path: path, log: log, startup: cfg, defaults: cfg.Defaults}, nil
+}
+
+// ClusterDefaults returns a copy of the current cluster defaults.
+func (f *defaultsFile) ClusterDefaults() dcv1alpha1.ClusterDefaults {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return *f.defaults.DeepCopy()
+}
+
+// Start reloads the defaults until the context is done. The directory of the
+// file is watched since ConfigMap volumes update files by swapping symlinks.
+func (f *defaultsFile) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err = watcher.Add(filepath.Dir(f.path)); err != nil {
+		return fmt.Errorf("cannot watch config file: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			f.reload()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			f.log.Error(err, "config file watch failed")
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so that the
+// webhooks of every replica use the reloaded defaults.
+func (f *defaultsFile) NeedLeaderElection() bool {
+	return false
+}
+
+func (f *defaultsFile) reload() {
+	cfg, err := LoadConfigFile(f.path)
+	if err != nil {
+		f.log.Error(err, "cannot reload config file, keeping current cluster defaults")
+		return
+	}
+	if fields := restartFields(f.startup, cfg); len(fields) > 0 {
+		f.log.Info("config file changes require a restart to take effect", "path", f.path, "fields", fields)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if equality.Semantic.DeepEqual(cfg.Defaults, f.defaults) {
+		return
+	}
+	f.defaults = cfg.Defaults
+	f.log.Info("reloaded cluster defaults", "path", f.path)
+}
+
+// restartFields returns the settings that differ between the configuration
+// read on startup and the reloaded one. Only cluster defaults are reloaded,
+// the other settings are read once when the operator starts.
+func restartFields(startup, reloaded *configv1alpha1.OperatorConfig) []string {
+	settings := []struct {
+		name              string
+		startup, reloaded interface{}
+	}{
+		{"syncPeriod", startup.SyncPeriod, reloaded.SyncPeriod},
+		{"leaderElection", startup.LeaderElection, reloaded.LeaderElection},
+		{"cacheNamespace", startup.CacheNamespace, reloaded.CacheNamespace},
+		{"gracefulShutDown", startup.GracefulShutdownTimeout, reloaded.GracefulShutdownTimeout},
+		{"metrics", startup.Metrics, reloaded.Metrics},
+		{"health", startup.Health, reloaded.Health},
+		{"webhook", startup.Webhook, reloaded.Webhook},
+		{"features.istio", startup.Features.Istio, reloaded.Features.Istio},
+		{"features.keda", startup.Features.KEDA, reloaded.Features.KEDA},
+		{"features.queueing", startup.Features.Queueing, reloaded.Features.Queueing},
+		{"features.serverSideApply", startup.Features.ServerSideApply, reloaded.Features.ServerSideApply},
+		{"features.podSecurityBackend", startup.Features.PodSecurityBackend, reloaded.Features.PodSecurityBackend},
+	}
+
+	var changed []string
+	for _, s := range settings {
+		if !equality.Semantic.DeepEqual(s.startup, s.reloaded) {
+			changed = append(changed, s.name)
+		}
+	}
+
+	return changed
+}
+
+func init() {
+	utilruntime.Must(configv1alpha1.AddToScheme(configScheme))
+}
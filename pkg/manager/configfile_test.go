@@ -0,0 +1,189 @@
+package manager
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/spark"
+)
+
+const testConfig = `
+apiVersion: config.distributed-compute.dominodatalab.com/v1alpha1
+kind: OperatorConfig
+metrics:
+  bindAddress: :9090
+features:
+  keda: true
+defaults:
+  ray:
+    image:
+      repository: rayproject/ray
+      tag: 1.4.0
+    workerReplicas: 2
+  spark:
+    runAsUser: 1002
+`
+
+func TestParseConfig(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		cfg, err := parseConfig([]byte(testConfig))
+		require.NoError(t, err)
+
+		assert.Equal(t, ":9090", cfg.Metrics.BindAddress)
+		assert.True(t, cfg.Features.KEDA)
+
+		builtin := dcv1alpha1.NewClusterDefaults()
+		assert.Equal(t, "1.4.0", cfg.Defaults.Ray.Image.Tag)
+		assert.Equal(t, int32(2), *cfg.Defaults.Ray.WorkerReplicas)
+		assert.Equal(t, builtin.Ray.Port, cfg.Defaults.Ray.Port)
+		assert.Equal(t, int64(1002), *cfg.Defaults.Spark.RunAsUser)
+		assert.Equal(t, builtin.Spark.Image, cfg.Defaults.Spark.Image)
+	})
+
+	t.Run("empty_defaults", func(t *testing.T) {
+		cfg, err := parseConfig([]byte(`
+apiVersion: config.distributed-compute.dominodatalab.com/v1alpha1
+kind: OperatorConfig
+`))
+		require.NoError(t, err)
+
+		assert.Equal(t, dcv1alpha1.NewClusterDefaults(), cfg.Defaults)
+	})
+
+	t.Run("missing_kind", func(t *testing.T) {
+		_, err := parseConfig(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown_kind", func(t *testing.T) {
+		_, err := parseConfig([]byte(`
+apiVersion: controller-runtime.sigs.k8s.io/v1alpha1
+kind: ControllerManagerConfig
+`))
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown_field", func(t *testing.T) {
+		_, err := parseConfig([]byte(`
+apiVersion: config.distributed-compute.dominodatalab.com/v1alpha1
+kind: OperatorConfig
+defaults:
+  ray:
+    workerReplica: 2
+`))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid_defaults", func(t *testing.T) {
+		_, err := parseConfig([]byte(`
+apiVersion: config.distributed-compute.dominodatalab.com/v1alpha1
+kind: OperatorConfig
+defaults:
+  ray:
+    port: 80
+`))
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dco-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(testConfig), 0600))
+
+	file, err := newDefaultsFile(path, ctrl.Log)
+	require.NoError(t, err)
+	assert.False(t, file.NeedLeaderElection())
+	assert.Equal(t, "1.4.0", file.ClusterDefaults().Ray.Image.Tag)
+	assert.Equal(t, int64(1002), sparkRunAsUser(t, file))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error)
+	go func() { done <- file.Start(ctx) }()
+
+	// give the watcher time to start before the file changes
+	time.Sleep(100 * time.Millisecond)
+
+	t.Run("reload", func(t *testing.T) {
+		updated := []byte(`
+apiVersion: config.distributed-compute.dominodatalab.com/v1alpha1
+kind: OperatorConfig
+defaults:
+  ray:
+    image:
+      repository: rayproject/ray
+      tag: 1.5.0
+`)
+		require.NoError(t, ioutil.WriteFile(path, updated, 0600))
+
+		assert.Eventually(t, func() bool {
+			return file.ClusterDefaults().Ray.Image.Tag == "1.5.0"
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, int64(1001), sparkRunAsUser(t, file), "removed defaults should be reset")
+	})
+
+	t.Run("invalid_update", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(path, []byte("kind: Unknown"), 0600))
+
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, "1.5.0", file.ClusterDefaults().Ray.Image.Tag, "current defaults should be kept")
+	})
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestRestartFields(t *testing.T) {
+	startup, err := parseConfig([]byte(testConfig))
+	require.NoError(t, err)
+
+	reloaded, err := parseConfig([]byte(`
+apiVersion: config.distributed-compute.dominodatalab.com/v1alpha1
+kind: OperatorConfig
+metrics:
+  bindAddress: :9090
+features:
+  keda: true
+defaults:
+  ray:
+    workerReplicas: 5
+`))
+	require.NoError(t, err)
+	assert.Empty(t, restartFields(startup, reloaded), "cluster defaults should be reloaded")
+
+	reloaded.Metrics.BindAddress = ":8080"
+	reloaded.Features.KEDA = false
+	reloaded.Features.Queueing = true
+	assert.Equal(t, []string{"metrics", "features.keda", "features.queueing"}, restartFields(startup, reloaded))
+}
+
+// sparkRunAsUser returns the user of the pods built with the current defaults
+// of the file.
+func sparkRunAsUser(t *testing.T, file *defaultsFile) int64 {
+	sc := &dcv1alpha1.SparkCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: dcv1alpha1.SparkClusterSpec{
+			Image: &dcv1alpha1.OCIImageDefinition{Repository: "bitnami/spark", Tag: "3.0.2"},
+		},
+	}
+
+	sts, err := spark.NewStatefulSet(sc, spark.ComponentMaster, file.ClusterDefaults().Spark)
+	require.NoError(t, err)
+
+	return *sts.Spec.Template.Spec.SecurityContext.RunAsUser
+}
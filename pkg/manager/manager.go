@@ -10,6 +10,7 @@ import (
 	istioscheme "istio.io/client-go/pkg/clientset/versioned/scheme"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -17,12 +18,12 @@ import (
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/controllers"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/admission"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/autoscaler"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/logging"
 	//+kubebuilder:scaffold:imports
 )
 
-const leaderElectionID = "a846cbf2.dominodatalab.com"
-
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -33,45 +34,106 @@ var (
 func Start(cfg *Config) error {
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&cfg.ZapOptions)))
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	namespaceSelector, err := parseLabelSelector(cfg.NamespaceSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid namespace selector")
+		return err
+	}
+	selector, err := controllerSelector(cfg.ControllerClass, cfg.WatchLabelSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid controller selection")
+		return err
+	}
+
+	opts := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     cfg.MetricsAddr,
 		Port:                   cfg.WebhookServerPort,
 		HealthProbeBindAddress: cfg.HealthProbeAddr,
 		LeaderElection:         cfg.EnableLeaderElection,
-		LeaderElectionID:       leaderElectionID,
-		Namespace:              cfg.Namespace,
-	})
+		LeaderElectionID:       leaderElectionID(selector),
+	}
+	watchNamespaces(&opts, cfg.Namespaces)
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), opts)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		return err
 	}
 
+	var defaults dcv1alpha1.ClusterDefaultsSource
+	if cfg.ConfigFile != "" {
+		file, err := newDefaultsFile(cfg.ConfigFile, ctrl.Log.WithName("config"))
+		if err != nil {
+			setupLog.Error(err, "unable to load config file")
+			return err
+		}
+		if err = mgr.Add(file); err != nil {
+			setupLog.Error(err, "unable to watch config file")
+			return err
+		}
+		defaults = file
+	}
+
+	var admitter *admission.Admitter
+	if cfg.QueueingEnabled {
+		admitter = &admission.Admitter{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("admission"),
+		}
+	}
+
 	if err = (&controllers.RayClusterReconciler{
-		Client:       mgr.GetClient(),
-		Log:          logging.New(ctrl.Log.WithName("controllers").WithName("RayCluster")),
-		Scheme:       mgr.GetScheme(),
-		IstioEnabled: cfg.IstioEnabled,
+		Client:             mgr.GetClient(),
+		Log:                logging.New(ctrl.Log.WithName("controllers").WithName("RayCluster")),
+		Scheme:             mgr.GetScheme(),
+		Recorder:           mgr.GetEventRecorderFor("raycluster-controller"),
+		IstioEnabled:       cfg.IstioEnabled,
+		KEDAEnabled:        cfg.KEDAEnabled,
+		PodSecurityBackend: cfg.PodSecurityBackend,
+		ServerSideApply:    cfg.ServerSideApply,
+		NamespaceSelector:  namespaceSelector,
+		ControllerSelector: selector,
+		Admitter:           admitter,
+		Defaults:           defaults,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RayCluster")
 		return err
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create dynamic client")
+		return err
+	}
+
 	if err = (&controllers.SparkClusterReconciler{
-		Client: mgr.GetClient(),
-		Log:    logging.New(ctrl.Log.WithName("controllers").WithName("SparkCluster")),
-		Scheme: mgr.GetScheme(),
+		Client:             mgr.GetClient(),
+		Log:                logging.New(ctrl.Log.WithName("controllers").WithName("SparkCluster")),
+		Scheme:             mgr.GetScheme(),
+		Recorder:           mgr.GetEventRecorderFor("sparkcluster-controller"),
+		IstioEnabled:       cfg.IstioEnabled,
+		KEDAEnabled:        cfg.KEDAEnabled,
+		PodSecurityBackend: cfg.PodSecurityBackend,
+		ServerSideApply:    cfg.ServerSideApply,
+		NamespaceSelector:  namespaceSelector,
+		ControllerSelector: selector,
+		MasterScaler:       autoscaler.NewSparkMasterScaler(dynamicClient),
+		OperatorNamespace:  cfg.OperatorNamespace,
+		KEDANamespace:      cfg.KEDANamespace,
+		Admitter:           admitter,
+		Defaults:           defaults,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SparkCluster")
 		return err
 	}
 
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err = (&dcv1alpha1.RayCluster{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&dcv1alpha1.RayCluster{}).SetupWebhookWithManager(mgr, selector, defaults); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "RayCluster")
 			return err
 		}
-		if err = (&dcv1alpha1.SparkCluster{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&dcv1alpha1.SparkCluster{}).SetupWebhookWithManager(mgr, selector, defaults); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "SparkCluster")
 			return err
 		}
@@ -0,0 +1,150 @@
+package manager
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// clusterScopedObjects are read by the controllers and cannot be served by a
+// multi-namespaced cache, so they are always read from the API server when
+// the operator watches more than one namespace. Namespaces are served by the
+// cluster-wide informer of namespaceCache instead.
+var clusterScopedObjects = []client.Object{
+	&policyv1beta1.PodSecurityPolicy{},
+	&schedulingv1.PriorityClass{},
+}
+
+// watchNamespaces restricts the manager cache to the given namespaces. An
+// empty list watches every namespace in the cluster.
+func watchNamespaces(opts *ctrl.Options, namespaces []string) {
+	namespaces = uniqueNamespaces(namespaces)
+
+	switch len(namespaces) {
+	case 0:
+		opts.Namespace = ""
+	case 1:
+		opts.Namespace = namespaces[0]
+	default:
+		opts.NewCache = namespaceCacheBuilder(namespaces)
+		opts.ClientBuilder = cluster.NewClientBuilder().WithUncached(clusterScopedObjects...)
+	}
+}
+
+// namespaceCacheBuilder returns a multi-namespaced cache that serves
+// Namespaces from a single cluster-wide informer. A multi-namespaced cache
+// starts one informer per namespace for every kind, so each Namespace event
+// would otherwise be delivered once per watched namespace.
+func namespaceCacheBuilder(namespaces []string) cache.NewCacheFunc {
+	return func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		namespaced, err := cache.MultiNamespacedCacheBuilder(namespaces)(config, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Namespace = ""
+		clusterWide, err := cache.New(config, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return &namespaceCache{Cache: namespaced, namespaces: clusterWide}, nil
+	}
+}
+
+// namespaceCache serves Namespaces from the namespaces cache and every other
+// kind from the embedded multi-namespaced cache.
+type namespaceCache struct {
+	cache.Cache
+	namespaces cache.Cache
+}
+
+func (c *namespaceCache) cacheFor(obj runtime.Object) cache.Cache {
+	switch obj.(type) {
+	case *corev1.Namespace, *corev1.NamespaceList:
+		return c.namespaces
+	default:
+		return c.Cache
+	}
+}
+
+func (c *namespaceCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	return c.cacheFor(obj).Get(ctx, key, obj)
+}
+
+func (c *namespaceCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.cacheFor(list).List(ctx, list, opts...)
+}
+
+func (c *namespaceCache) GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	return c.cacheFor(obj).GetInformer(ctx, obj)
+}
+
+func (c *namespaceCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error) {
+	if gvk == corev1.SchemeGroupVersion.WithKind("Namespace") {
+		return c.namespaces.GetInformerForKind(ctx, gvk)
+	}
+
+	return c.Cache.GetInformerForKind(ctx, gvk)
+}
+
+func (c *namespaceCache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	return c.cacheFor(obj).IndexField(ctx, obj, field, extractValue)
+}
+
+// Start runs both caches until the context is done.
+func (c *namespaceCache) Start(ctx context.Context) error {
+	errs := make(chan error, 1)
+	go func() { errs <- c.namespaces.Start(ctx) }()
+
+	if err := c.Cache.Start(ctx); err != nil {
+		return err
+	}
+
+	return <-errs
+}
+
+func (c *namespaceCache) WaitForCacheSync(ctx context.Context) bool {
+	namespacesSynced := c.namespaces.WaitForCacheSync(ctx)
+	return c.Cache.WaitForCacheSync(ctx) && namespacesSynced
+}
+
+// uniqueNamespaces drops blank and duplicate entries from a list of
+// namespaces while preserving their order.
+func uniqueNamespaces(namespaces []string) []string {
+	seen := map[string]bool{}
+
+	var unique []string
+	for _, ns := range namespaces {
+		ns = strings.TrimSpace(ns)
+		if ns == "" || seen[ns] {
+			continue
+		}
+
+		seen[ns] = true
+		unique = append(unique, ns)
+	}
+
+	return unique
+}
+
+// parseLabelSelector parses a label selector. An empty value selects every
+// object and returns nil.
+func parseLabelSelector(selector string) (labels.Selector, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+
+	return labels.Parse(selector)
+}
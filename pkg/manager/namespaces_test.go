@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+)
+
+func TestWatchNamespaces(t *testing.T) {
+	t.Run("cluster_wide", func(t *testing.T) {
+		opts := ctrl.Options{}
+		watchNamespaces(&opts, []string{" ", ""})
+
+		assert.Empty(t, opts.Namespace)
+		assert.Nil(t, opts.NewCache)
+		assert.Nil(t, opts.ClientBuilder)
+	})
+
+	t.Run("single", func(t *testing.T) {
+		opts := ctrl.Options{}
+		watchNamespaces(&opts, []string{"team-a", "team-a"})
+
+		assert.Equal(t, "team-a", opts.Namespace)
+		assert.Nil(t, opts.NewCache)
+		assert.Nil(t, opts.ClientBuilder)
+	})
+
+	t.Run("multiple", func(t *testing.T) {
+		opts := ctrl.Options{}
+		watchNamespaces(&opts, []string{"team-a", "team-b"})
+
+		assert.Empty(t, opts.Namespace)
+		assert.NotNil(t, opts.NewCache)
+		assert.NotNil(t, opts.ClientBuilder)
+	})
+}
+
+func TestNamespaceCache(t *testing.T) {
+	ctx := context.Background()
+	namespaced := &informertest.FakeInformers{}
+	clusterWide := &informertest.FakeInformers{}
+	c := &namespaceCache{Cache: namespaced, namespaces: clusterWide}
+
+	nsGVK := corev1.SchemeGroupVersion.WithKind("Namespace")
+	podGVK := corev1.SchemeGroupVersion.WithKind("Pod")
+
+	_, err := c.GetInformer(ctx, &corev1.Namespace{})
+	require.NoError(t, err)
+	_, err = c.GetInformer(ctx, &corev1.Pod{})
+	require.NoError(t, err)
+
+	assert.Contains(t, clusterWide.InformersByGVK, nsGVK)
+	assert.NotContains(t, clusterWide.InformersByGVK, podGVK)
+	assert.Contains(t, namespaced.InformersByGVK, podGVK)
+	assert.NotContains(t, namespaced.InformersByGVK, nsGVK)
+
+	_, err = c.GetInformerForKind(ctx, nsGVK)
+	require.NoError(t, err)
+	assert.Len(t, clusterWide.InformersByGVK, 1)
+	assert.Len(t, namespaced.InformersByGVK, 1)
+}
+
+func TestUniqueNamespaces(t *testing.T) {
+	actual := uniqueNamespaces([]string{"team-b", " team-a", "", "team-b", "team-a "})
+	assert.Equal(t, []string{"team-b", "team-a"}, actual)
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	selector, err := parseLabelSelector(" ")
+	require.NoError(t, err)
+	assert.Nil(t, selector)
+
+	selector, err = parseLabelSelector("dco.dominodatalab.com/managed=true")
+	require.NoError(t, err)
+	assert.True(t, selector.Matches(labels.Set{"dco.dominodatalab.com/managed": "true"}))
+	assert.False(t, selector.Matches(labels.Set{}))
+
+	_, err = parseLabelSelector("managed in (")
+	assert.Error(t, err)
+}
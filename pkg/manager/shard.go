@@ -0,0 +1,49 @@
+package manager
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+// baseLeaderElectionID is the leader election lock of the default shard.
+const baseLeaderElectionID = "a846cbf2.dominodatalab.com"
+
+// controllerSelector returns the selector of the clusters managed by an
+// operator shard.
+func controllerSelector(class, labelSelector string) (dcv1alpha1.ControllerSelector, error) {
+	if class == "" {
+		class = dcv1alpha1.DefaultControllerClass
+	}
+	if errs := validation.IsDNS1123Label(class); len(errs) > 0 {
+		return dcv1alpha1.ControllerSelector{}, fmt.Errorf("invalid controller class %q: %s", class, strings.Join(errs, ", "))
+	}
+
+	selector, err := parseLabelSelector(labelSelector)
+	if err != nil {
+		return dcv1alpha1.ControllerSelector{}, err
+	}
+
+	return dcv1alpha1.ControllerSelector{Class: class, Labels: selector}, nil
+}
+
+// leaderElectionID returns a leader election lock name that is unique to an
+// operator shard so that the instances of every shard elect their own leader.
+// The default shard keeps the name used by earlier releases.
+func leaderElectionID(selector dcv1alpha1.ControllerSelector) string {
+	id := baseLeaderElectionID
+	if selector.Labels != nil {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(selector.Labels.String()))
+		id = fmt.Sprintf("%08x.%s", h.Sum32(), id)
+	}
+	if selector.Class != dcv1alpha1.DefaultControllerClass {
+		id = fmt.Sprintf("%s.%s", selector.Class, id)
+	}
+
+	return id
+}
@@ -0,0 +1,46 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestControllerSelector(t *testing.T) {
+	selector, err := controllerSelector("", "")
+	require.NoError(t, err)
+	assert.Equal(t, dcv1alpha1.ControllerSelector{Class: dcv1alpha1.DefaultControllerClass}, selector)
+
+	selector, err = controllerSelector("experimental", "tier=canary")
+	require.NoError(t, err)
+	assert.Equal(t, "experimental", selector.Class)
+	assert.Equal(t, "tier=canary", selector.Labels.String())
+
+	_, err = controllerSelector("Not_Valid", "")
+	assert.Error(t, err)
+
+	_, err = controllerSelector("experimental", "tier in (")
+	assert.Error(t, err)
+}
+
+func TestLeaderElectionID(t *testing.T) {
+	testcases := []struct {
+		class    string
+		labels   string
+		expected string
+	}{
+		{class: "", labels: "", expected: "a846cbf2.dominodatalab.com"},
+		{class: "experimental", labels: "", expected: "experimental.a846cbf2.dominodatalab.com"},
+		{class: "default", labels: "tier=canary", expected: "35d652bc.a846cbf2.dominodatalab.com"},
+		{class: "experimental", labels: "tier=canary", expected: "experimental.35d652bc.a846cbf2.dominodatalab.com"},
+	}
+
+	for _, tc := range testcases {
+		selector, err := controllerSelector(tc.class, tc.labels)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, leaderElectionID(selector), "class %q labels %q", tc.class, tc.labels)
+	}
+}
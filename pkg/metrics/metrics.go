@@ -0,0 +1,254 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const namespace = "distributed_compute"
+
+// Lifecycle phases reported for compute clusters.
+const (
+	// PhaseQueued indicates the cluster is waiting for admission.
+	PhaseQueued = "Queued"
+	// PhasePending indicates the cluster is admitted but some of its nodes
+	// are not ready.
+	PhasePending = "Pending"
+	// PhaseReady indicates the head and all desired workers are ready.
+	PhaseReady = "Ready"
+	// PhaseTerminating indicates the cluster is being deleted.
+	PhaseTerminating = "Terminating"
+)
+
+var phases = []string{PhaseQueued, PhasePending, PhaseReady, PhaseTerminating}
+
+// Histogram buckets range from 10s to ~85m for time-to-ready and from 64B to
+// 128KiB for patch sizes.
+const (
+	timeToReadyBucketStart = 10
+	patchSizeBucketStart   = 64
+	bucketFactor           = 2
+	timeToReadyBucketCount = 10
+	patchSizeBucketCount   = 12
+)
+
+var (
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Number of reconciliation errors by cluster kind and failing step.",
+	}, []string{"kind", "step"})
+
+	patchesApplied = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "patches_applied_total",
+		Help:      "Number of patches applied to resources owned by compute clusters.",
+	}, []string{"kind", "resource"})
+
+	patchSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "patch_size_bytes",
+		Help:      "Size of patches applied to resources owned by compute clusters.",
+		Buckets:   prometheus.ExponentialBuckets(patchSizeBucketStart, bucketFactor, patchSizeBucketCount),
+	}, []string{"kind", "resource"})
+
+	driftCorrections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "drift_corrections_total",
+		Help:      "Number of times fields of owned resources modified by other managers were forcibly reapplied.",
+	}, []string{"kind", "resource"})
+
+	clusters = NewClusterCollector()
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(reconcileErrors, patchesApplied, patchSize, driftCorrections, clusters)
+}
+
+// RecordReconcileError counts a failure of the named reconciliation step.
+func RecordReconcileError(kind, step string) {
+	reconcileErrors.WithLabelValues(kind, step).Inc()
+}
+
+// RecordPatch records a patch of the given size applied to an owned resource.
+func RecordPatch(kind, resource string, size int) {
+	patchesApplied.WithLabelValues(kind, resource).Inc()
+	patchSize.WithLabelValues(kind, resource).Observe(float64(size))
+}
+
+// RecordDriftCorrection counts a server-side apply that took ownership of
+// fields that were changed by another field manager.
+func RecordDriftCorrection(kind, resource string) {
+	driftCorrections.WithLabelValues(kind, resource).Inc()
+}
+
+// RecordCluster records the observed state of a cluster with the default
+// collector.
+func RecordCluster(kind string, key types.NamespacedName, state ClusterState) {
+	clusters.Record(kind, key, state)
+}
+
+// ForgetCluster removes a cluster that was deleted or is no longer managed by
+// this operator instance from the default collector.
+func ForgetCluster(kind string, key types.NamespacedName) {
+	clusters.Forget(kind, key)
+}
+
+// Phase returns the lifecycle phase of a cluster.
+func Phase(deleting bool, admission dcv1alpha1.ClusterPhase, ready bool) string {
+	switch {
+	case deleting:
+		return PhaseTerminating
+	case admission == dcv1alpha1.ClusterPhaseQueued:
+		return PhaseQueued
+	case ready:
+		return PhaseReady
+	default:
+		return PhasePending
+	}
+}
+
+// ClusterState is the observed state of a single cluster.
+type ClusterState struct {
+	Phase           string
+	DesiredReplicas int32
+	ReadyReplicas   int32
+	Created         time.Time
+}
+
+type clusterKey struct {
+	kind string
+	types.NamespacedName
+}
+
+type clusterEntry struct {
+	state ClusterState
+	// readySeen is set once the cluster has been observed in the ready phase
+	// so that time-to-ready is only reported for the first transition.
+	readySeen bool
+}
+
+// ClusterCollector reports per-phase cluster counts and worker replicas for
+// the clusters recorded by the controllers. Time-to-ready is only observed
+// for clusters that were seen before they became ready, so clusters that are
+// already running when the operator starts are not reported.
+type ClusterCollector struct {
+	mu       sync.Mutex
+	clusters map[clusterKey]*clusterEntry
+	// kinds keeps reporting zero counts after the last cluster of a kind is
+	// deleted.
+	kinds map[string]bool
+
+	phaseDesc   *prometheus.Desc
+	desiredDesc *prometheus.Desc
+	readyDesc   *prometheus.Desc
+	timeToReady *prometheus.HistogramVec
+}
+
+// NewClusterCollector creates an empty cluster collector.
+func NewClusterCollector() *ClusterCollector {
+	replicaLabels := []string{"kind", "namespace", "name"}
+
+	return &ClusterCollector{
+		clusters: map[clusterKey]*clusterEntry{},
+		kinds:    map[string]bool{},
+		phaseDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "clusters"),
+			"Number of clusters by kind and lifecycle phase.",
+			[]string{"kind", "phase"}, nil,
+		),
+		desiredDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "cluster", "worker_replicas_desired"),
+			"Number of worker replicas requested for a cluster.",
+			replicaLabels, nil,
+		),
+		readyDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "cluster", "worker_replicas_ready"),
+			"Number of ready worker replicas in a cluster.",
+			replicaLabels, nil,
+		),
+		timeToReady: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "cluster",
+			Name:      "time_to_ready_seconds",
+			Help:      "Time from cluster creation until the head and all workers are ready.",
+			Buckets:   prometheus.ExponentialBuckets(timeToReadyBucketStart, bucketFactor, timeToReadyBucketCount),
+		}, []string{"kind"}),
+	}
+}
+
+// Record stores the latest state of a cluster.
+func (c *ClusterCollector) Record(kind string, key types.NamespacedName, state ClusterState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.kinds[kind] = true
+
+	ck := clusterKey{kind: kind, NamespacedName: key}
+	entry, seen := c.clusters[ck]
+	if !seen {
+		entry = &clusterEntry{}
+		c.clusters[ck] = entry
+	}
+
+	if state.Phase == PhaseReady && !entry.readySeen {
+		if seen {
+			c.timeToReady.WithLabelValues(kind).Observe(time.Since(state.Created).Seconds())
+		}
+		entry.readySeen = true
+	}
+	entry.state = state
+}
+
+// Forget removes a cluster from the collector.
+func (c *ClusterCollector) Forget(kind string, key types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.clusters, clusterKey{kind: kind, NamespacedName: key})
+}
+
+// Describe implements prometheus.Collector.
+func (c *ClusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.phaseDesc
+	ch <- c.desiredDesc
+	ch <- c.readyDesc
+	c.timeToReady.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *ClusterCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := map[string]map[string]int{}
+	for kind := range c.kinds {
+		counts[kind] = map[string]int{}
+	}
+	for key, entry := range c.clusters {
+		counts[key.kind][entry.state.Phase]++
+
+		ch <- prometheus.MustNewConstMetric(
+			c.desiredDesc, prometheus.GaugeValue, float64(entry.state.DesiredReplicas),
+			key.kind, key.Namespace, key.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.readyDesc, prometheus.GaugeValue, float64(entry.state.ReadyReplicas),
+			key.kind, key.Namespace, key.Name,
+		)
+	}
+
+	for kind, byPhase := range counts {
+		for _, phase := range phases {
+			ch <- prometheus.MustNewConstMetric(c.phaseDesc, prometheus.GaugeValue, float64(byPhase[phase]), kind, phase)
+		}
+	}
+
+	c.timeToReady.Collect(ch)
+}
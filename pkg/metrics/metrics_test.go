@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestPhase(t *testing.T) {
+	assert.Equal(t, PhaseTerminating, Phase(true, dcv1alpha1.ClusterPhaseQueued, true))
+	assert.Equal(t, PhaseQueued, Phase(false, dcv1alpha1.ClusterPhaseQueued, false))
+	assert.Equal(t, PhaseReady, Phase(false, dcv1alpha1.ClusterPhaseAdmitted, true))
+	assert.Equal(t, PhaseReady, Phase(false, "", true))
+	assert.Equal(t, PhasePending, Phase(false, "", false))
+}
+
+func TestRecordDriftCorrection(t *testing.T) {
+	RecordDriftCorrection("RayCluster", "StatefulSet")
+	RecordDriftCorrection("RayCluster", "StatefulSet")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(driftCorrections.WithLabelValues("RayCluster", "StatefulSet")))
+}
+
+func TestClusterCollector(t *testing.T) {
+	key := types.NamespacedName{Namespace: "ns", Name: "test"}
+	created := time.Now().Add(-time.Minute)
+
+	t.Run("phases_and_replicas", func(t *testing.T) {
+		c := NewClusterCollector()
+		c.Record("RayCluster", key, ClusterState{Phase: PhasePending, DesiredReplicas: 3, ReadyReplicas: 1, Created: created})
+
+		expected := `
+# HELP distributed_compute_clusters Number of clusters by kind and lifecycle phase.
+# TYPE distributed_compute_clusters gauge
+distributed_compute_clusters{kind="RayCluster",phase="Pending"} 1
+distributed_compute_clusters{kind="RayCluster",phase="Queued"} 0
+distributed_compute_clusters{kind="RayCluster",phase="Ready"} 0
+distributed_compute_clusters{kind="RayCluster",phase="Terminating"} 0
+# HELP distributed_compute_cluster_worker_replicas_desired Number of worker replicas requested for a cluster.
+# TYPE distributed_compute_cluster_worker_replicas_desired gauge
+distributed_compute_cluster_worker_replicas_desired{kind="RayCluster",name="test",namespace="ns"} 3
+# HELP distributed_compute_cluster_worker_replicas_ready Number of ready worker replicas in a cluster.
+# TYPE distributed_compute_cluster_worker_replicas_ready gauge
+distributed_compute_cluster_worker_replicas_ready{kind="RayCluster",name="test",namespace="ns"} 1
+`
+		require.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected),
+			"distributed_compute_clusters",
+			"distributed_compute_cluster_worker_replicas_desired",
+			"distributed_compute_cluster_worker_replicas_ready",
+		))
+
+		c.Forget("RayCluster", key)
+		assert.Equal(t, 4, testutil.CollectAndCount(c, "distributed_compute_clusters"))
+		assert.Equal(t, 0, testutil.CollectAndCount(c, "distributed_compute_cluster_worker_replicas_desired"))
+	})
+
+	t.Run("time_to_ready", func(t *testing.T) {
+		c := NewClusterCollector()
+		c.Record("SparkCluster", key, ClusterState{Phase: PhasePending, Created: created})
+		c.Record("SparkCluster", key, ClusterState{Phase: PhaseReady, Created: created})
+		c.Record("SparkCluster", key, ClusterState{Phase: PhasePending, Created: created})
+		c.Record("SparkCluster", key, ClusterState{Phase: PhaseReady, Created: created})
+
+		assert.Equal(t, uint64(1), sampleCount(t, c))
+	})
+
+	t.Run("time_to_ready_skips_running_clusters", func(t *testing.T) {
+		c := NewClusterCollector()
+		c.Record("SparkCluster", key, ClusterState{Phase: PhaseReady, Created: created})
+
+		assert.Equal(t, uint64(0), sampleCount(t, c))
+	})
+}
+
+func sampleCount(t *testing.T, c *ClusterCollector) uint64 {
+	t.Helper()
+
+	var count uint64
+	for _, kind := range []string{"RayCluster", "SparkCluster"} {
+		metric := &dto.Metric{}
+		observer, err := c.timeToReady.GetMetricWithLabelValues(kind)
+		require.NoError(t, err)
+		require.NoError(t, observer.(prometheus.Histogram).Write(metric))
+		count += metric.GetHistogram().GetSampleCount()
+	}
+
+	return count
+}
@@ -0,0 +1,46 @@
+package podsecurity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Backend selects the platform mechanism used to govern the security of
+// cluster pods.
+type Backend string
+
+const (
+	// BackendPodSecurityPolicy grants cluster pods use of a named
+	// policy/v1beta1 PodSecurityPolicy. This is only available on
+	// Kubernetes versions prior to 1.25.
+	BackendPodSecurityPolicy Backend = "psp"
+	// BackendPodSecurityAdmission validates cluster pod templates against
+	// the Pod Security Standards level enforced on the target namespace.
+	BackendPodSecurityAdmission Backend = "pod-security-admission"
+	// BackendSecurityContextConstraints grants cluster pods use of a named
+	// OpenShift SecurityContextConstraints.
+	BackendSecurityContextConstraints Backend = "scc"
+)
+
+// Backends lists all supported pod security backends.
+var Backends = []Backend{
+	BackendPodSecurityPolicy,
+	BackendPodSecurityAdmission,
+	BackendSecurityContextConstraints,
+}
+
+// ParseBackend returns the backend matching the provided name.
+func ParseBackend(name string) (Backend, error) {
+	for _, backend := range Backends {
+		if string(backend) == name {
+			return backend, nil
+		}
+	}
+
+	names := make([]string, 0, len(Backends))
+	for _, backend := range Backends {
+		names = append(names, string(backend))
+	}
+
+	return "", fmt.Errorf("unsupported pod security backend %q, must be one of: %s", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,19 @@
+package podsecurity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBackend(t *testing.T) {
+	for _, backend := range Backends {
+		actual, err := ParseBackend(string(backend))
+		require.NoError(t, err)
+		assert.Equal(t, backend, actual)
+	}
+
+	_, err := ParseBackend("apparmor")
+	assert.EqualError(t, err, `unsupported pod security backend "apparmor", must be one of: psp, pod-security-admission, scc`)
+}
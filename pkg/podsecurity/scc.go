@@ -0,0 +1,24 @@
+package podsecurity
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SecurityContextConstraintsGVK is the group version kind of the OpenShift
+// SecurityContextConstraints API.
+var SecurityContextConstraintsGVK = schema.GroupVersionKind{
+	Group:   "security.openshift.io",
+	Version: "v1",
+	Kind:    "SecurityContextConstraints",
+}
+
+// NewSecurityContextConstraints returns an empty unstructured object that can
+// be used to fetch OpenShift security context constraints without
+// registering the OpenShift API types with the scheme.
+func NewSecurityContextConstraints() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(SecurityContextConstraintsGVK)
+
+	return obj
+}
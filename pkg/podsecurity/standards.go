@@ -0,0 +1,284 @@
+package podsecurity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Level is a Pod Security Standards profile.
+type Level string
+
+const (
+	// LevelPrivileged is an unrestricted policy.
+	LevelPrivileged Level = "privileged"
+	// LevelBaseline prevents known privilege escalations.
+	LevelBaseline Level = "baseline"
+	// LevelRestricted enforces current pod hardening best practices.
+	LevelRestricted Level = "restricted"
+
+	// EnforceLevelLabel is the namespace label used by Pod Security Admission
+	// to select the level that is enforced on pods.
+	EnforceLevelLabel = "pod-security.kubernetes.io/enforce"
+
+	appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+)
+
+var (
+	baselineCapabilities = map[corev1.Capability]bool{
+		"AUDIT_WRITE":      true,
+		"CHOWN":            true,
+		"DAC_OVERRIDE":     true,
+		"FOWNER":           true,
+		"FSETID":           true,
+		"KILL":             true,
+		"MKNOD":            true,
+		"NET_BIND_SERVICE": true,
+		"SETFCAP":          true,
+		"SETGID":           true,
+		"SETPCAP":          true,
+		"SETUID":           true,
+		"SYS_CHROOT":       true,
+	}
+	baselineSELinuxTypes = map[string]bool{
+		"":                 true,
+		"container_t":      true,
+		"container_init_t": true,
+		"container_kvm_t":  true,
+	}
+	baselineSysctls = map[string]bool{
+		"kernel.shm_rmid_forced":              true,
+		"net.ipv4.ip_local_port_range":        true,
+		"net.ipv4.ip_unprivileged_port_start": true,
+		"net.ipv4.tcp_syncookies":             true,
+		"net.ipv4.ping_group_range":           true,
+	}
+)
+
+// NamespaceLevel returns the level enforced on the namespace. Namespaces
+// without a valid enforce label are treated as privileged.
+func NamespaceLevel(ns *corev1.Namespace) Level {
+	switch level := Level(ns.Labels[EnforceLevelLabel]); level {
+	case LevelBaseline, LevelRestricted:
+		return level
+	default:
+		return LevelPrivileged
+	}
+}
+
+// Violations checks the pod templates of the provided stateful sets against
+// the given level and returns a description of every failed check.
+func Violations(level Level, sets ...*appsv1.StatefulSet) []string {
+	var violations []string
+	for _, sts := range sets {
+		for _, msg := range CheckPodTemplate(level, &sts.Spec.Template) {
+			violations = append(violations, fmt.Sprintf("%s: %s", sts.Name, msg))
+		}
+	}
+
+	return violations
+}
+
+// CheckPodTemplate checks a single pod template against the given level.
+func CheckPodTemplate(level Level, tmpl *corev1.PodTemplateSpec) []string {
+	if level != LevelBaseline && level != LevelRestricted {
+		return nil
+	}
+
+	c := &checker{spec: &tmpl.Spec}
+	c.baseline(tmpl.Annotations)
+	if level == LevelRestricted {
+		c.restricted()
+	}
+
+	return c.violations
+}
+
+type checker struct {
+	spec       *corev1.PodSpec
+	violations []string
+}
+
+func (c *checker) addf(format string, args ...interface{}) {
+	c.violations = append(c.violations, fmt.Sprintf(format, args...))
+}
+
+func (c *checker) containers() []corev1.Container {
+	return append(append([]corev1.Container{}, c.spec.InitContainers...), c.spec.Containers...)
+}
+
+func (c *checker) baseline(annotations map[string]string) {
+	if c.spec.HostNetwork {
+		c.addf("host namespaces: hostNetwork=true")
+	}
+	if c.spec.HostPID {
+		c.addf("host namespaces: hostPID=true")
+	}
+	if c.spec.HostIPC {
+		c.addf("host namespaces: hostIPC=true")
+	}
+
+	for _, vol := range c.spec.Volumes {
+		if vol.HostPath != nil {
+			c.addf("hostPath volumes: volume %q", vol.Name)
+		}
+	}
+
+	var keys []string
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val := annotations[key]
+		if strings.HasPrefix(key, appArmorAnnotationPrefix) && val != "runtime/default" && !strings.HasPrefix(val, "localhost/") {
+			c.addf("appArmor profile: annotation %q=%q", key, val)
+		}
+	}
+
+	if psc := c.spec.SecurityContext; psc != nil {
+		c.checkSELinux("pod", psc.SELinuxOptions)
+		if psc.SeccompProfile != nil && psc.SeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+			c.addf("seccompProfile: pod must not set type=Unconfined")
+		}
+		for _, sysctl := range psc.Sysctls {
+			if !baselineSysctls[sysctl.Name] {
+				c.addf("forbidden sysctls: %s", sysctl.Name)
+			}
+		}
+	}
+
+	containers := c.containers()
+	for i := range containers {
+		c.baselineContainer(&containers[i])
+	}
+}
+
+func (c *checker) baselineContainer(container *corev1.Container) {
+	for _, port := range container.Ports {
+		if port.HostPort != 0 {
+			c.addf("hostPort: container %q uses hostPort %d", container.Name, port.HostPort)
+		}
+	}
+
+	sc := container.SecurityContext
+	if sc == nil {
+		return
+	}
+	if sc.Privileged != nil && *sc.Privileged {
+		c.addf("privileged: container %q must not set privileged=true", container.Name)
+	}
+	if sc.Capabilities != nil {
+		for _, capability := range sc.Capabilities.Add {
+			if !baselineCapabilities[capability] {
+				c.addf("non-default capabilities: container %q must not add %s", container.Name, capability)
+			}
+		}
+	}
+	c.checkSELinux(fmt.Sprintf("container %q", container.Name), sc.SELinuxOptions)
+	if sc.ProcMount != nil && *sc.ProcMount != corev1.DefaultProcMount {
+		c.addf("procMount: container %q must use the default proc mount", container.Name)
+	}
+	if sc.SeccompProfile != nil && sc.SeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+		c.addf("seccompProfile: container %q must not set type=Unconfined", container.Name)
+	}
+}
+
+func (c *checker) checkSELinux(subject string, opts *corev1.SELinuxOptions) {
+	if opts == nil {
+		return
+	}
+	if !baselineSELinuxTypes[opts.Type] {
+		c.addf("seLinuxOptions: %s must not set type=%q", subject, opts.Type)
+	}
+	if opts.User != "" || opts.Role != "" {
+		c.addf("seLinuxOptions: %s must not set user or role", subject)
+	}
+}
+
+func (c *checker) restricted() {
+	for _, vol := range c.spec.Volumes {
+		if !restrictedVolume(&vol.VolumeSource) {
+			c.addf("restricted volume types: volume %q uses a restricted volume type", vol.Name)
+		}
+	}
+
+	var podNonRoot, podSeccomp bool
+	if psc := c.spec.SecurityContext; psc != nil {
+		if psc.RunAsNonRoot != nil {
+			if !*psc.RunAsNonRoot {
+				c.addf("runAsNonRoot: pod must not set runAsNonRoot=false")
+			}
+			podNonRoot = *psc.RunAsNonRoot
+		}
+		if psc.RunAsUser != nil && *psc.RunAsUser == 0 {
+			c.addf("runAsUser: pod must not set runAsUser=0")
+		}
+		podSeccomp = allowedSeccomp(psc.SeccompProfile)
+	}
+
+	containers := c.containers()
+	for i := range containers {
+		c.restrictedContainer(&containers[i], podNonRoot, podSeccomp)
+	}
+}
+
+func (c *checker) restrictedContainer(container *corev1.Container, podNonRoot, podSeccomp bool) {
+	sc := container.SecurityContext
+	if sc == nil {
+		sc = &corev1.SecurityContext{}
+	}
+
+	if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+		c.addf("allowPrivilegeEscalation: container %q must set allowPrivilegeEscalation=false", container.Name)
+	}
+
+	switch {
+	case sc.RunAsNonRoot != nil && !*sc.RunAsNonRoot:
+		c.addf("runAsNonRoot: container %q must not set runAsNonRoot=false", container.Name)
+	case sc.RunAsNonRoot == nil && !podNonRoot:
+		c.addf("runAsNonRoot: container %q must set runAsNonRoot=true", container.Name)
+	}
+	if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+		c.addf("runAsUser: container %q must not set runAsUser=0", container.Name)
+	}
+
+	if (sc.SeccompProfile == nil && !podSeccomp) || (sc.SeccompProfile != nil && !allowedSeccomp(sc.SeccompProfile)) {
+		c.addf("seccompProfile: container %q must set type=RuntimeDefault or Localhost", container.Name)
+	}
+
+	c.restrictedCapabilities(container.Name, sc.Capabilities)
+}
+
+func (c *checker) restrictedCapabilities(name string, caps *corev1.Capabilities) {
+	var dropsAll bool
+	if caps != nil {
+		for _, capability := range caps.Drop {
+			if capability == "ALL" {
+				dropsAll = true
+			}
+		}
+		for _, capability := range caps.Add {
+			if capability != "NET_BIND_SERVICE" {
+				c.addf("capabilities: container %q must not add %s", name, capability)
+			}
+		}
+	}
+	if !dropsAll {
+		c.addf("capabilities: container %q must drop ALL", name)
+	}
+}
+
+func restrictedVolume(src *corev1.VolumeSource) bool {
+	return src.ConfigMap != nil || src.CSI != nil || src.DownwardAPI != nil || src.EmptyDir != nil ||
+		src.Ephemeral != nil || src.PersistentVolumeClaim != nil || src.Projected != nil || src.Secret != nil
+}
+
+func allowedSeccomp(profile *corev1.SeccompProfile) bool {
+	return profile != nil &&
+		(profile.Type == corev1.SeccompProfileTypeRuntimeDefault || profile.Type == corev1.SeccompProfileTypeLocalhost)
+}
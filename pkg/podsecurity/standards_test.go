@@ -0,0 +1,141 @@
+package podsecurity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestNamespaceLevel(t *testing.T) {
+	testcases := []struct {
+		labels   map[string]string
+		expected Level
+	}{
+		{nil, LevelPrivileged},
+		{map[string]string{EnforceLevelLabel: "baseline"}, LevelBaseline},
+		{map[string]string{EnforceLevelLabel: "restricted"}, LevelRestricted},
+		{map[string]string{EnforceLevelLabel: "garbage"}, LevelPrivileged},
+	}
+
+	for _, tc := range testcases {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: tc.labels}}
+		assert.Equal(t, tc.expected, NamespaceLevel(ns))
+	}
+}
+
+func TestCheckPodTemplate(t *testing.T) {
+	t.Run("privileged", func(t *testing.T) {
+		tmpl := podTemplateFixture()
+		tmpl.Spec.HostNetwork = true
+
+		assert.Empty(t, CheckPodTemplate(LevelPrivileged, tmpl))
+	})
+
+	t.Run("baseline", func(t *testing.T) {
+		tmpl := podTemplateFixture()
+		tmpl.Annotations = map[string]string{appArmorAnnotationPrefix + "main": "unconfined"}
+		tmpl.Spec.HostNetwork = true
+		tmpl.Spec.Volumes = []corev1.Volume{
+			{
+				Name:         "host",
+				VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}},
+			},
+		}
+		tmpl.Spec.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: 80, HostPort: 80}}
+		tmpl.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{
+			Privileged: pointer.BoolPtr(true),
+			Capabilities: &corev1.Capabilities{
+				Add: []corev1.Capability{"SYS_ADMIN", "CHOWN"},
+			},
+		}
+
+		expected := []string{
+			"host namespaces: hostNetwork=true",
+			`hostPath volumes: volume "host"`,
+			`appArmor profile: annotation "container.apparmor.security.beta.kubernetes.io/main"="unconfined"`,
+			`hostPort: container "main" uses hostPort 80`,
+			`privileged: container "main" must not set privileged=true`,
+			`non-default capabilities: container "main" must not add SYS_ADMIN`,
+		}
+		assert.Equal(t, expected, CheckPodTemplate(LevelBaseline, tmpl))
+	})
+
+	t.Run("baseline_compliant", func(t *testing.T) {
+		assert.Empty(t, CheckPodTemplate(LevelBaseline, podTemplateFixture()))
+	})
+
+	t.Run("restricted", func(t *testing.T) {
+		tmpl := podTemplateFixture()
+		tmpl.Spec.InitContainers = []corev1.Container{{Name: "init"}}
+
+		expected := []string{
+			`allowPrivilegeEscalation: container "init" must set allowPrivilegeEscalation=false`,
+			`runAsNonRoot: container "init" must set runAsNonRoot=true`,
+			`seccompProfile: container "init" must set type=RuntimeDefault or Localhost`,
+			`capabilities: container "init" must drop ALL`,
+			`allowPrivilegeEscalation: container "main" must set allowPrivilegeEscalation=false`,
+			`runAsNonRoot: container "main" must set runAsNonRoot=true`,
+			`seccompProfile: container "main" must set type=RuntimeDefault or Localhost`,
+			`capabilities: container "main" must drop ALL`,
+		}
+		assert.Equal(t, expected, CheckPodTemplate(LevelRestricted, tmpl))
+	})
+
+	t.Run("restricted_compliant", func(t *testing.T) {
+		tmpl := podTemplateFixture()
+		tmpl.Spec.SecurityContext = &corev1.PodSecurityContext{
+			RunAsNonRoot: pointer.BoolPtr(true),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		}
+		tmpl.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+				Drop: []corev1.Capability{"ALL"},
+			},
+		}
+		tmpl.Spec.Volumes = []corev1.Volume{
+			{
+				Name:         "scratch",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			},
+		}
+
+		assert.Empty(t, CheckPodTemplate(LevelRestricted, tmpl))
+	})
+}
+
+func TestViolations(t *testing.T) {
+	compliant := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "compliant"},
+		Spec:       appsv1.StatefulSetSpec{Template: *podTemplateFixture()},
+	}
+	privileged := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "privileged"},
+		Spec:       appsv1.StatefulSetSpec{Template: *podTemplateFixture()},
+	}
+	privileged.Spec.Template.Spec.HostPID = true
+
+	expected := []string{"privileged: host namespaces: hostPID=true"}
+	assert.Equal(t, expected, Violations(LevelBaseline, compliant, privileged))
+	assert.Empty(t, Violations(LevelPrivileged, compliant, privileged))
+}
+
+func podTemplateFixture() *corev1.PodTemplateSpec {
+	return &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "main",
+					Image: "busybox",
+				},
+			},
+		},
+	}
+}
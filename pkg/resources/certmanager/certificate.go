@@ -0,0 +1,89 @@
+package certmanager
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+// CertificateGVK identifies the cert-manager Certificate kind.
+var CertificateGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// CertificateInfo defines fields used to generate cert-manager Certificate
+// objects.
+type CertificateInfo struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	SecretName  string
+	CommonName  string
+	IsCA        bool
+	IssuerRef   dcv1alpha1.CertificateIssuerRef
+	Duration    time.Duration
+	RenewBefore time.Duration
+}
+
+// Available returns true when the cert-manager Certificate API is installed.
+func Available(mapper meta.RESTMapper) bool {
+	_, err := mapper.RESTMapping(CertificateGVK.GroupKind(), CertificateGVK.Version)
+	return err == nil
+}
+
+// NewCertificate uses CertificateInfo to generate and return a new
+// Certificate that cert-manager will issue into the named secret.
+//
+// The object is built as unstructured content so that the operator does not
+// require the cert-manager API types to be compiled in.
+func NewCertificate(info *CertificateInfo) *unstructured.Unstructured {
+	issuerRef := map[string]interface{}{
+		"name": info.IssuerRef.Name,
+	}
+	if info.IssuerRef.Kind != "" {
+		issuerRef["kind"] = info.IssuerRef.Kind
+	}
+	if info.IssuerRef.Group != "" {
+		issuerRef["group"] = info.IssuerRef.Group
+	}
+
+	spec := map[string]interface{}{
+		"secretName": info.SecretName,
+		"commonName": info.CommonName,
+		"isCA":       info.IsCA,
+		"issuerRef":  issuerRef,
+		"privateKey": map[string]interface{}{
+			"algorithm": "ECDSA",
+			"size":      int64(256),
+		},
+	}
+	if info.Duration != 0 {
+		spec["duration"] = info.Duration.String()
+	}
+	if info.RenewBefore != 0 {
+		spec["renewBefore"] = info.RenewBefore.String()
+	}
+
+	obj := NewCertificateReference(info.Name, info.Namespace)
+	obj.SetLabels(info.Labels)
+	obj.Object["spec"] = spec
+
+	return obj
+}
+
+// NewCertificateReference returns a shallow Certificate that can be used to
+// look up or delete an existing object.
+func NewCertificateReference(name, namespace string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(CertificateGVK)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	return obj
+}
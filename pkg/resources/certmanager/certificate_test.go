@@ -0,0 +1,66 @@
+package certmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewCertificate(t *testing.T) {
+	info := &CertificateInfo{
+		Name:       "test-ca",
+		Namespace:  "test-ns",
+		Labels:     map[string]string{"app": "test"},
+		SecretName: "test-ca-secret",
+		CommonName: "test-ca",
+		IsCA:       true,
+		IssuerRef: dcv1alpha1.CertificateIssuerRef{
+			Name: "root",
+			Kind: "ClusterIssuer",
+		},
+		Duration:    90 * 24 * time.Hour,
+		RenewBefore: 30 * 24 * time.Hour,
+	}
+	actual := NewCertificate(info)
+
+	expected := map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata": map[string]interface{}{
+			"name":      "test-ca",
+			"namespace": "test-ns",
+			"labels": map[string]interface{}{
+				"app": "test",
+			},
+		},
+		"spec": map[string]interface{}{
+			"secretName": "test-ca-secret",
+			"commonName": "test-ca",
+			"isCA":       true,
+			"issuerRef": map[string]interface{}{
+				"name": "root",
+				"kind": "ClusterIssuer",
+			},
+			"privateKey": map[string]interface{}{
+				"algorithm": "ECDSA",
+				"size":      int64(256),
+			},
+			"duration":    "2160h0m0s",
+			"renewBefore": "720h0m0s",
+		},
+	}
+	assert.Equal(t, expected, actual.Object)
+}
+
+func TestAvailable(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{CertificateGVK.GroupVersion()})
+	assert.False(t, Available(mapper))
+
+	mapper.Add(CertificateGVK, meta.RESTScopeNamespace)
+	assert.True(t, Available(mapper))
+}
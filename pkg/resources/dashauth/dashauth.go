@@ -0,0 +1,120 @@
+package dashauth
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+const (
+	// ContainerName is the name of the proxy sidecar container.
+	ContainerName = "dashboard-auth"
+	// PortName is the name of the container port served by the proxy.
+	PortName = "http-auth"
+
+	// ClientIDKey is the client secret key that holds the OIDC client id.
+	ClientIDKey = "client-id"
+	// ClientSecretKey is the client secret key that holds the OIDC client secret.
+	ClientSecretKey = "client-secret"
+	// CookieSecretKey is the client secret key that holds the seed used to
+	// sign session cookies.
+	CookieSecretKey = "cookie-secret"
+)
+
+// ProxyInfo defines fields used to generate the proxy sidecar container.
+type ProxyInfo struct {
+	// Config provided in the cluster spec.
+	Config *dcv1alpha1.DashboardAuthConfig
+	// UpstreamPort is the local dashboard port that authenticated requests
+	// are forwarded to.
+	UpstreamPort int32
+	// SkipAuthRoutes are path patterns served without authentication.
+	SkipAuthRoutes []string
+	// PortName of the proxy container port. PortName is used when blank.
+	PortName string
+}
+
+// Enabled returns true when the dashboard should be served through the proxy.
+func Enabled(cfg *dcv1alpha1.DashboardAuthConfig) bool {
+	return cfg != nil && util.BoolPtrIsTrue(cfg.Enabled)
+}
+
+// NewContainer generates an oauth2-proxy sidecar that authenticates users
+// with an OIDC provider before forwarding requests to the dashboard.
+func NewContainer(info ProxyInfo) (corev1.Container, error) {
+	cfg := info.Config
+	portName := info.PortName
+	if portName == "" {
+		portName = PortName
+	}
+
+	image, err := util.ParseImageDefinition(cfg.Image)
+	if err != nil {
+		return corev1.Container{}, err
+	}
+
+	args := []string{
+		fmt.Sprintf("--http-address=0.0.0.0:%d", cfg.Port),
+		fmt.Sprintf("--upstream=http://127.0.0.1:%d/", info.UpstreamPort),
+		"--provider=oidc",
+		fmt.Sprintf("--oidc-issuer-url=%s", cfg.IssuerURL),
+		"--email-domain=*",
+		"--reverse-proxy=true",
+		"--skip-provider-button=true",
+	}
+	for _, route := range info.SkipAuthRoutes {
+		args = append(args, fmt.Sprintf("--skip-auth-route=%s", route))
+	}
+	if util.BoolPtrIsTrue(cfg.InsecureSkipVerify) {
+		args = append(args,
+			"--ssl-insecure-skip-verify=true",
+			"--insecure-oidc-skip-issuer-verification=true",
+			"--cookie-secure=false",
+		)
+	}
+	args = append(args, cfg.ExtraArgs...)
+
+	return corev1.Container{
+		Name:            ContainerName,
+		Image:           image,
+		ImagePullPolicy: cfg.Image.PullPolicy,
+		Args:            args,
+		Env: []corev1.EnvVar{
+			secretEnvVar("OAUTH2_PROXY_CLIENT_ID", cfg.ClientSecretRef, ClientIDKey),
+			secretEnvVar("OAUTH2_PROXY_CLIENT_SECRET", cfg.ClientSecretRef, ClientSecretKey),
+			secretEnvVar("OAUTH2_PROXY_COOKIE_SECRET", cfg.ClientSecretRef, CookieSecretKey),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          portName,
+				ContainerPort: cfg.Port,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		Resources: cfg.Resources,
+		ReadinessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/ping",
+					Port: intstr.FromString(portName),
+				},
+			},
+		},
+	}, nil
+}
+
+func secretEnvVar(name string, ref *corev1.LocalObjectReference, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: *ref,
+				Key:                  key,
+			},
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package dashauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func configFixture() *dcv1alpha1.DashboardAuthConfig {
+	return &dcv1alpha1.DashboardAuthConfig{
+		Enabled: pointer.BoolPtr(true),
+		Image: &dcv1alpha1.OCIImageDefinition{
+			Registry:   "quay.io",
+			Repository: "oauth2-proxy/oauth2-proxy",
+			Tag:        "v7.1.3",
+			PullPolicy: corev1.PullIfNotPresent,
+		},
+		Port:            4180,
+		IssuerURL:       "https://accounts.example.com",
+		ClientSecretRef: &corev1.LocalObjectReference{Name: "oidc-client"},
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	assert.False(t, Enabled(nil))
+	assert.False(t, Enabled(&dcv1alpha1.DashboardAuthConfig{}))
+	assert.False(t, Enabled(&dcv1alpha1.DashboardAuthConfig{Enabled: pointer.BoolPtr(false)}))
+	assert.True(t, Enabled(configFixture()))
+}
+
+func TestNewContainer(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		actual, err := NewContainer(ProxyInfo{
+			Config:       configFixture(),
+			UpstreamPort: 8265,
+		})
+		require.NoError(t, err)
+
+		secretRef := func(key string) *corev1.EnvVarSource {
+			return &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "oidc-client"},
+					Key:                  key,
+				},
+			}
+		}
+		expected := corev1.Container{
+			Name:            "dashboard-auth",
+			Image:           "quay.io/oauth2-proxy/oauth2-proxy:v7.1.3",
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Args: []string{
+				"--http-address=0.0.0.0:4180",
+				"--upstream=http://127.0.0.1:8265/",
+				"--provider=oidc",
+				"--oidc-issuer-url=https://accounts.example.com",
+				"--email-domain=*",
+				"--reverse-proxy=true",
+				"--skip-provider-button=true",
+			},
+			Env: []corev1.EnvVar{
+				{Name: "OAUTH2_PROXY_CLIENT_ID", ValueFrom: secretRef("client-id")},
+				{Name: "OAUTH2_PROXY_CLIENT_SECRET", ValueFrom: secretRef("client-secret")},
+				{Name: "OAUTH2_PROXY_COOKIE_SECRET", ValueFrom: secretRef("cookie-secret")},
+			},
+			Ports: []corev1.ContainerPort{
+				{
+					Name:          "http-auth",
+					ContainerPort: 4180,
+					Protocol:      corev1.ProtocolTCP,
+				},
+			},
+			ReadinessProbe: &corev1.Probe{
+				Handler: corev1.Handler{
+					HTTPGet: &corev1.HTTPGetAction{
+						Path: "/ping",
+						Port: intstr.FromString("http-auth"),
+					},
+				},
+			},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("local_stand_in", func(t *testing.T) {
+		cfg := configFixture()
+		cfg.IssuerURL = "http://oidc-stand-in.test-ns:8080"
+		cfg.InsecureSkipVerify = pointer.BoolPtr(true)
+		cfg.ExtraArgs = []string{"--email-domain=example.com"}
+
+		actual, err := NewContainer(ProxyInfo{
+			Config:         cfg,
+			UpstreamPort:   8080,
+			SkipAuthRoutes: []string{"^/json/$"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"--http-address=0.0.0.0:4180",
+			"--upstream=http://127.0.0.1:8080/",
+			"--provider=oidc",
+			"--oidc-issuer-url=http://oidc-stand-in.test-ns:8080",
+			"--email-domain=*",
+			"--reverse-proxy=true",
+			"--skip-provider-button=true",
+			"--skip-auth-route=^/json/$",
+			"--ssl-insecure-skip-verify=true",
+			"--insecure-oidc-skip-issuer-verification=true",
+			"--cookie-secure=false",
+			"--email-domain=example.com",
+		}, actual.Args)
+	})
+
+	t.Run("invalid_image", func(t *testing.T) {
+		cfg := configFixture()
+		cfg.Image = &dcv1alpha1.OCIImageDefinition{}
+
+		_, err := NewContainer(ProxyInfo{Config: cfg})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,374 @@
+package expose
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	istioapi "istio.io/api/networking/v1beta1"
+	istio "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const (
+	defaultPathPrefix = "/"
+	anyHost           = "*"
+	httpPort          = 80
+	httpsPort         = 443
+)
+
+// DefaultGatewaySelector selects the default Istio ingress gateway pods.
+var DefaultGatewaySelector = map[string]string{"istio": "ingressgateway"}
+
+// Route maps an exposed endpoint to a port on a cluster service.
+type Route struct {
+	Name     string
+	Endpoint *dcv1alpha1.ExposeEndpoint
+	Service  string
+	Port     int32
+}
+
+// Info defines fields used to generate objects that expose cluster endpoints.
+type Info struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	Config    *dcv1alpha1.ExposeConfig
+	Routes    []Route
+}
+
+// Uses returns true when cluster endpoints are exposed with the given type.
+func Uses(cfg *dcv1alpha1.ExposeConfig, typ dcv1alpha1.ExposeType) bool {
+	return cfg != nil && cfg.Type == typ
+}
+
+// ServiceType returns the type of the head service given the expose config.
+// The fallback is returned when the service is not exposed directly.
+func ServiceType(cfg *dcv1alpha1.ExposeConfig, fallback corev1.ServiceType) corev1.ServiceType {
+	switch {
+	case Uses(cfg, dcv1alpha1.ExposeTypeLoadBalancer):
+		return corev1.ServiceTypeLoadBalancer
+	case Uses(cfg, dcv1alpha1.ExposeTypeNodePort):
+		return corev1.ServiceTypeNodePort
+	default:
+		return fallback
+	}
+}
+
+// ServiceAnnotations returns the annotations added to the head service when
+// it is exposed directly.
+func ServiceAnnotations(cfg *dcv1alpha1.ExposeConfig) map[string]string {
+	if !Uses(cfg, dcv1alpha1.ExposeTypeLoadBalancer) && !Uses(cfg, dcv1alpha1.ExposeTypeNodePort) {
+		return nil
+	}
+
+	return cfg.Annotations
+}
+
+// DashboardPeers returns the network policy peers that route external traffic
+// to the head dashboard port. No peers are returned when the dashboard is not
+// exposed.
+func DashboardPeers(cfg *dcv1alpha1.ExposeConfig) []networkingv1.NetworkPolicyPeer {
+	if cfg == nil {
+		return nil
+	}
+
+	return peers(cfg, cfg.Dashboard)
+}
+
+// ClientPeers returns the network policy peers that route external traffic to
+// the head client port. No peers are returned when the client port is not
+// exposed.
+func ClientPeers(cfg *dcv1alpha1.ExposeConfig) []networkingv1.NetworkPolicyPeer {
+	if cfg == nil {
+		return nil
+	}
+
+	return peers(cfg, cfg.Client)
+}
+
+// peers returns the configured exposure peers. Services exposed directly
+// serve every head port while ingresses and gateways only route to the ports
+// of their endpoints.
+func peers(cfg *dcv1alpha1.ExposeConfig, ep *dcv1alpha1.ExposeEndpoint) []networkingv1.NetworkPolicyPeer {
+	if ep == nil && !Uses(cfg, dcv1alpha1.ExposeTypeLoadBalancer) && !Uses(cfg, dcv1alpha1.ExposeTypeNodePort) {
+		return nil
+	}
+
+	var result []networkingv1.NetworkPolicyPeer
+	if len(cfg.PodLabels) > 0 || len(cfg.NamespaceLabels) > 0 {
+		peer := networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{MatchLabels: cfg.PodLabels},
+		}
+		if len(cfg.NamespaceLabels) > 0 {
+			peer.NamespaceSelector = &metav1.LabelSelector{MatchLabels: cfg.NamespaceLabels}
+		}
+		result = append(result, peer)
+	}
+	for _, cidr := range cfg.CIDRs {
+		result = append(result, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+
+	return result
+}
+
+// PathPrefix returns the path prefix routed to an endpoint.
+func PathPrefix(ep *dcv1alpha1.ExposeEndpoint) string {
+	if ep.PathPrefix == "" {
+		return defaultPathPrefix
+	}
+
+	return ep.PathPrefix
+}
+
+// NewIngress generates an ingress with a rule for every route.
+func NewIngress(info *Info) *networkingv1.Ingress {
+	prefix := networkingv1.PathTypePrefix
+
+	var rules []networkingv1.IngressRule
+	index := map[string]int{}
+	for _, route := range info.Routes {
+		host := route.Endpoint.Host
+
+		idx, ok := index[host]
+		if !ok {
+			idx = len(rules)
+			index[host] = idx
+			rules = append(rules, networkingv1.IngressRule{
+				Host: host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{},
+				},
+			})
+		}
+
+		http := rules[idx].HTTP
+		http.Paths = append(http.Paths, networkingv1.HTTPIngressPath{
+			Path:     PathPrefix(route.Endpoint),
+			PathType: &prefix,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: route.Service,
+					Port: networkingv1.ServiceBackendPort{Number: route.Port},
+				},
+			},
+		})
+	}
+
+	var tls []networkingv1.IngressTLS
+	if info.Config.TLSSecretName != "" {
+		tls = append(tls, networkingv1.IngressTLS{
+			Hosts:      namedHosts(info.Routes),
+			SecretName: info.Config.TLSSecretName,
+		})
+	}
+
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        info.Name,
+			Namespace:   info.Namespace,
+			Labels:      info.Labels,
+			Annotations: info.Config.Annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: info.Config.IngressClassName,
+			TLS:              tls,
+			Rules:            rules,
+		},
+	}
+}
+
+// NewGateway generates an Istio gateway that accepts traffic for the hosts of
+// every route. HTTPS is served when a TLS secret is provided.
+func NewGateway(info *Info) *istio.Gateway {
+	selector := info.Config.GatewaySelector
+	if len(selector) == 0 {
+		selector = DefaultGatewaySelector
+	}
+
+	server := &istioapi.Server{
+		Hosts: gatewayHosts(info.Routes),
+		Port: &istioapi.Port{
+			Number:   httpPort,
+			Name:     "http",
+			Protocol: "HTTP",
+		},
+	}
+	if info.Config.TLSSecretName != "" {
+		server.Port = &istioapi.Port{
+			Number:   httpsPort,
+			Name:     "https",
+			Protocol: "HTTPS",
+		}
+		server.Tls = &istioapi.ServerTLSSettings{
+			Mode:           istioapi.ServerTLSSettings_SIMPLE,
+			CredentialName: info.Config.TLSSecretName,
+		}
+	}
+
+	return &istio.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        info.Name,
+			Namespace:   info.Namespace,
+			Labels:      info.Labels,
+			Annotations: info.Config.Annotations,
+		},
+		Spec: istioapi.Gateway{
+			Selector: selector,
+			Servers:  []*istioapi.Server{server},
+		},
+	}
+}
+
+// NewVirtualService generates an Istio virtual service bound to the gateway
+// created by NewGateway that routes requests to cluster services.
+func NewVirtualService(info *Info) *istio.VirtualService {
+	var routes []*istioapi.HTTPRoute
+	for _, route := range info.Routes {
+		match := &istioapi.HTTPMatchRequest{
+			Uri: &istioapi.StringMatch{
+				MatchType: &istioapi.StringMatch_Prefix{Prefix: PathPrefix(route.Endpoint)},
+			},
+		}
+		if route.Endpoint.Host != "" {
+			match.Authority = &istioapi.StringMatch{
+				MatchType: &istioapi.StringMatch_Exact{Exact: route.Endpoint.Host},
+			}
+		}
+
+		routes = append(routes, &istioapi.HTTPRoute{
+			Name:  route.Name,
+			Match: []*istioapi.HTTPMatchRequest{match},
+			Route: []*istioapi.HTTPRouteDestination{
+				{
+					Destination: &istioapi.Destination{
+						Host: fmt.Sprintf("%s.%s.svc.cluster.local", route.Service, info.Namespace),
+						Port: &istioapi.PortSelector{Number: uint32(route.Port)},
+					},
+				},
+			},
+		})
+	}
+
+	return &istio.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        info.Name,
+			Namespace:   info.Namespace,
+			Labels:      info.Labels,
+			Annotations: info.Config.Annotations,
+		},
+		Spec: istioapi.VirtualService{
+			Hosts:    gatewayHosts(info.Routes),
+			Gateways: []string{info.Name},
+			Http:     routes,
+		},
+	}
+}
+
+// LoadBalancerAddress returns the first hostname or IP address assigned to a
+// load balancer. An empty string is returned when none has been assigned.
+func LoadBalancerAddress(ingress []corev1.LoadBalancerIngress) string {
+	for _, lb := range ingress {
+		if lb.Hostname != "" {
+			return lb.Hostname
+		}
+		if lb.IP != "" {
+			return lb.IP
+		}
+	}
+
+	return ""
+}
+
+// NodePorts maps the ports of a service to the node ports allocated to them.
+func NodePorts(svc *corev1.Service) map[int32]int32 {
+	ports := map[int32]int32{}
+	for _, sp := range svc.Spec.Ports {
+		if sp.NodePort != 0 {
+			ports[sp.Port] = sp.NodePort
+		}
+	}
+
+	return ports
+}
+
+// ExternalPort returns the port on which a port of the head service is
+// reached when the service is exposed directly. Zero is returned when a node
+// port has not been allocated.
+func ExternalPort(cfg *dcv1alpha1.ExposeConfig, port int32, nodePorts map[int32]int32) int32 {
+	if Uses(cfg, dcv1alpha1.ExposeTypeNodePort) {
+		return nodePorts[port]
+	}
+
+	return port
+}
+
+// HTTPURL returns the external URL of an endpoint routed by an ingress or
+// gateway. The load balancer address is used when the endpoint has no host.
+func HTTPURL(cfg *dcv1alpha1.ExposeConfig, ep *dcv1alpha1.ExposeEndpoint, address string) string {
+	host := ep.Host
+	if host == "" {
+		host = address
+	}
+	if host == "" {
+		return ""
+	}
+
+	scheme := "http"
+	if cfg.TLSSecretName != "" {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, host, PathPrefix(ep))
+}
+
+// HostPort returns the external host and port of an endpoint routed by an
+// ingress or gateway for clients that do not use URL paths.
+func HostPort(cfg *dcv1alpha1.ExposeConfig, ep *dcv1alpha1.ExposeEndpoint, address string) string {
+	host := ep.Host
+	if host == "" {
+		host = address
+	}
+	if host == "" {
+		return ""
+	}
+
+	port := httpPort
+	if cfg.TLSSecretName != "" {
+		port = httpsPort
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// namedHosts returns the unique, non-empty hosts of all routes.
+func namedHosts(routes []Route) []string {
+	var hosts []string
+	seen := map[string]bool{}
+	for _, route := range routes {
+		if host := route.Endpoint.Host; host != "" && !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+
+	return hosts
+}
+
+// gatewayHosts returns the hosts served by a gateway. Any host is accepted
+// when one of the routes does not specify a host.
+func gatewayHosts(routes []Route) []string {
+	for _, route := range routes {
+		if route.Endpoint.Host == "" {
+			return []string{anyHost}
+		}
+	}
+
+	return namedHosts(routes)
+}
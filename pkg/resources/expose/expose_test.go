@@ -0,0 +1,306 @@
+package expose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	istioapi "istio.io/api/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func testInfo(cfg *dcv1alpha1.ExposeConfig) *Info {
+	return &Info{
+		Name:      "test-id-ray",
+		Namespace: "fake-ns",
+		Labels:    map[string]string{"awesome": "true"},
+		Config:    cfg,
+		Routes: []Route{
+			{
+				Name:     "dashboard",
+				Endpoint: cfg.Dashboard,
+				Service:  "test-id-ray-client",
+				Port:     8265,
+			},
+			{
+				Name:     "client",
+				Endpoint: cfg.Client,
+				Service:  "test-id-ray-client",
+				Port:     10001,
+			},
+		},
+	}
+}
+
+func TestServiceType(t *testing.T) {
+	assert.Equal(t, corev1.ServiceTypeClusterIP, ServiceType(nil, corev1.ServiceTypeClusterIP))
+	assert.Equal(t, corev1.ServiceType(""), ServiceType(&dcv1alpha1.ExposeConfig{Type: dcv1alpha1.ExposeTypeIngress}, ""))
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, ServiceType(&dcv1alpha1.ExposeConfig{Type: dcv1alpha1.ExposeTypeLoadBalancer}, ""))
+	assert.Equal(t, corev1.ServiceTypeNodePort, ServiceType(&dcv1alpha1.ExposeConfig{Type: dcv1alpha1.ExposeTypeNodePort}, ""))
+}
+
+func TestServiceAnnotations(t *testing.T) {
+	annotations := map[string]string{"awesome": "true"}
+
+	assert.Nil(t, ServiceAnnotations(nil))
+	assert.Nil(t, ServiceAnnotations(&dcv1alpha1.ExposeConfig{Type: dcv1alpha1.ExposeTypeIngress, Annotations: annotations}))
+	assert.Equal(t, annotations, ServiceAnnotations(&dcv1alpha1.ExposeConfig{
+		Type:        dcv1alpha1.ExposeTypeLoadBalancer,
+		Annotations: annotations,
+	}))
+}
+
+func TestPeers(t *testing.T) {
+	assert.Nil(t, DashboardPeers(nil))
+	assert.Nil(t, ClientPeers(nil))
+
+	cfg := &dcv1alpha1.ExposeConfig{
+		Type:            dcv1alpha1.ExposeTypeIngress,
+		Dashboard:       &dcv1alpha1.ExposeEndpoint{Host: "ray.example.com"},
+		NamespaceLabels: map[string]string{"ingress": "true"},
+		CIDRs:           []string{"10.0.0.0/8"},
+	}
+	expected := []networkingv1.NetworkPolicyPeer{
+		{
+			PodSelector:       &metav1.LabelSelector{},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"ingress": "true"}},
+		},
+		{
+			IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"},
+		},
+	}
+	assert.Equal(t, expected, DashboardPeers(cfg))
+	assert.Nil(t, ClientPeers(cfg), "endpoints that are not routed should not admit exposure peers")
+
+	cfg = &dcv1alpha1.ExposeConfig{
+		Type:      dcv1alpha1.ExposeTypeLoadBalancer,
+		PodLabels: map[string]string{"app": "proxy"},
+	}
+	expected = []networkingv1.NetworkPolicyPeer{
+		{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "proxy"}}},
+	}
+	assert.Equal(t, expected, DashboardPeers(cfg))
+	assert.Equal(t, expected, ClientPeers(cfg))
+}
+
+func TestNewIngress(t *testing.T) {
+	cfg := &dcv1alpha1.ExposeConfig{
+		Type:             dcv1alpha1.ExposeTypeIngress,
+		Dashboard:        &dcv1alpha1.ExposeEndpoint{Host: "ray.example.com", PathPrefix: "/dashboard"},
+		Client:           &dcv1alpha1.ExposeEndpoint{Host: "client.example.com"},
+		TLSSecretName:    "ray-tls",
+		IngressClassName: pointer.StringPtr("nginx"),
+		Annotations:      map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "GRPC"},
+	}
+	prefix := networkingv1.PathTypePrefix
+
+	actual := NewIngress(testInfo(cfg))
+
+	assert.Equal(t, "test-id-ray", actual.Name)
+	assert.Equal(t, "fake-ns", actual.Namespace)
+	assert.Equal(t, map[string]string{"awesome": "true"}, actual.Labels)
+	assert.Equal(t, cfg.Annotations, actual.Annotations)
+	assert.Equal(t, pointer.StringPtr("nginx"), actual.Spec.IngressClassName)
+	assert.Equal(t, []networkingv1.IngressTLS{
+		{Hosts: []string{"ray.example.com", "client.example.com"}, SecretName: "ray-tls"},
+	}, actual.Spec.TLS)
+
+	backend := func(port int32) networkingv1.IngressBackend {
+		return networkingv1.IngressBackend{
+			Service: &networkingv1.IngressServiceBackend{
+				Name: "test-id-ray-client",
+				Port: networkingv1.ServiceBackendPort{Number: port},
+			},
+		}
+	}
+	expected := []networkingv1.IngressRule{
+		{
+			Host: "ray.example.com",
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/dashboard", PathType: &prefix, Backend: backend(8265)},
+					},
+				},
+			},
+		},
+		{
+			Host: "client.example.com",
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/", PathType: &prefix, Backend: backend(10001)},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual.Spec.Rules)
+
+	t.Run("shared_host", func(t *testing.T) {
+		cfg := &dcv1alpha1.ExposeConfig{
+			Type:      dcv1alpha1.ExposeTypeIngress,
+			Dashboard: &dcv1alpha1.ExposeEndpoint{PathPrefix: "/dashboard"},
+			Client:    &dcv1alpha1.ExposeEndpoint{},
+		}
+
+		actual := NewIngress(testInfo(cfg))
+
+		assert.Nil(t, actual.Spec.TLS)
+		if assert.Len(t, actual.Spec.Rules, 1) {
+			assert.Empty(t, actual.Spec.Rules[0].Host)
+			assert.Len(t, actual.Spec.Rules[0].HTTP.Paths, 2)
+		}
+	})
+}
+
+func TestNewGateway(t *testing.T) {
+	cfg := &dcv1alpha1.ExposeConfig{
+		Type:      dcv1alpha1.ExposeTypeIstioGateway,
+		Dashboard: &dcv1alpha1.ExposeEndpoint{Host: "ray.example.com"},
+		Client:    &dcv1alpha1.ExposeEndpoint{Host: "ray.example.com"},
+	}
+
+	t.Run("http", func(t *testing.T) {
+		actual := NewGateway(testInfo(cfg))
+
+		assert.Equal(t, "test-id-ray", actual.Name)
+		assert.Equal(t, DefaultGatewaySelector, actual.Spec.Selector)
+		assert.Equal(t, []*istioapi.Server{
+			{
+				Hosts: []string{"ray.example.com"},
+				Port:  &istioapi.Port{Number: 80, Name: "http", Protocol: "HTTP"},
+			},
+		}, actual.Spec.Servers)
+	})
+
+	t.Run("https", func(t *testing.T) {
+		cfg := cfg.DeepCopy()
+		cfg.TLSSecretName = "ray-tls"
+		cfg.GatewaySelector = map[string]string{"app": "private-gateway"}
+		cfg.Client.Host = ""
+
+		actual := NewGateway(testInfo(cfg))
+
+		assert.Equal(t, map[string]string{"app": "private-gateway"}, actual.Spec.Selector)
+		assert.Equal(t, []*istioapi.Server{
+			{
+				Hosts: []string{"*"},
+				Port:  &istioapi.Port{Number: 443, Name: "https", Protocol: "HTTPS"},
+				Tls: &istioapi.ServerTLSSettings{
+					Mode:           istioapi.ServerTLSSettings_SIMPLE,
+					CredentialName: "ray-tls",
+				},
+			},
+		}, actual.Spec.Servers)
+	})
+}
+
+func TestNewVirtualService(t *testing.T) {
+	cfg := &dcv1alpha1.ExposeConfig{
+		Type:      dcv1alpha1.ExposeTypeIstioGateway,
+		Dashboard: &dcv1alpha1.ExposeEndpoint{Host: "ray.example.com", PathPrefix: "/dashboard"},
+		Client:    &dcv1alpha1.ExposeEndpoint{},
+	}
+
+	actual := NewVirtualService(testInfo(cfg))
+
+	assert.Equal(t, "test-id-ray", actual.Name)
+	assert.Equal(t, []string{"*"}, actual.Spec.Hosts)
+	assert.Equal(t, []string{"test-id-ray"}, actual.Spec.Gateways)
+
+	expected := []*istioapi.HTTPRoute{
+		{
+			Name: "dashboard",
+			Match: []*istioapi.HTTPMatchRequest{
+				{
+					Uri:       &istioapi.StringMatch{MatchType: &istioapi.StringMatch_Prefix{Prefix: "/dashboard"}},
+					Authority: &istioapi.StringMatch{MatchType: &istioapi.StringMatch_Exact{Exact: "ray.example.com"}},
+				},
+			},
+			Route: []*istioapi.HTTPRouteDestination{
+				{
+					Destination: &istioapi.Destination{
+						Host: "test-id-ray-client.fake-ns.svc.cluster.local",
+						Port: &istioapi.PortSelector{Number: 8265},
+					},
+				},
+			},
+		},
+		{
+			Name: "client",
+			Match: []*istioapi.HTTPMatchRequest{
+				{
+					Uri: &istioapi.StringMatch{MatchType: &istioapi.StringMatch_Prefix{Prefix: "/"}},
+				},
+			},
+			Route: []*istioapi.HTTPRouteDestination{
+				{
+					Destination: &istioapi.Destination{
+						Host: "test-id-ray-client.fake-ns.svc.cluster.local",
+						Port: &istioapi.PortSelector{Number: 10001},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual.Spec.Http)
+}
+
+func TestLoadBalancerAddress(t *testing.T) {
+	assert.Empty(t, LoadBalancerAddress(nil))
+	assert.Equal(t, "10.0.0.1", LoadBalancerAddress([]corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}))
+	assert.Equal(t, "lb.example.com", LoadBalancerAddress([]corev1.LoadBalancerIngress{
+		{IP: "10.0.0.1", Hostname: "lb.example.com"},
+	}))
+}
+
+func TestHTTPURL(t *testing.T) {
+	cfg := &dcv1alpha1.ExposeConfig{}
+	ep := &dcv1alpha1.ExposeEndpoint{Host: "ray.example.com", PathPrefix: "/dashboard"}
+
+	assert.Equal(t, "http://ray.example.com/dashboard", HTTPURL(cfg, ep, "10.0.0.1"))
+	assert.Equal(t, "http://10.0.0.1/", HTTPURL(cfg, &dcv1alpha1.ExposeEndpoint{}, "10.0.0.1"))
+	assert.Empty(t, HTTPURL(cfg, &dcv1alpha1.ExposeEndpoint{}, ""))
+
+	cfg.TLSSecretName = "tls"
+	assert.Equal(t, "https://ray.example.com/dashboard", HTTPURL(cfg, ep, ""))
+}
+
+func TestHostPort(t *testing.T) {
+	cfg := &dcv1alpha1.ExposeConfig{}
+	ep := &dcv1alpha1.ExposeEndpoint{Host: "ray.example.com"}
+
+	assert.Equal(t, "ray.example.com:80", HostPort(cfg, ep, ""))
+	assert.Equal(t, "10.0.0.1:80", HostPort(cfg, &dcv1alpha1.ExposeEndpoint{}, "10.0.0.1"))
+	assert.Empty(t, HostPort(cfg, &dcv1alpha1.ExposeEndpoint{}, ""))
+
+	cfg.TLSSecretName = "tls"
+	assert.Equal(t, "ray.example.com:443", HostPort(cfg, ep, ""))
+}
+
+func TestNodePorts(t *testing.T) {
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Port: 8265, NodePort: 30265},
+				{Port: 10001},
+			},
+		},
+	}
+	assert.Equal(t, map[int32]int32{8265: 30265}, NodePorts(svc))
+}
+
+func TestExternalPort(t *testing.T) {
+	nodePorts := map[int32]int32{8265: 30265}
+
+	assert.Equal(t, int32(8265), ExternalPort(&dcv1alpha1.ExposeConfig{Type: dcv1alpha1.ExposeTypeLoadBalancer}, 8265, nodePorts))
+
+	cfg := &dcv1alpha1.ExposeConfig{Type: dcv1alpha1.ExposeTypeNodePort}
+	assert.Equal(t, int32(30265), ExternalPort(cfg, 8265, nodePorts))
+	assert.Zero(t, ExternalPort(cfg, 10001, nodePorts))
+}
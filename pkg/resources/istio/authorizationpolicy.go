@@ -0,0 +1,113 @@
+package istio
+
+import (
+	"fmt"
+	"strconv"
+
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	"istio.io/api/type/v1beta1"
+	istio "istio.io/client-go/pkg/apis/security/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AuthorizedPort defines the sources granted access to a single port.
+type AuthorizedPort struct {
+	Port       int32
+	Principals []string
+	Namespaces []string
+	IPBlocks   []string
+}
+
+// AuthzPolicyInfo defines fields used to generate Istio AuthorizationPolicy objects.
+type AuthzPolicyInfo struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	Selector  map[string]string
+	// Principal identifies the workloads that are granted access to all ports.
+	Principal string
+	Ports     []AuthorizedPort
+}
+
+// NewAuthorizationPolicy uses AuthzPolicyInfo to generate and return a new
+// AuthorizationPolicy object that only allows traffic between the selected
+// workloads and from the sources authorized for each port.
+func NewAuthorizationPolicy(info *AuthzPolicyInfo) *istio.AuthorizationPolicy {
+	rules := []*securityv1beta1.Rule{
+		{
+			From: []*securityv1beta1.Rule_From{
+				{
+					Source: &securityv1beta1.Source{
+						Principals: []string{info.Principal},
+					},
+				},
+			},
+		},
+	}
+
+	for _, ap := range info.Ports {
+		var from []*securityv1beta1.Rule_From
+		if len(ap.Principals) > 0 || len(ap.Namespaces) > 0 {
+			from = append(from, &securityv1beta1.Rule_From{
+				Source: &securityv1beta1.Source{
+					Principals: ap.Principals,
+					Namespaces: ap.Namespaces,
+				},
+			})
+		}
+		if len(ap.IPBlocks) > 0 {
+			from = append(from, &securityv1beta1.Rule_From{
+				Source: &securityv1beta1.Source{
+					IpBlocks: ap.IPBlocks,
+				},
+			})
+		}
+		if from == nil {
+			continue
+		}
+
+		rules = append(rules, &securityv1beta1.Rule{
+			From: from,
+			To: []*securityv1beta1.Rule_To{
+				{
+					Operation: &securityv1beta1.Operation{
+						Ports: []string{strconv.Itoa(int(ap.Port))},
+					},
+				},
+			},
+		})
+	}
+
+	return &istio.AuthorizationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.Name,
+			Namespace: info.Namespace,
+			Labels:    info.Labels,
+		},
+		Spec: securityv1beta1.AuthorizationPolicy{
+			Selector: &v1beta1.WorkloadSelector{
+				MatchLabels: info.Selector,
+			},
+			Action: securityv1beta1.AuthorizationPolicy_ALLOW,
+			Rules:  rules,
+		},
+	}
+}
+
+// MetricsPort returns the authorization that admits Prometheus scrapes of the
+// given port. Workloads in the cluster namespace are admitted when no
+// principals are provided.
+func MetricsPort(port int32, principals []string, namespace string) AuthorizedPort {
+	ap := AuthorizedPort{Port: port, Principals: principals}
+	if len(principals) == 0 {
+		ap.Namespaces = []string{namespace}
+	}
+
+	return ap
+}
+
+// ServiceAccountPrincipal returns the peer identity of workloads running as
+// the given service account within any trust domain.
+func ServiceAccountPrincipal(namespace, serviceAccount string) string {
+	return fmt.Sprintf("*/ns/%s/sa/%s", namespace, serviceAccount)
+}
@@ -0,0 +1,100 @@
+package istio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	"istio.io/api/type/v1beta1"
+	istio "istio.io/client-go/pkg/apis/security/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewAuthorizationPolicy(t *testing.T) {
+	info := &AuthzPolicyInfo{
+		Name:      "cluster",
+		Namespace: "ns",
+		Labels: map[string]string{
+			"awesome": "true",
+		},
+		Selector: map[string]string{
+			"app.kubernetes.io/name": "compute-r",
+		},
+		Principal: "*/ns/ns/sa/cluster",
+		Ports: []AuthorizedPort{
+			{
+				Port:       10001,
+				Principals: []string{"cluster.local/ns/other/sa/client"},
+				IPBlocks:   []string{"10.0.0.0/8"},
+			},
+			{
+				Port:       8265,
+				Namespaces: []string{"ns"},
+			},
+			{
+				Port: 6379,
+			},
+		},
+	}
+	actual := NewAuthorizationPolicy(info)
+
+	expected := &istio.AuthorizationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: "ns",
+			Labels: map[string]string{
+				"awesome": "true",
+			},
+		},
+		Spec: securityv1beta1.AuthorizationPolicy{
+			Selector: &v1beta1.WorkloadSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name": "compute-r",
+				},
+			},
+			Action: securityv1beta1.AuthorizationPolicy_ALLOW,
+			Rules: []*securityv1beta1.Rule{
+				{
+					From: []*securityv1beta1.Rule_From{
+						{Source: &securityv1beta1.Source{Principals: []string{"*/ns/ns/sa/cluster"}}},
+					},
+				},
+				{
+					From: []*securityv1beta1.Rule_From{
+						{Source: &securityv1beta1.Source{Principals: []string{"cluster.local/ns/other/sa/client"}}},
+						{Source: &securityv1beta1.Source{IpBlocks: []string{"10.0.0.0/8"}}},
+					},
+					To: []*securityv1beta1.Rule_To{
+						{Operation: &securityv1beta1.Operation{Ports: []string{"10001"}}},
+					},
+				},
+				{
+					From: []*securityv1beta1.Rule_From{
+						{Source: &securityv1beta1.Source{Namespaces: []string{"ns"}}},
+					},
+					To: []*securityv1beta1.Rule_To{
+						{Operation: &securityv1beta1.Operation{Ports: []string{"8265"}}},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestMetricsPort(t *testing.T) {
+	t.Run("namespace", func(t *testing.T) {
+		expected := AuthorizedPort{Port: 9090, Namespaces: []string{"ns"}}
+		assert.Equal(t, expected, MetricsPort(9090, nil, "ns"))
+	})
+
+	t.Run("principals", func(t *testing.T) {
+		principals := []string{"cluster.local/ns/monitoring/sa/prometheus"}
+		expected := AuthorizedPort{Port: 9090, Principals: principals}
+		assert.Equal(t, expected, MetricsPort(9090, principals, "ns"))
+	})
+}
+
+func TestServiceAccountPrincipal(t *testing.T) {
+	assert.Equal(t, "*/ns/ns/sa/cluster", ServiceAccountPrincipal("ns", "cluster"))
+}
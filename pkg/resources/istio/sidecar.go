@@ -0,0 +1,35 @@
+package istio
+
+import (
+	"strings"
+
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+const (
+	// ExcludeInboundPortsAnnotation lists ports that bypass inbound sidecar interception.
+	ExcludeInboundPortsAnnotation = "traffic.sidecar.istio.io/excludeInboundPorts"
+	// ExcludeOutboundPortsAnnotation lists ports that bypass outbound sidecar interception.
+	ExcludeOutboundPortsAnnotation = "traffic.sidecar.istio.io/excludeOutboundPorts"
+	// ProxyConfigAnnotation overrides the mesh proxy configuration for a pod.
+	ProxyConfigAnnotation = "proxy.istio.io/config"
+
+	holdApplicationConfig = "holdApplicationUntilProxyStarts: true"
+)
+
+// SidecarAnnotations returns pod annotations that hold application containers
+// until the sidecar proxy is ready and exclude the provided ports from inbound
+// and outbound traffic interception.
+func SidecarAnnotations(excludedPorts []int32) map[string]string {
+	annotations := map[string]string{
+		ProxyConfigAnnotation: holdApplicationConfig,
+	}
+
+	if len(excludedPorts) > 0 {
+		ports := strings.Join(util.IntsToStrings(excludedPorts), ",")
+		annotations[ExcludeInboundPortsAnnotation] = ports
+		annotations[ExcludeOutboundPortsAnnotation] = ports
+	}
+
+	return annotations
+}
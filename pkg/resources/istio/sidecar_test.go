@@ -0,0 +1,25 @@
+package istio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSidecarAnnotations(t *testing.T) {
+	t.Run("no_excluded_ports", func(t *testing.T) {
+		expected := map[string]string{
+			"proxy.istio.io/config": "holdApplicationUntilProxyStarts: true",
+		}
+		assert.Equal(t, expected, SidecarAnnotations(nil))
+	})
+
+	t.Run("excluded_ports", func(t *testing.T) {
+		expected := map[string]string{
+			"proxy.istio.io/config":                         "holdApplicationUntilProxyStarts: true",
+			"traffic.sidecar.istio.io/excludeInboundPorts":  "2384,10002,10003",
+			"traffic.sidecar.istio.io/excludeOutboundPorts": "2384,10002,10003",
+		}
+		assert.Equal(t, expected, SidecarAnnotations([]int32{2384, 10002, 10003}))
+	})
+}
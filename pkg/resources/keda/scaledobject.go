@@ -0,0 +1,120 @@
+package keda
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+// ScaledObjectGVK identifies the KEDA ScaledObject kind.
+var ScaledObjectGVK = schema.GroupVersionKind{
+	Group:   "keda.sh",
+	Version: "v1alpha1",
+	Kind:    "ScaledObject",
+}
+
+// ScaledObjectInfo defines fields used to generate KEDA ScaledObject objects.
+type ScaledObjectInfo struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Target      metav1.TypeMeta
+	TargetName  string
+	Autoscaling *dcv1alpha1.Autoscaling
+	Triggers    []dcv1alpha1.KEDATrigger
+}
+
+// NewScaledObject uses ScaledObjectInfo to generate and return a new
+// ScaledObject that targets the scale subresource of a custom resource.
+//
+// The object is built as unstructured content so that the operator does not
+// require the KEDA API types to be compiled in.
+func NewScaledObject(info *ScaledObjectInfo) *unstructured.Unstructured {
+	as := info.Autoscaling
+
+	spec := map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": info.Target.APIVersion,
+			"kind":       info.Target.Kind,
+			"name":       info.TargetName,
+		},
+		"maxReplicaCount": int64(as.MaxReplicas),
+		"triggers":        triggers(info.Triggers),
+	}
+	if as.MinReplicas != nil {
+		spec["minReplicaCount"] = int64(*as.MinReplicas)
+	}
+	if cfg := as.KEDA; cfg != nil {
+		if cfg.PollingInterval != nil {
+			spec["pollingInterval"] = int64(*cfg.PollingInterval)
+		}
+		if cfg.CooldownPeriod != nil {
+			spec["cooldownPeriod"] = int64(*cfg.CooldownPeriod)
+		}
+	}
+	behavior := map[string]interface{}{}
+	if as.ScaleDownStabilizationWindowSeconds != nil {
+		behavior["scaleDown"] = map[string]interface{}{
+			"stabilizationWindowSeconds": int64(*as.ScaleDownStabilizationWindowSeconds),
+		}
+	}
+	if as.ScaleUpStabilizationWindowSeconds != nil {
+		behavior["scaleUp"] = map[string]interface{}{
+			"stabilizationWindowSeconds": int64(*as.ScaleUpStabilizationWindowSeconds),
+		}
+	}
+	if len(behavior) > 0 {
+		spec["advanced"] = map[string]interface{}{
+			"horizontalPodAutoscalerConfig": map[string]interface{}{
+				"behavior": behavior,
+			},
+		}
+	}
+
+	obj := NewScaledObjectReference(info.Name, info.Namespace)
+	obj.SetLabels(info.Labels)
+	obj.Object["spec"] = spec
+
+	return obj
+}
+
+// NewScaledObjectReference returns a shallow ScaledObject that can be used to
+// look up or delete an existing object.
+func NewScaledObjectReference(name, namespace string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(ScaledObjectGVK)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	return obj
+}
+
+func triggers(ts []dcv1alpha1.KEDATrigger) []interface{} {
+	var out []interface{}
+
+	for _, t := range ts {
+		metadata := map[string]interface{}{}
+		for k, v := range t.Metadata {
+			metadata[k] = v
+		}
+
+		trigger := map[string]interface{}{
+			"type":     t.Type,
+			"metadata": metadata,
+		}
+		if t.Name != "" {
+			trigger["name"] = t.Name
+		}
+		if t.AuthenticationRef != "" {
+			trigger["authenticationRef"] = map[string]interface{}{
+				"name": t.AuthenticationRef,
+			}
+		}
+
+		out = append(out, trigger)
+	}
+
+	return out
+}
@@ -0,0 +1,83 @@
+package keda
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewScaledObject(t *testing.T) {
+	info := &ScaledObjectInfo{
+		Name:      "cluster",
+		Namespace: "ns",
+		Labels: map[string]string{
+			"awesome": "true",
+		},
+		Target: metav1.TypeMeta{
+			Kind:       "RayCluster",
+			APIVersion: "distributed-compute.dominodatalab.com/v1test1",
+		},
+		TargetName: "test-id",
+		Autoscaling: &dcv1alpha1.Autoscaling{
+			MinReplicas:                         pointer.Int32Ptr(0),
+			MaxReplicas:                         5,
+			ScaleDownStabilizationWindowSeconds: pointer.Int32Ptr(60),
+			KEDA: &dcv1alpha1.KEDAConfig{
+				PollingInterval: pointer.Int32Ptr(15),
+				CooldownPeriod:  pointer.Int32Ptr(300),
+			},
+		},
+		Triggers: []dcv1alpha1.KEDATrigger{
+			{
+				Type:              "prometheus",
+				Name:              "queue",
+				Metadata:          map[string]string{"query": "sum(pending)"},
+				AuthenticationRef: "prom-auth",
+			},
+		},
+	}
+	actual := NewScaledObject(info)
+
+	assert.Equal(t, ScaledObjectGVK, actual.GroupVersionKind())
+	assert.Equal(t, "cluster", actual.GetName())
+	assert.Equal(t, "ns", actual.GetNamespace())
+	assert.Equal(t, map[string]string{"awesome": "true"}, actual.GetLabels())
+
+	expected := map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"apiVersion": "distributed-compute.dominodatalab.com/v1test1",
+			"kind":       "RayCluster",
+			"name":       "test-id",
+		},
+		"minReplicaCount": int64(0),
+		"maxReplicaCount": int64(5),
+		"pollingInterval": int64(15),
+		"cooldownPeriod":  int64(300),
+		"advanced": map[string]interface{}{
+			"horizontalPodAutoscalerConfig": map[string]interface{}{
+				"behavior": map[string]interface{}{
+					"scaleDown": map[string]interface{}{
+						"stabilizationWindowSeconds": int64(60),
+					},
+				},
+			},
+		},
+		"triggers": []interface{}{
+			map[string]interface{}{
+				"type": "prometheus",
+				"name": "queue",
+				"metadata": map[string]interface{}{
+					"query": "sum(pending)",
+				},
+				"authenticationRef": map[string]interface{}{
+					"name": "prom-auth",
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual.Object["spec"])
+}
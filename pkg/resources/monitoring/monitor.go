@@ -0,0 +1,128 @@
+package monitoring
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+// PortName is the name of the service and container ports that publish
+// workload metrics.
+const PortName = "http-metrics"
+
+var (
+	// ServiceMonitorGVK identifies the Prometheus Operator ServiceMonitor kind.
+	ServiceMonitorGVK = schema.GroupVersionKind{
+		Group:   "monitoring.coreos.com",
+		Version: "v1",
+		Kind:    string(dcv1alpha1.MonitorKindServiceMonitor),
+	}
+	// PodMonitorGVK identifies the Prometheus Operator PodMonitor kind.
+	PodMonitorGVK = schema.GroupVersionKind{
+		Group:   "monitoring.coreos.com",
+		Version: "v1",
+		Kind:    string(dcv1alpha1.MonitorKindPodMonitor),
+	}
+)
+
+// Kinds lists the supported scrape object kinds.
+var Kinds = []dcv1alpha1.MonitorKind{
+	dcv1alpha1.MonitorKindServiceMonitor,
+	dcv1alpha1.MonitorKindPodMonitor,
+}
+
+// MonitorInfo defines fields used to generate Prometheus Operator scrape
+// objects.
+type MonitorInfo struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	// Selector matches the services or pods that publish metrics.
+	Selector map[string]string
+	// Port is the name of the service or container port that is scraped.
+	Port string
+	// Path of the metrics endpoint. Prometheus uses "/metrics" when blank.
+	Path   string
+	Config *dcv1alpha1.MonitoringConfig
+}
+
+// Enabled returns true when cluster processes should export metrics.
+func Enabled(cfg *dcv1alpha1.MonitoringConfig) bool {
+	return cfg != nil && util.BoolPtrIsTrue(cfg.Enabled)
+}
+
+// GVK returns the group version kind of the given scrape object kind.
+func GVK(kind dcv1alpha1.MonitorKind) schema.GroupVersionKind {
+	if kind == dcv1alpha1.MonitorKindPodMonitor {
+		return PodMonitorGVK
+	}
+	return ServiceMonitorGVK
+}
+
+// Available returns true when the Prometheus Operator API for the given kind
+// is installed.
+func Available(mapper meta.RESTMapper, kind dcv1alpha1.MonitorKind) bool {
+	gvk := GVK(kind)
+	_, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	return err == nil
+}
+
+// NewMonitor uses MonitorInfo to generate a ServiceMonitor or PodMonitor that
+// scrapes the named port of the selected services or pods in the cluster
+// namespace.
+//
+// The object is built as unstructured content so that the operator does not
+// require the Prometheus Operator API types to be compiled in.
+func NewMonitor(info *MonitorInfo) *unstructured.Unstructured {
+	endpoint := map[string]interface{}{
+		"port": info.Port,
+	}
+	if info.Path != "" {
+		endpoint["path"] = info.Path
+	}
+	if info.Config.Interval != "" {
+		endpoint["interval"] = info.Config.Interval
+	}
+
+	matchLabels := map[string]interface{}{}
+	for k, v := range info.Selector {
+		matchLabels[k] = v
+	}
+
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": []interface{}{info.Namespace},
+		},
+	}
+	if info.Config.Kind == dcv1alpha1.MonitorKindPodMonitor {
+		spec["podMetricsEndpoints"] = []interface{}{endpoint}
+	} else {
+		spec["endpoints"] = []interface{}{endpoint}
+	}
+
+	labels := util.MergeStringMaps(info.Labels, map[string]string{})
+	labels = util.MergeStringMaps(info.Config.Labels, labels)
+
+	obj := NewMonitorReference(info.Name, info.Namespace, info.Config.Kind)
+	obj.SetLabels(labels)
+	obj.Object["spec"] = spec
+
+	return obj
+}
+
+// NewMonitorReference returns a shallow scrape object of the given kind that
+// can be used to look up or delete an existing object.
+func NewMonitorReference(name, namespace string, kind dcv1alpha1.MonitorKind) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(GVK(kind))
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	return obj
+}
@@ -0,0 +1,88 @@
+package monitoring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestEnabled(t *testing.T) {
+	assert.False(t, Enabled(nil))
+	assert.False(t, Enabled(&dcv1alpha1.MonitoringConfig{}))
+	assert.False(t, Enabled(&dcv1alpha1.MonitoringConfig{Enabled: pointer.BoolPtr(false)}))
+	assert.True(t, Enabled(&dcv1alpha1.MonitoringConfig{Enabled: pointer.BoolPtr(true)}))
+}
+
+func TestNewMonitor(t *testing.T) {
+	info := func(kind dcv1alpha1.MonitorKind) *MonitorInfo {
+		return &MonitorInfo{
+			Name:      "test-metrics",
+			Namespace: "test-ns",
+			Labels:    map[string]string{"app": "test"},
+			Selector:  map[string]string{"instance": "test"},
+			Port:      "http-metrics",
+			Path:      "/metrics/prometheus",
+			Config: &dcv1alpha1.MonitoringConfig{
+				Enabled:  pointer.BoolPtr(true),
+				Kind:     kind,
+				Interval: "30s",
+				Labels:   map[string]string{"release": "prometheus"},
+			},
+		}
+	}
+	expected := func(kind, endpointsKey string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      "test-metrics",
+				"namespace": "test-ns",
+				"labels": map[string]interface{}{
+					"app":     "test",
+					"release": "prometheus",
+				},
+			},
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{
+						"instance": "test",
+					},
+				},
+				"namespaceSelector": map[string]interface{}{
+					"matchNames": []interface{}{"test-ns"},
+				},
+				endpointsKey: []interface{}{
+					map[string]interface{}{
+						"port":     "http-metrics",
+						"path":     "/metrics/prometheus",
+						"interval": "30s",
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("service_monitor", func(t *testing.T) {
+		actual := NewMonitor(info(dcv1alpha1.MonitorKindServiceMonitor))
+		assert.Equal(t, expected("ServiceMonitor", "endpoints"), actual.Object)
+	})
+
+	t.Run("pod_monitor", func(t *testing.T) {
+		actual := NewMonitor(info(dcv1alpha1.MonitorKindPodMonitor))
+		assert.Equal(t, expected("PodMonitor", "podMetricsEndpoints"), actual.Object)
+	})
+}
+
+func TestAvailable(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{ServiceMonitorGVK.GroupVersion()})
+	assert.False(t, Available(mapper, dcv1alpha1.MonitorKindServiceMonitor))
+
+	mapper.Add(ServiceMonitorGVK, meta.RESTScopeNamespace)
+	assert.True(t, Available(mapper, dcv1alpha1.MonitorKindServiceMonitor))
+	assert.False(t, Available(mapper, dcv1alpha1.MonitorKindPodMonitor))
+}
@@ -0,0 +1,122 @@
+package netpol
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const dnsPort = 53
+
+// NamespaceNameLabelKey is set by the API server on every namespace to the
+// name of the namespace (Kubernetes 1.21+).
+const NamespaceNameLabelKey = "kubernetes.io/metadata.name"
+
+// Peers returns the peers granted access to a port. Pod labels are scoped to
+// the cluster namespace unless namespace labels are provided, in which case
+// matching pods in matching namespaces are selected. Each CIDR is rendered as
+// an additional ipBlock peer.
+func Peers(podLabels, namespaceLabels map[string]string, cidrs []string) []networkingv1.NetworkPolicyPeer {
+	peer := networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: podLabels,
+		},
+	}
+	if namespaceLabels != nil {
+		peer.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: namespaceLabels,
+		}
+	}
+
+	peers := []networkingv1.NetworkPolicyPeer{peer}
+	for _, cidr := range cidrs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+
+	return peers
+}
+
+// NamespacePeers returns peers that select every pod in the named
+// namespaces. Empty names are ignored.
+func NamespacePeers(namespaces ...string) []networkingv1.NetworkPolicyPeer {
+	var peers []networkingv1.NetworkPolicyPeer
+	for _, ns := range namespaces {
+		if ns == "" {
+			continue
+		}
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{NamespaceNameLabelKey: ns},
+			},
+		})
+	}
+
+	return peers
+}
+
+// EgressEnabled returns true when the egress configuration restricts
+// outbound traffic.
+func EgressEnabled(cfg *dcv1alpha1.NetworkPolicyEgress) bool {
+	return cfg != nil && cfg.Enabled != nil && *cfg.Enabled
+}
+
+// EgressRules returns the rules permitting outbound traffic to other cluster
+// nodes, DNS servers, configured CIDRs and any custom destinations.
+func EgressRules(cfg *dcv1alpha1.NetworkPolicyEgress, cluster metav1.LabelSelector) []networkingv1.NetworkPolicyEgressRule {
+	rules := []networkingv1.NetworkPolicyEgressRule{
+		{
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &cluster,
+				},
+			},
+		},
+	}
+
+	if cfg.AllowDNS == nil || *cfg.AllowDNS {
+		udp := corev1.ProtocolUDP
+		tcp := corev1.ProtocolTCP
+		port := intstr.FromInt(dnsPort)
+
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: &udp, Port: &port},
+				{Protocol: &tcp, Port: &port},
+			},
+		})
+	}
+
+	if len(cfg.CIDRs) > 0 {
+		var to []networkingv1.NetworkPolicyPeer
+		for _, cidr := range cfg.CIDRs {
+			to = append(to, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+			})
+		}
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{To: to})
+	}
+
+	return append(rules, cfg.Rules...)
+}
+
+// PortRange returns TCP ports spanning start and the subsequent number of
+// retry ports that a process may bind to when start is unavailable.
+func PortRange(start, retries int32) []networkingv1.NetworkPolicyPort {
+	proto := corev1.ProtocolTCP
+
+	var ports []networkingv1.NetworkPolicyPort
+	for p := start; p <= start+retries; p++ {
+		port := intstr.FromInt(int(p))
+		ports = append(ports, networkingv1.NetworkPolicyPort{
+			Protocol: &proto,
+			Port:     &port,
+		})
+	}
+
+	return ports
+}
@@ -0,0 +1,160 @@
+package netpol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestPeers(t *testing.T) {
+	podLabels := map[string]string{"client": "true"}
+
+	t.Run("pod_labels", func(t *testing.T) {
+		actual := Peers(podLabels, nil, nil)
+
+		expected := []networkingv1.NetworkPolicyPeer{
+			{
+				PodSelector: &metav1.LabelSelector{MatchLabels: podLabels},
+			},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("namespace_labels", func(t *testing.T) {
+		nsLabels := map[string]string{"team": "ml"}
+		actual := Peers(podLabels, nsLabels, nil)
+
+		expected := []networkingv1.NetworkPolicyPeer{
+			{
+				PodSelector:       &metav1.LabelSelector{MatchLabels: podLabels},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: nsLabels},
+			},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("cidrs", func(t *testing.T) {
+		actual := Peers(podLabels, nil, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+		expected := []networkingv1.NetworkPolicyPeer{
+			{
+				PodSelector: &metav1.LabelSelector{MatchLabels: podLabels},
+			},
+			{
+				IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"},
+			},
+			{
+				IPBlock: &networkingv1.IPBlock{CIDR: "192.168.1.0/24"},
+			},
+		}
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func TestNamespacePeers(t *testing.T) {
+	assert.Nil(t, NamespacePeers())
+	assert.Nil(t, NamespacePeers(""))
+
+	expected := []networkingv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": "dco"},
+			},
+		},
+		{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": "keda"},
+			},
+		},
+	}
+	assert.Equal(t, expected, NamespacePeers("dco", "", "keda"))
+}
+
+func TestEgressEnabled(t *testing.T) {
+	assert.False(t, EgressEnabled(nil))
+	assert.False(t, EgressEnabled(&dcv1alpha1.NetworkPolicyEgress{}))
+	assert.False(t, EgressEnabled(&dcv1alpha1.NetworkPolicyEgress{Enabled: pointer.BoolPtr(false)}))
+	assert.True(t, EgressEnabled(&dcv1alpha1.NetworkPolicyEgress{Enabled: pointer.BoolPtr(true)}))
+}
+
+func TestEgressRules(t *testing.T) {
+	cluster := metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": "test"},
+	}
+	clusterRule := networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{
+			{PodSelector: &cluster},
+		},
+	}
+
+	udp := corev1.ProtocolUDP
+	tcp := corev1.ProtocolTCP
+	dns := intstr.FromInt(53)
+	dnsRule := networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: &udp, Port: &dns},
+			{Protocol: &tcp, Port: &dns},
+		},
+	}
+
+	t.Run("default", func(t *testing.T) {
+		actual := EgressRules(&dcv1alpha1.NetworkPolicyEgress{}, cluster)
+		assert.Equal(t, []networkingv1.NetworkPolicyEgressRule{clusterRule, dnsRule}, actual)
+	})
+
+	t.Run("dns_disabled", func(t *testing.T) {
+		actual := EgressRules(&dcv1alpha1.NetworkPolicyEgress{AllowDNS: pointer.BoolPtr(false)}, cluster)
+		assert.Equal(t, []networkingv1.NetworkPolicyEgressRule{clusterRule}, actual)
+	})
+
+	t.Run("cidrs_and_rules", func(t *testing.T) {
+		https := intstr.FromInt(443)
+		custom := networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: &tcp, Port: &https},
+			},
+		}
+		cfg := &dcv1alpha1.NetworkPolicyEgress{
+			AllowDNS: pointer.BoolPtr(false),
+			CIDRs:    []string{"52.216.0.0/15"},
+			Rules:    []networkingv1.NetworkPolicyEgressRule{custom},
+		}
+		actual := EgressRules(cfg, cluster)
+
+		expected := []networkingv1.NetworkPolicyEgressRule{
+			clusterRule,
+			{
+				To: []networkingv1.NetworkPolicyPeer{
+					{IPBlock: &networkingv1.IPBlock{CIDR: "52.216.0.0/15"}},
+				},
+			},
+			custom,
+		}
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func TestPortRange(t *testing.T) {
+	tcp := corev1.ProtocolTCP
+	port := func(p int) *intstr.IntOrString {
+		val := intstr.FromInt(p)
+		return &val
+	}
+
+	assert.Equal(t, []networkingv1.NetworkPolicyPort{
+		{Protocol: &tcp, Port: port(40000)},
+	}, PortRange(40000, 0))
+
+	assert.Equal(t, []networkingv1.NetworkPolicyPort{
+		{Protocol: &tcp, Port: port(40000)},
+		{Protocol: &tcp, Port: port(40001)},
+		{Protocol: &tcp, Port: port(40002)},
+	}, PortRange(40000, 2))
+}
@@ -0,0 +1,68 @@
+package pdb
+
+import (
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	// GroupKind identifies the PodDisruptionBudget kind across API versions.
+	GroupKind = schema.GroupKind{Group: "policy", Kind: "PodDisruptionBudget"}
+	// V1GVK identifies the policy/v1 PodDisruptionBudget kind.
+	V1GVK = GroupKind.WithVersion("v1")
+)
+
+// V1Available returns true when the API server serves policy/v1
+// PodDisruptionBudget objects.
+func V1Available(mapper meta.RESTMapper) bool {
+	_, err := mapper.RESTMapping(GroupKind, V1GVK.Version)
+	return err == nil
+}
+
+// ForAPIVersion returns the provided budget when v1 is false. Otherwise, the
+// budget is converted into an equivalent policy/v1 object.
+//
+// The v1 object is built as unstructured content because the spec is
+// identical across versions and the compiled API types only include v1beta1.
+func ForAPIVersion(pdb *policyv1beta1.PodDisruptionBudget, v1 bool) (client.Object, error) {
+	if !v1 {
+		return pdb, nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pdb)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetGroupVersionKind(V1GVK)
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	return obj, nil
+}
+
+// NewReference returns a shallow budget that can be used to look up or delete
+// an existing object using the appropriate API version.
+func NewReference(name, namespace string, v1 bool) client.Object {
+	if !v1 {
+		return &policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(V1GVK)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	return obj
+}
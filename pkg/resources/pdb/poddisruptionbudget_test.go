@@ -0,0 +1,114 @@
+package pdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func budgetFixture() *policyv1beta1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt(0)
+
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-id-ray-head",
+			Namespace: "fake-ns",
+			Labels: map[string]string{
+				"app.kubernetes.io/name": "ray",
+			},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/component": "head",
+				},
+			},
+		},
+	}
+}
+
+func TestForAPIVersion(t *testing.T) {
+	t.Run("v1beta1", func(t *testing.T) {
+		budget := budgetFixture()
+
+		actual, err := ForAPIVersion(budget, false)
+		require.NoError(t, err)
+
+		assert.Same(t, budget, actual)
+	})
+
+	t.Run("v1", func(t *testing.T) {
+		actual, err := ForAPIVersion(budgetFixture(), true)
+		require.NoError(t, err)
+
+		obj, ok := actual.(*unstructured.Unstructured)
+		require.True(t, ok)
+
+		expected := map[string]interface{}{
+			"apiVersion": "policy/v1",
+			"kind":       "PodDisruptionBudget",
+			"metadata": map[string]interface{}{
+				"name":      "test-id-ray-head",
+				"namespace": "fake-ns",
+				"labels": map[string]interface{}{
+					"app.kubernetes.io/name": "ray",
+				},
+			},
+			"spec": map[string]interface{}{
+				"maxUnavailable": int64(0),
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{
+						"app.kubernetes.io/component": "head",
+					},
+				},
+			},
+		}
+		assert.Equal(t, expected, obj.Object)
+	})
+}
+
+func TestNewReference(t *testing.T) {
+	t.Run("v1beta1", func(t *testing.T) {
+		ref := NewReference("name", "ns", false)
+
+		assert.IsType(t, &policyv1beta1.PodDisruptionBudget{}, ref)
+		assert.Equal(t, "name", ref.GetName())
+		assert.Equal(t, "ns", ref.GetNamespace())
+	})
+
+	t.Run("v1", func(t *testing.T) {
+		ref := NewReference("name", "ns", true)
+
+		assert.Equal(t, V1GVK, ref.GetObjectKind().GroupVersionKind())
+		assert.Equal(t, "name", ref.GetName())
+		assert.Equal(t, "ns", ref.GetNamespace())
+	})
+}
+
+func TestV1Available(t *testing.T) {
+	v1beta1 := schema.GroupVersion{Group: "policy", Version: "v1beta1"}
+	v1 := schema.GroupVersion{Group: "policy", Version: "v1"}
+
+	t.Run("served", func(t *testing.T) {
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{v1, v1beta1})
+		mapper.Add(v1.WithKind("PodDisruptionBudget"), meta.RESTScopeNamespace)
+		mapper.Add(v1beta1.WithKind("PodDisruptionBudget"), meta.RESTScopeNamespace)
+
+		assert.True(t, V1Available(mapper))
+	})
+
+	t.Run("not_served", func(t *testing.T) {
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{v1beta1})
+		mapper.Add(v1beta1.WithKind("PodDisruptionBudget"), meta.RESTScopeNamespace)
+
+		assert.False(t, V1Available(mapper))
+	})
+}
@@ -0,0 +1,173 @@
+package podgroup
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const (
+	// SchedulerPluginsLabelKey is the pod label used by the coscheduling
+	// plugin to associate pods with a pod group.
+	SchedulerPluginsLabelKey = "pod-group.scheduling.sigs.k8s.io"
+	// VolcanoAnnotationKey is the pod annotation used by volcano to associate
+	// pods with a pod group.
+	VolcanoAnnotationKey = "scheduling.k8s.io/group-name"
+
+	volcanoSchedulerName = "volcano"
+)
+
+var (
+	// SchedulerPluginsGVK identifies the scheduler-plugins PodGroup kind.
+	SchedulerPluginsGVK = schema.GroupVersionKind{
+		Group:   "scheduling.sigs.k8s.io",
+		Version: "v1alpha1",
+		Kind:    "PodGroup",
+	}
+	// VolcanoGVK identifies the volcano PodGroup kind.
+	VolcanoGVK = schema.GroupVersionKind{
+		Group:   "scheduling.volcano.sh",
+		Version: "v1beta1",
+		Kind:    "PodGroup",
+	}
+
+	// Flavors lists every supported pod group flavor.
+	Flavors = []dcv1alpha1.PodGroupFlavor{
+		dcv1alpha1.PodGroupFlavorSchedulerPlugins,
+		dcv1alpha1.PodGroupFlavorVolcano,
+	}
+)
+
+// PodGroupInfo defines fields used to generate PodGroup objects.
+type PodGroupInfo struct {
+	Name       string
+	Namespace  string
+	Labels     map[string]string
+	MinMember  int32
+	Scheduling *dcv1alpha1.SchedulingConfig
+}
+
+// Enabled returns true when gang scheduling has been requested.
+func Enabled(cfg *dcv1alpha1.SchedulingConfig) bool {
+	return cfg != nil && cfg.Gang != nil && cfg.Gang.Enabled != nil && *cfg.Gang.Enabled
+}
+
+// Flavor returns the pod group flavor selected in the config.
+func Flavor(cfg *dcv1alpha1.SchedulingConfig) dcv1alpha1.PodGroupFlavor {
+	if cfg == nil || cfg.Gang == nil || cfg.Gang.Flavor == "" {
+		return dcv1alpha1.PodGroupFlavorSchedulerPlugins
+	}
+
+	return cfg.Gang.Flavor
+}
+
+// GroupVersionKind returns the PodGroup kind used by a flavor.
+func GroupVersionKind(flavor dcv1alpha1.PodGroupFlavor) schema.GroupVersionKind {
+	if flavor == dcv1alpha1.PodGroupFlavorVolcano {
+		return VolcanoGVK
+	}
+
+	return SchedulerPluginsGVK
+}
+
+// Available returns true when the API server serves the PodGroup kind used by
+// a flavor.
+func Available(mapper meta.RESTMapper, flavor dcv1alpha1.PodGroupFlavor) bool {
+	gvk := GroupVersionKind(flavor)
+	_, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+
+	return err == nil
+}
+
+// NewPodGroup uses PodGroupInfo to generate and return a new PodGroup of the
+// configured flavor.
+//
+// The object is built as unstructured content so that the operator does not
+// require the scheduler API types to be compiled in.
+func NewPodGroup(info *PodGroupInfo) *unstructured.Unstructured {
+	cfg := info.Scheduling
+	flavor := Flavor(cfg)
+
+	spec := map[string]interface{}{
+		"minMember": int64(info.MinMember),
+	}
+
+	switch flavor {
+	case dcv1alpha1.PodGroupFlavorVolcano:
+		if cfg.Gang.Queue != "" {
+			spec["queue"] = cfg.Gang.Queue
+		}
+		if cfg.PriorityClassName != "" {
+			spec["priorityClassName"] = cfg.PriorityClassName
+		}
+	default:
+		if cfg.Gang.ScheduleTimeoutSeconds != nil {
+			spec["scheduleTimeoutSeconds"] = int64(*cfg.Gang.ScheduleTimeoutSeconds)
+		}
+	}
+
+	obj := NewPodGroupReference(info.Name, info.Namespace, flavor)
+	obj.SetLabels(info.Labels)
+	obj.Object["spec"] = spec
+
+	return obj
+}
+
+// NewPodGroupReference returns a shallow PodGroup that can be used to look up
+// or delete an existing object.
+func NewPodGroupReference(name, namespace string, flavor dcv1alpha1.PodGroupFlavor) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(GroupVersionKind(flavor))
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	return obj
+}
+
+// PodMetadata returns copies of the provided pod labels and annotations that
+// associate pods with the named pod group. The originals are returned
+// unmodified when gang scheduling is disabled.
+func PodMetadata(name string, cfg *dcv1alpha1.SchedulingConfig, labels, annotations map[string]string) (map[string]string, map[string]string) {
+	if !Enabled(cfg) {
+		return labels, annotations
+	}
+
+	if Flavor(cfg) == dcv1alpha1.PodGroupFlavorVolcano {
+		return labels, withEntry(annotations, VolcanoAnnotationKey, name)
+	}
+
+	return withEntry(labels, SchedulerPluginsLabelKey, name), annotations
+}
+
+// SchedulerName returns the scheduler that should dispatch cluster pods.
+func SchedulerName(cfg *dcv1alpha1.SchedulingConfig) string {
+	if cfg == nil {
+		return ""
+	}
+	if cfg.SchedulerName == "" && Enabled(cfg) && Flavor(cfg) == dcv1alpha1.PodGroupFlavorVolcano {
+		return volcanoSchedulerName
+	}
+
+	return cfg.SchedulerName
+}
+
+// PriorityClassName returns the priority class assigned to cluster pods.
+func PriorityClassName(cfg *dcv1alpha1.SchedulingConfig) string {
+	if cfg == nil {
+		return ""
+	}
+
+	return cfg.PriorityClassName
+}
+
+func withEntry(m map[string]string, key, value string) map[string]string {
+	out := make(map[string]string, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	out[key] = value
+
+	return out
+}
@@ -0,0 +1,111 @@
+package podgroup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func gangConfig(flavor dcv1alpha1.PodGroupFlavor) *dcv1alpha1.SchedulingConfig {
+	return &dcv1alpha1.SchedulingConfig{
+		PriorityClassName: "high-priority",
+		Gang: &dcv1alpha1.GangSchedulingConfig{
+			Enabled:                pointer.BoolPtr(true),
+			Flavor:                 flavor,
+			ScheduleTimeoutSeconds: pointer.Int32Ptr(60),
+			Queue:                  "research",
+		},
+	}
+}
+
+func TestNewPodGroup(t *testing.T) {
+	t.Run("scheduler_plugins", func(t *testing.T) {
+		actual := NewPodGroup(&PodGroupInfo{
+			Name:       "test-id-ray",
+			Namespace:  "fake-ns",
+			Labels:     map[string]string{"awesome": "true"},
+			MinMember:  3,
+			Scheduling: gangConfig(""),
+		})
+
+		assert.Equal(t, SchedulerPluginsGVK, actual.GroupVersionKind())
+		assert.Equal(t, "test-id-ray", actual.GetName())
+		assert.Equal(t, "fake-ns", actual.GetNamespace())
+		assert.Equal(t, map[string]string{"awesome": "true"}, actual.GetLabels())
+
+		expected := map[string]interface{}{
+			"minMember":              int64(3),
+			"scheduleTimeoutSeconds": int64(60),
+		}
+		assert.Equal(t, expected, actual.Object["spec"])
+	})
+
+	t.Run("volcano", func(t *testing.T) {
+		actual := NewPodGroup(&PodGroupInfo{
+			Name:       "test-id-ray",
+			Namespace:  "fake-ns",
+			MinMember:  3,
+			Scheduling: gangConfig(dcv1alpha1.PodGroupFlavorVolcano),
+		})
+
+		assert.Equal(t, VolcanoGVK, actual.GroupVersionKind())
+
+		expected := map[string]interface{}{
+			"minMember":         int64(3),
+			"queue":             "research",
+			"priorityClassName": "high-priority",
+		}
+		assert.Equal(t, expected, actual.Object["spec"])
+	})
+}
+
+func TestPodMetadata(t *testing.T) {
+	labels := map[string]string{"app": "ray"}
+	annotations := map[string]string{"note": "value"}
+
+	t.Run("disabled", func(t *testing.T) {
+		l, a := PodMetadata("group", &dcv1alpha1.SchedulingConfig{}, labels, annotations)
+
+		assert.Equal(t, labels, l)
+		assert.Equal(t, annotations, a)
+	})
+
+	t.Run("scheduler_plugins", func(t *testing.T) {
+		l, a := PodMetadata("group", gangConfig(""), labels, annotations)
+
+		assert.Equal(t, map[string]string{"app": "ray", SchedulerPluginsLabelKey: "group"}, l)
+		assert.Equal(t, annotations, a)
+		assert.NotContains(t, labels, SchedulerPluginsLabelKey, "input labels were modified")
+	})
+
+	t.Run("volcano", func(t *testing.T) {
+		l, a := PodMetadata("group", gangConfig(dcv1alpha1.PodGroupFlavorVolcano), labels, nil)
+
+		assert.Equal(t, labels, l)
+		assert.Equal(t, map[string]string{VolcanoAnnotationKey: "group"}, a)
+	})
+}
+
+func TestSchedulerName(t *testing.T) {
+	assert.Equal(t, "", SchedulerName(nil))
+	assert.Equal(t, "", SchedulerName(gangConfig("")))
+	assert.Equal(t, "volcano", SchedulerName(gangConfig(dcv1alpha1.PodGroupFlavorVolcano)))
+
+	cfg := gangConfig(dcv1alpha1.PodGroupFlavorVolcano)
+	cfg.SchedulerName = "custom"
+	assert.Equal(t, "custom", SchedulerName(cfg))
+}
+
+func TestAvailable(t *testing.T) {
+	gv := SchedulerPluginsGVK.GroupVersion()
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+	mapper.Add(SchedulerPluginsGVK, meta.RESTScopeNamespace)
+
+	assert.True(t, Available(mapper, dcv1alpha1.PodGroupFlavorSchedulerPlugins))
+	assert.False(t, Available(mapper, dcv1alpha1.PodGroupFlavorVolcano))
+}
@@ -0,0 +1,33 @@
+package ray
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashauth"
+)
+
+// DashboardTargetPort returns the head pod port that receives dashboard
+// traffic. This is the authenticating proxy port when dashboard auth is
+// enabled.
+func DashboardTargetPort(rc *dcv1alpha1.RayCluster) int32 {
+	if dashauth.Enabled(rc.Spec.DashboardAuth) {
+		return rc.Spec.DashboardAuth.Port
+	}
+
+	return rc.Spec.DashboardPort
+}
+
+// addDashboardAuth appends the authenticating proxy sidecar to the head pod.
+func addDashboardAuth(rc *dcv1alpha1.RayCluster, spec *corev1.PodSpec) error {
+	proxy, err := dashauth.NewContainer(dashauth.ProxyInfo{
+		Config:       rc.Spec.DashboardAuth,
+		UpstreamPort: rc.Spec.DashboardPort,
+	})
+	if err != nil {
+		return err
+	}
+	spec.Containers = append(spec.Containers, proxy)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func dashboardAuthClusterFixture() *dcv1alpha1.RayCluster {
+	rc := rayClusterFixture()
+	rc.Spec.EnableDashboard = pointer.BoolPtr(true)
+	rc.Spec.DashboardAuth = &dcv1alpha1.DashboardAuthConfig{
+		Enabled: pointer.BoolPtr(true),
+		Image: &dcv1alpha1.OCIImageDefinition{
+			Registry:   "quay.io",
+			Repository: "oauth2-proxy/oauth2-proxy",
+			Tag:        "v7.1.3",
+		},
+		Port:            4180,
+		IssuerURL:       "https://accounts.example.com",
+		ClientSecretRef: &corev1.LocalObjectReference{Name: "oidc-client"},
+	}
+
+	return rc
+}
+
+func TestDashboardTargetPort(t *testing.T) {
+	rc := rayClusterFixture()
+	assert.Equal(t, int32(8265), DashboardTargetPort(rc))
+
+	rc = dashboardAuthClusterFixture()
+	assert.Equal(t, int32(4180), DashboardTargetPort(rc))
+}
+
+func TestStatefulSetDashboardAuth(t *testing.T) {
+	rc := dashboardAuthClusterFixture()
+
+	head, err := NewStatefulSet(rc, ComponentHead, testDefaults.Ray)
+	require.NoError(t, err)
+
+	containers := head.Spec.Template.Spec.Containers
+	require.Len(t, containers, 2)
+	assert.Contains(t, containers[0].Args, "--dashboard-host=127.0.0.1")
+	assert.Equal(t, "dashboard-auth", containers[1].Name)
+	assert.Contains(t, containers[1].Args, "--upstream=http://127.0.0.1:8265/")
+
+	worker, err := NewStatefulSet(rc, ComponentWorker, testDefaults.Ray)
+	require.NoError(t, err)
+	assert.Len(t, worker.Spec.Template.Spec.Containers, 1)
+}
@@ -0,0 +1,108 @@
+package ray
+
+import (
+	"fmt"
+
+	istio "istio.io/client-go/pkg/apis/networking/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/expose"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+// NewIngress generates an ingress that routes external traffic to the
+// dashboard and client server endpoints of the head node.
+func NewIngress(rc *dcv1alpha1.RayCluster) *networkingv1.Ingress {
+	return expose.NewIngress(exposeInfo(rc))
+}
+
+// NewGateway generates an Istio gateway that accepts external traffic for
+// the dashboard and client server endpoints of the head node.
+func NewGateway(rc *dcv1alpha1.RayCluster) *istio.Gateway {
+	return expose.NewGateway(exposeInfo(rc))
+}
+
+// NewVirtualService generates an Istio virtual service that routes gateway
+// traffic to the dashboard and client server endpoints of the head node.
+func NewVirtualService(rc *dcv1alpha1.RayCluster) *istio.VirtualService {
+	return expose.NewVirtualService(exposeInfo(rc))
+}
+
+// ExposeObjectMeta returns the metadata shared by all of the objects used to
+// route external traffic into the cluster.
+func ExposeObjectMeta(rc *dcv1alpha1.RayCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      InstanceObjectName(rc.Name, ComponentNone),
+		Namespace: rc.Namespace,
+	}
+}
+
+// ExposedURLs returns the external dashboard and client addresses of the
+// cluster. The address assigned to the ingress or load balancer service is
+// used when an endpoint does not have a host name. Node port services are
+// reached on the given node address and the allocated node ports.
+func ExposedURLs(rc *dcv1alpha1.RayCluster, address string, nodePorts map[int32]int32) (dashboard, client string) {
+	cfg := rc.Spec.Expose
+	if cfg == nil {
+		return "", ""
+	}
+	dashboardEnabled := util.BoolPtrIsTrue(rc.Spec.EnableDashboard)
+
+	switch cfg.Type {
+	case dcv1alpha1.ExposeTypeIngress, dcv1alpha1.ExposeTypeIstioGateway:
+		if cfg.Dashboard != nil && dashboardEnabled {
+			dashboard = expose.HTTPURL(cfg, cfg.Dashboard, address)
+		}
+		if cfg.Client != nil {
+			if hostPort := expose.HostPort(cfg, cfg.Client, address); hostPort != "" {
+				client = "ray://" + hostPort
+			}
+		}
+	case dcv1alpha1.ExposeTypeLoadBalancer, dcv1alpha1.ExposeTypeNodePort:
+		if address == "" {
+			return "", ""
+		}
+		if port := expose.ExternalPort(cfg, rc.Spec.DashboardPort, nodePorts); dashboardEnabled && port != 0 {
+			dashboard = fmt.Sprintf("http://%s:%d", address, port)
+		}
+		if port := expose.ExternalPort(cfg, rc.Spec.ClientServerPort, nodePorts); port != 0 {
+			client = fmt.Sprintf("ray://%s:%d", address, port)
+		}
+	}
+
+	return dashboard, client
+}
+
+func exposeInfo(rc *dcv1alpha1.RayCluster) *expose.Info {
+	cfg := rc.Spec.Expose
+	svc := ClientServiceName(rc.Name)
+
+	var routes []expose.Route
+	if cfg.Dashboard != nil && util.BoolPtrIsTrue(rc.Spec.EnableDashboard) {
+		routes = append(routes, expose.Route{
+			Name:     "dashboard",
+			Endpoint: cfg.Dashboard,
+			Service:  svc,
+			Port:     rc.Spec.DashboardPort,
+		})
+	}
+	if cfg.Client != nil {
+		routes = append(routes, expose.Route{
+			Name:     "client",
+			Endpoint: cfg.Client,
+			Service:  svc,
+			Port:     rc.Spec.ClientServerPort,
+		})
+	}
+
+	meta := ExposeObjectMeta(rc)
+	return &expose.Info{
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+		Labels:    MetadataLabels(rc),
+		Config:    cfg,
+		Routes:    routes,
+	}
+}
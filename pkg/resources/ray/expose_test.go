@@ -0,0 +1,112 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewIngress(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.EnableDashboard = pointer.BoolPtr(true)
+	rc.Spec.Expose = &dcv1alpha1.ExposeConfig{
+		Type:      dcv1alpha1.ExposeTypeIngress,
+		Dashboard: &dcv1alpha1.ExposeEndpoint{Host: "ray.example.com"},
+		Client:    &dcv1alpha1.ExposeEndpoint{Host: "client.example.com"},
+	}
+
+	actual := NewIngress(rc)
+
+	assert.Equal(t, "test-id-ray", actual.Name)
+	assert.Equal(t, "fake-ns", actual.Namespace)
+	assert.Equal(t, MetadataLabels(rc), actual.Labels)
+	if assert.Len(t, actual.Spec.Rules, 2) {
+		dashboard := actual.Spec.Rules[0].HTTP.Paths[0].Backend.Service
+		assert.Equal(t, "test-id-ray-client", dashboard.Name)
+		assert.Equal(t, int32(8265), dashboard.Port.Number)
+
+		client := actual.Spec.Rules[1].HTTP.Paths[0].Backend.Service
+		assert.Equal(t, "test-id-ray-client", client.Name)
+		assert.Equal(t, int32(10001), client.Port.Number)
+	}
+
+	t.Run("dashboard_disabled", func(t *testing.T) {
+		rc.Spec.EnableDashboard = pointer.BoolPtr(false)
+
+		actual := NewIngress(rc)
+
+		if assert.Len(t, actual.Spec.Rules, 1) {
+			assert.Equal(t, "client.example.com", actual.Spec.Rules[0].Host)
+		}
+	})
+}
+
+func TestNewGatewayAndVirtualService(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.EnableDashboard = pointer.BoolPtr(true)
+	rc.Spec.Expose = &dcv1alpha1.ExposeConfig{
+		Type:      dcv1alpha1.ExposeTypeIstioGateway,
+		Dashboard: &dcv1alpha1.ExposeEndpoint{Host: "ray.example.com"},
+	}
+
+	gateway := NewGateway(rc)
+	vs := NewVirtualService(rc)
+
+	assert.Equal(t, "test-id-ray", gateway.Name)
+	assert.Equal(t, "test-id-ray", vs.Name)
+	assert.Equal(t, []string{gateway.Name}, vs.Spec.Gateways)
+	if assert.Len(t, vs.Spec.Http, 1) {
+		assert.Equal(t, "test-id-ray-client.fake-ns.svc.cluster.local", vs.Spec.Http[0].Route[0].Destination.Host)
+	}
+}
+
+func TestExposedURLs(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.EnableDashboard = pointer.BoolPtr(true)
+
+	t.Run("not_exposed", func(t *testing.T) {
+		dashboard, client := ExposedURLs(rc, "10.0.0.1", nil)
+		assert.Empty(t, dashboard)
+		assert.Empty(t, client)
+	})
+
+	t.Run("ingress", func(t *testing.T) {
+		rc.Spec.Expose = &dcv1alpha1.ExposeConfig{
+			Type:          dcv1alpha1.ExposeTypeIngress,
+			Dashboard:     &dcv1alpha1.ExposeEndpoint{Host: "ray.example.com", PathPrefix: "/dashboard"},
+			Client:        &dcv1alpha1.ExposeEndpoint{},
+			TLSSecretName: "ray-tls",
+		}
+
+		dashboard, client := ExposedURLs(rc, "10.0.0.1", nil)
+		assert.Equal(t, "https://ray.example.com/dashboard", dashboard)
+		assert.Equal(t, "ray://10.0.0.1:443", client)
+	})
+
+	t.Run("load_balancer", func(t *testing.T) {
+		rc.Spec.Expose = &dcv1alpha1.ExposeConfig{Type: dcv1alpha1.ExposeTypeLoadBalancer}
+
+		dashboard, client := ExposedURLs(rc, "lb.example.com", nil)
+		assert.Equal(t, "http://lb.example.com:8265", dashboard)
+		assert.Equal(t, "ray://lb.example.com:10001", client)
+
+		dashboard, client = ExposedURLs(rc, "", nil)
+		assert.Empty(t, dashboard)
+		assert.Empty(t, client)
+	})
+
+	t.Run("node_port", func(t *testing.T) {
+		rc.Spec.Expose = &dcv1alpha1.ExposeConfig{Type: dcv1alpha1.ExposeTypeNodePort}
+
+		dashboard, client := ExposedURLs(rc, "10.0.0.1", map[int32]int32{8265: 30265, 10001: 30001})
+		assert.Equal(t, "http://10.0.0.1:30265", dashboard)
+		assert.Equal(t, "ray://10.0.0.1:30001", client)
+
+		dashboard, client = ExposedURLs(rc, "10.0.0.1", nil)
+		assert.Empty(t, dashboard, "node ports not allocated")
+		assert.Empty(t, client, "node ports not allocated")
+	})
+}
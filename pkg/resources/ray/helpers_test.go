@@ -8,6 +8,9 @@ import (
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
 
+// testDefaults are the built-in operator defaults used by resource builders.
+var testDefaults = dcv1alpha1.NewClusterDefaults()
+
 // rayClusterFixture should be used for all ray unit testing.
 func rayClusterFixture() *dcv1alpha1.RayCluster {
 	return &dcv1alpha1.RayCluster{
@@ -22,11 +22,17 @@ func NewHorizontalPodAutoscaler(rc *dcv1alpha1.RayCluster) (*autoscalingv2beta2.
 	}
 
 	var behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior
+	if autoscaling.ScaleDownStabilizationWindowSeconds != nil || autoscaling.ScaleUpStabilizationWindowSeconds != nil {
+		behavior = &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{}
+	}
 	if autoscaling.ScaleDownStabilizationWindowSeconds != nil {
-		behavior = &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{
-			ScaleDown: &autoscalingv2beta2.HPAScalingRules{
-				StabilizationWindowSeconds: autoscaling.ScaleDownStabilizationWindowSeconds,
-			},
+		behavior.ScaleDown = &autoscalingv2beta2.HPAScalingRules{
+			StabilizationWindowSeconds: autoscaling.ScaleDownStabilizationWindowSeconds,
+		}
+	}
+	if autoscaling.ScaleUpStabilizationWindowSeconds != nil {
+		behavior.ScaleUp = &autoscalingv2beta2.HPAScalingRules{
+			StabilizationWindowSeconds: autoscaling.ScaleUpStabilizationWindowSeconds,
 		}
 	}
 
@@ -0,0 +1,62 @@
+package ray
+
+import (
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+)
+
+// NewAuthorizationPolicy generates an Istio authorization policy that mirrors
+// the cluster network policies. Cluster nodes can reach one another on all
+// ports while the head client server and dashboard ports are only reachable
+// by authorized principals and CIDRs. Prometheus is granted access to the
+// metrics port when monitoring is enabled.
+func NewAuthorizationPolicy(rc *dcv1alpha1.RayCluster) *securityv1beta1.AuthorizationPolicy {
+	serviceAccount := InstanceObjectName(rc.Name, ComponentNone)
+	if rc.Spec.ServiceAccountName != "" {
+		serviceAccount = rc.Spec.ServiceAccountName
+	}
+
+	var namespaces []string
+	principals := rc.Spec.IstioConfig.AuthorizedPrincipals
+	if len(principals) == 0 {
+		namespaces = []string{rc.Namespace}
+	}
+
+	ports := []istio.AuthorizedPort{
+		{
+			Port:       rc.Spec.ClientServerPort,
+			Principals: principals,
+			Namespaces: namespaces,
+			IPBlocks:   rc.Spec.NetworkPolicy.ClientServerCIDRs,
+		},
+	}
+	if rc.Spec.EnableDashboard != nil && *rc.Spec.EnableDashboard {
+		ports = append(ports, istio.AuthorizedPort{
+			Port:       DashboardTargetPort(rc),
+			Principals: principals,
+			Namespaces: namespaces,
+			IPBlocks:   rc.Spec.NetworkPolicy.DashboardCIDRs,
+		})
+	}
+	if cfg := rc.Spec.Monitoring; monitoring.Enabled(cfg) {
+		ports = append(ports, istio.MetricsPort(cfg.Port, cfg.IstioPrincipals, rc.Namespace))
+	}
+
+	return istio.NewAuthorizationPolicy(&istio.AuthzPolicyInfo{
+		Name:      InstanceObjectName(rc.Name, ComponentNone),
+		Namespace: rc.Namespace,
+		Labels:    MetadataLabels(rc),
+		Selector:  SelectorLabels(rc),
+		Principal: istio.ServiceAccountPrincipal(rc.Namespace, serviceAccount),
+		Ports:     ports,
+	})
+}
+
+// SidecarExcludedPorts returns the object manager and worker ports that must
+// bypass sidecar interception so that nodes can exchange objects directly.
+func SidecarExcludedPorts(rc *dcv1alpha1.RayCluster) []int32 {
+	return append([]int32{rc.Spec.ObjectManagerPort}, rc.Spec.WorkerPorts...)
+}
@@ -0,0 +1,103 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewAuthorizationPolicy(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		rc := rayClusterFixture()
+		actual := NewAuthorizationPolicy(rc)
+
+		assert.Equal(t, "test-id-ray", actual.Name)
+		assert.Equal(t, "fake-ns", actual.Namespace)
+		assert.Equal(t, map[string]string{
+			"app.kubernetes.io/name":     "ray",
+			"app.kubernetes.io/instance": "test-id",
+		}, actual.Spec.Selector.MatchLabels)
+
+		expected := []*securityv1beta1.Rule{
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Principals: []string{"*/ns/fake-ns/sa/test-id-ray"}}},
+				},
+			},
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Namespaces: []string{"fake-ns"}}},
+				},
+				To: []*securityv1beta1.Rule_To{
+					{Operation: &securityv1beta1.Operation{Ports: []string{"10001"}}},
+				},
+			},
+		}
+		assert.Equal(t, expected, actual.Spec.Rules)
+	})
+
+	t.Run("authorized_principals_and_dashboard", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.ServiceAccountName = "custom"
+		rc.Spec.EnableDashboard = pointer.BoolPtr(true)
+		rc.Spec.IstioConfig.AuthorizedPrincipals = []string{"cluster.local/ns/apps/sa/notebook"}
+		rc.Spec.NetworkPolicy.DashboardCIDRs = []string{"10.0.0.0/8"}
+		actual := NewAuthorizationPolicy(rc)
+
+		expected := []*securityv1beta1.Rule{
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Principals: []string{"*/ns/fake-ns/sa/custom"}}},
+				},
+			},
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Principals: []string{"cluster.local/ns/apps/sa/notebook"}}},
+				},
+				To: []*securityv1beta1.Rule_To{
+					{Operation: &securityv1beta1.Operation{Ports: []string{"10001"}}},
+				},
+			},
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Principals: []string{"cluster.local/ns/apps/sa/notebook"}}},
+					{Source: &securityv1beta1.Source{IpBlocks: []string{"10.0.0.0/8"}}},
+				},
+				To: []*securityv1beta1.Rule_To{
+					{Operation: &securityv1beta1.Operation{Ports: []string{"8265"}}},
+				},
+			},
+		}
+		assert.Equal(t, expected, actual.Spec.Rules)
+	})
+
+	t.Run("monitoring", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.Monitoring = &dcv1alpha1.MonitoringConfig{
+			Enabled:         pointer.BoolPtr(true),
+			Port:            9090,
+			IstioPrincipals: []string{"cluster.local/ns/monitoring/sa/prometheus"},
+		}
+		actual := NewAuthorizationPolicy(rc)
+
+		expected := &securityv1beta1.Rule{
+			From: []*securityv1beta1.Rule_From{
+				{Source: &securityv1beta1.Source{Principals: []string{"cluster.local/ns/monitoring/sa/prometheus"}}},
+			},
+			To: []*securityv1beta1.Rule_To{
+				{Operation: &securityv1beta1.Operation{Ports: []string{"9090"}}},
+			},
+		}
+		assert.Len(t, actual.Spec.Rules, 3)
+		assert.Equal(t, expected, actual.Spec.Rules[2])
+	})
+}
+
+func TestSidecarExcludedPorts(t *testing.T) {
+	rc := rayClusterFixture()
+	assert.Equal(t, []int32{2384, 11000, 11001}, SidecarExcludedPorts(rc))
+}
@@ -0,0 +1,63 @@
+package ray
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+)
+
+// NewMonitor generates a ServiceMonitor or PodMonitor that scrapes the
+// metrics exported by every ray node.
+func NewMonitor(rc *dcv1alpha1.RayCluster) *unstructured.Unstructured {
+	return monitoring.NewMonitor(&monitoring.MonitorInfo{
+		Name:      MonitorName(rc.Name),
+		Namespace: rc.Namespace,
+		Labels:    MetadataLabels(rc),
+		Selector:  SelectorLabels(rc),
+		Port:      monitoring.PortName,
+		Config:    rc.Spec.Monitoring,
+	})
+}
+
+// MonitorName returns the name of the cluster scrape object.
+func MonitorName(name string) string {
+	return InstanceObjectName(name, Component("metrics"))
+}
+
+// MonitorReference returns a shallow scrape object of the given kind that can
+// be used to delete an existing object.
+func MonitorReference(rc *dcv1alpha1.RayCluster, kind dcv1alpha1.MonitorKind) *unstructured.Unstructured {
+	return monitoring.NewMonitorReference(MonitorName(rc.Name), rc.Namespace, kind)
+}
+
+// metricsContainerPorts publishes the metrics export port on ray containers.
+func metricsContainerPorts(rc *dcv1alpha1.RayCluster) []corev1.ContainerPort {
+	if !monitoring.Enabled(rc.Spec.Monitoring) {
+		return nil
+	}
+
+	return []corev1.ContainerPort{
+		{
+			Name:          monitoring.PortName,
+			ContainerPort: rc.Spec.Monitoring.Port,
+		},
+	}
+}
+
+// metricsServicePorts publishes the metrics export port on headless services.
+func metricsServicePorts(rc *dcv1alpha1.RayCluster) []corev1.ServicePort {
+	if !monitoring.Enabled(rc.Spec.Monitoring) {
+		return nil
+	}
+
+	return []corev1.ServicePort{
+		{
+			Name:       monitoring.PortName,
+			Port:       rc.Spec.Monitoring.Port,
+			TargetPort: intstr.FromInt(int(rc.Spec.Monitoring.Port)),
+		},
+	}
+}
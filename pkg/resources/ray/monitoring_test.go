@@ -0,0 +1,89 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func monitoringClusterFixture() *dcv1alpha1.RayCluster {
+	rc := rayClusterFixture()
+	rc.Spec.Monitoring = &dcv1alpha1.MonitoringConfig{
+		Enabled:         pointer.BoolPtr(true),
+		Port:            8080,
+		Kind:            dcv1alpha1.MonitorKindServiceMonitor,
+		NamespaceLabels: map[string]string{"name": "monitoring"},
+	}
+
+	return rc
+}
+
+func TestNewMonitor(t *testing.T) {
+	rc := monitoringClusterFixture()
+
+	actual := NewMonitor(rc)
+	assert.Equal(t, "ServiceMonitor", actual.GetKind())
+	assert.Equal(t, "test-id-ray-metrics", actual.GetName())
+	assert.Equal(t, "fake-ns", actual.GetNamespace())
+
+	endpoints, _, err := unstructured.NestedSlice(actual.Object, "spec", "endpoints")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"port": "http-metrics"}}, endpoints)
+
+	ref := MonitorReference(rc, dcv1alpha1.MonitorKindPodMonitor)
+	assert.Equal(t, "PodMonitor", ref.GetKind())
+	assert.Equal(t, "test-id-ray-metrics", ref.GetName())
+}
+
+func TestMonitoringPorts(t *testing.T) {
+	rc := monitoringClusterFixture()
+
+	for _, comp := range []Component{ComponentHead, ComponentWorker} {
+		sts, err := NewStatefulSet(rc, comp, testDefaults.Ray)
+		require.NoError(t, err)
+
+		container := sts.Spec.Template.Spec.Containers[0]
+		assert.Contains(t, container.Args, "--metrics-export-port=8080")
+		assert.Contains(t, container.Ports, corev1.ContainerPort{Name: "http-metrics", ContainerPort: 8080})
+	}
+
+	expected := corev1.ServicePort{Name: "http-metrics", Port: 8080, TargetPort: intstr.FromInt(8080)}
+	assert.Contains(t, NewHeadlessHeadService(rc).Spec.Ports, expected)
+	assert.Contains(t, NewHeadlessWorkerService(rc).Spec.Ports, expected)
+
+	rc.Spec.Monitoring.Enabled = pointer.BoolPtr(false)
+	assert.NotContains(t, NewHeadlessHeadService(rc).Spec.Ports, expected)
+}
+
+func TestNewMonitoringNetworkPolicy(t *testing.T) {
+	rc := monitoringClusterFixture()
+	actual := NewMonitoringNetworkPolicy(rc)
+
+	proto := corev1.ProtocolTCP
+	port := intstr.FromInt(8080)
+	assert.Equal(t, "test-id-ray-metrics", actual.Name)
+	assert.Equal(t, SelectorLabels(rc), actual.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &proto, Port: &port}},
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector:       &metav1.LabelSelector{},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "monitoring"}},
+				},
+			},
+		},
+	}, actual.Spec.Ingress)
+
+	rc.Spec.Monitoring.NamespaceLabels = nil
+	assert.Empty(t, NewMonitoringNetworkPolicy(rc).Spec.Ingress, "monitoring pods must be selected explicitly")
+}
@@ -8,16 +8,21 @@ import (
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/resources"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/expose"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/netpol"
 )
 
 const (
 	descriptionCluster   = "Allows all ingress traffic between cluster nodes"
 	descriptionClient    = "Allows client ingress traffic to head client server port"
 	descriptionDashboard = "Allows client ingress traffic to head dashboard port"
+	descriptionEgress    = "Restricts egress traffic from cluster nodes"
+	descriptionMetrics   = "Allows monitoring ingress traffic to node metrics ports"
 )
 
 // NewClusterNetworkPolicy generates a network policy that allows all nodes
-// within a single cluster to communicate on all ports.
+// within a single cluster to communicate on all ports. Any additional ingress
+// rules are applied to all cluster nodes.
 func NewClusterNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPolicy {
 	labelSelector := metav1.LabelSelector{
 		MatchLabels: SelectorLabels(rc),
@@ -34,7 +39,7 @@ func NewClusterNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPol
 		},
 		Spec: networkingv1.NetworkPolicySpec{
 			PodSelector: labelSelector,
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
+			Ingress: append([]networkingv1.NetworkPolicyIngressRule{
 				{
 					From: []networkingv1.NetworkPolicyPeer{
 						{
@@ -42,7 +47,7 @@ func NewClusterNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPol
 						},
 					},
 				},
-			},
+			}, rc.Spec.NetworkPolicy.IngressRules...),
 			PolicyTypes: []networkingv1.PolicyType{
 				networkingv1.PolicyTypeIngress,
 			},
@@ -52,12 +57,17 @@ func NewClusterNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPol
 
 // NewHeadClientNetworkPolicy generates a network policy that allows client
 // access to any pods that have been appointed with the configured client
-// server labels.
+// server labels, namespace labels and CIDRs, and to the exposure peers when
+// the client port is exposed.
 func NewHeadClientNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPolicy {
 	return headNetworkPolicy(
 		rc,
 		rc.Spec.ClientServerPort,
-		rc.Spec.NetworkPolicy.ClientServerLabels,
+		append(netpol.Peers(
+			rc.Spec.NetworkPolicy.ClientServerLabels,
+			rc.Spec.NetworkPolicy.ClientServerNamespaceLabels,
+			rc.Spec.NetworkPolicy.ClientServerCIDRs,
+		), expose.ClientPeers(rc.Spec.Expose)...),
 		Component("client"),
 		descriptionClient,
 	)
@@ -65,18 +75,64 @@ func NewHeadClientNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.Network
 
 // NewHeadDashboardNetworkPolicy generates a network policy that allows
 // dashboard access to any pods that have been appointed with configured
-// dashboard labels.
+// dashboard labels, namespace labels and CIDRs, and to the exposure peers
+// when the dashboard is exposed.
 func NewHeadDashboardNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPolicy {
 	return headNetworkPolicy(
 		rc,
-		rc.Spec.DashboardPort,
-		rc.Spec.NetworkPolicy.DashboardLabels,
+		DashboardTargetPort(rc),
+		append(netpol.Peers(
+			rc.Spec.NetworkPolicy.DashboardLabels,
+			rc.Spec.NetworkPolicy.DashboardNamespaceLabels,
+			rc.Spec.NetworkPolicy.DashboardCIDRs,
+		), expose.DashboardPeers(rc.Spec.Expose)...),
 		Component("dashboard"),
 		descriptionDashboard,
 	)
 }
 
-func headNetworkPolicy(rc *dcv1alpha1.RayCluster, p int32, l map[string]string, c Component, desc string) *networkingv1.NetworkPolicy {
+// NewMonitoringNetworkPolicy generates a network policy that allows the
+// configured monitoring pods to scrape the metrics port of all cluster nodes.
+// Nothing is admitted unless monitoring pods are selected.
+func NewMonitoringNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPolicy {
+	var ingress []networkingv1.NetworkPolicyIngressRule
+	if cfg := rc.Spec.Monitoring; cfg != nil && (len(cfg.PodLabels) > 0 || len(cfg.NamespaceLabels) > 0) {
+		ingress = []networkingv1.NetworkPolicyIngressRule{
+			{
+				Ports: netpol.PortRange(cfg.Port, 0),
+				From:  netpol.Peers(cfg.PodLabels, cfg.NamespaceLabels, nil),
+			},
+		}
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      InstanceObjectName(rc.Name, Component("metrics")),
+			Namespace: rc.Namespace,
+			Labels:    MetadataLabels(rc),
+			Annotations: map[string]string{
+				resources.DescriptionAnnotationKey: descriptionMetrics,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: SelectorLabels(rc),
+			},
+			Ingress: ingress,
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+		},
+	}
+}
+
+func headNetworkPolicy(
+	rc *dcv1alpha1.RayCluster,
+	p int32,
+	from []networkingv1.NetworkPolicyPeer,
+	c Component,
+	desc string,
+) *networkingv1.NetworkPolicy {
 	proto := corev1.ProtocolTCP
 	targetPort := intstr.FromInt(int(p))
 
@@ -101,13 +157,7 @@ func headNetworkPolicy(rc *dcv1alpha1.RayCluster, p int32, l map[string]string,
 							Port:     &targetPort,
 						},
 					},
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: l,
-							},
-						},
-					},
+					From: from,
 				},
 			},
 			PolicyTypes: []networkingv1.PolicyType{
@@ -116,3 +166,34 @@ func headNetworkPolicy(rc *dcv1alpha1.RayCluster, p int32, l map[string]string,
 		},
 	}
 }
+
+// NewEgressNetworkPolicy generates a network policy that restricts outbound
+// traffic from all cluster nodes to the configured egress destinations.
+func NewEgressNetworkPolicy(rc *dcv1alpha1.RayCluster) *networkingv1.NetworkPolicy {
+	labelSelector := metav1.LabelSelector{
+		MatchLabels: SelectorLabels(rc),
+	}
+
+	var rules []networkingv1.NetworkPolicyEgressRule
+	if cfg := rc.Spec.NetworkPolicy.Egress; cfg != nil {
+		rules = netpol.EgressRules(cfg, labelSelector)
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      InstanceObjectName(rc.Name, Component("egress")),
+			Namespace: rc.Namespace,
+			Labels:    MetadataLabels(rc),
+			Annotations: map[string]string{
+				resources.DescriptionAnnotationKey: descriptionEgress,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: labelSelector,
+			Egress:      rules,
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeEgress,
+			},
+		},
+	}
+}
@@ -8,6 +8,7 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 
 	"github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
@@ -180,3 +181,134 @@ func TestNewHeadDashboardNetworkPolicy(t *testing.T) {
 	}
 	assert.Equal(t, expected, netpol)
 }
+
+func TestNewClusterNetworkPolicyIngressRules(t *testing.T) {
+	rc := rayClusterFixture()
+	rule := networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{
+			{
+				IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"},
+			},
+		},
+	}
+	rc.Spec.NetworkPolicy.IngressRules = []networkingv1.NetworkPolicyIngressRule{rule}
+
+	netpol := NewClusterNetworkPolicy(rc)
+	assert.Len(t, netpol.Spec.Ingress, 2)
+	assert.Equal(t, rule, netpol.Spec.Ingress[1])
+}
+
+func TestNewHeadClientNetworkPolicyExternalPeers(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.NetworkPolicy = v1alpha1.RayClusterNetworkPolicy{
+		ClientServerLabels:          map[string]string{"server-client": "true"},
+		ClientServerNamespaceLabels: map[string]string{"team": "ml"},
+		ClientServerCIDRs:           []string{"192.168.0.0/16"},
+	}
+	netpol := NewHeadClientNetworkPolicy(rc)
+
+	expected := []networkingv1.NetworkPolicyPeer{
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"server-client": "true"},
+			},
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "ml"},
+			},
+		},
+		{
+			IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.0/16"},
+		},
+	}
+	assert.Equal(t, expected, netpol.Spec.Ingress[0].From)
+}
+
+func TestNewHeadDashboardNetworkPolicyExposurePeers(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.NetworkPolicy = v1alpha1.RayClusterNetworkPolicy{
+		DashboardLabels: map[string]string{"dashboard-client": "true"},
+	}
+	rc.Spec.Expose = &v1alpha1.ExposeConfig{
+		Type:            v1alpha1.ExposeTypeIngress,
+		Dashboard:       &v1alpha1.ExposeEndpoint{Host: "ray.example.com"},
+		NamespaceLabels: map[string]string{"ingress": "true"},
+	}
+	netpol := NewHeadDashboardNetworkPolicy(rc)
+
+	expected := []networkingv1.NetworkPolicyPeer{
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"dashboard-client": "true"},
+			},
+		},
+		{
+			PodSelector: &metav1.LabelSelector{},
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"ingress": "true"},
+			},
+		},
+	}
+	assert.Equal(t, expected, netpol.Spec.Ingress[0].From)
+
+	client := NewHeadClientNetworkPolicy(rc).Spec.Ingress[0].From
+	assert.NotContains(t, client, expected[1], "client policy should not admit peers when the client port is not exposed")
+}
+
+func TestNewEgressNetworkPolicy(t *testing.T) {
+	rc := rayClusterFixture()
+	rc.Spec.NetworkPolicy.Egress = &v1alpha1.NetworkPolicyEgress{
+		Enabled:  pointer.BoolPtr(true),
+		AllowDNS: pointer.BoolPtr(false),
+		CIDRs:    []string{"52.216.0.0/15"},
+	}
+	netpol := NewEgressNetworkPolicy(rc)
+
+	expected := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-id-ray-egress",
+			Namespace: "fake-ns",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "ray",
+				"app.kubernetes.io/instance":   "test-id",
+				"app.kubernetes.io/version":    "fake-tag",
+				"app.kubernetes.io/managed-by": "distributed-compute-operator",
+			},
+			Annotations: map[string]string{
+				"distributed-compute.dominodatalab.com/description": "Restricts egress traffic from cluster nodes",
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":     "ray",
+					"app.kubernetes.io/instance": "test-id",
+				},
+			},
+			Egress: []networkingv1.NetworkPolicyEgressRule{
+				{
+					To: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"app.kubernetes.io/name":     "ray",
+									"app.kubernetes.io/instance": "test-id",
+								},
+							},
+						},
+					},
+				},
+				{
+					To: []networkingv1.NetworkPolicyPeer{
+						{
+							IPBlock: &networkingv1.IPBlock{CIDR: "52.216.0.0/15"},
+						},
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				"Egress",
+			},
+		},
+	}
+	assert.Equal(t, expected, netpol)
+}
@@ -0,0 +1,53 @@
+package ray
+
+import (
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+// NewPodDisruptionBudgets generates disruption budgets for the head and worker
+// pods of a RayCluster. The head budget does not allow any voluntary evictions
+// while the worker budget uses the limits provided in the spec.
+func NewPodDisruptionBudgets(rc *dcv1alpha1.RayCluster) (head, worker *policyv1beta1.PodDisruptionBudget) {
+	headMaxUnavailable := intstr.FromInt(0)
+	head = podDisruptionBudget(rc, ComponentHead)
+	head.Spec.MaxUnavailable = &headMaxUnavailable
+
+	worker = podDisruptionBudget(rc, ComponentWorker)
+	cfg := rc.Spec.PodDisruptionBudget
+	switch {
+	case cfg.MinAvailable != nil:
+		worker.Spec.MinAvailable = cfg.MinAvailable
+	case cfg.MaxUnavailable != nil:
+		worker.Spec.MaxUnavailable = cfg.MaxUnavailable
+	default:
+		workerMaxUnavailable := intstr.FromInt(1)
+		worker.Spec.MaxUnavailable = &workerMaxUnavailable
+	}
+
+	return head, worker
+}
+
+// PodDisruptionBudgetObjectMeta returns the ObjectMeta used to identify the
+// disruption budget for a component.
+func PodDisruptionBudgetObjectMeta(rc *dcv1alpha1.RayCluster, comp Component) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      InstanceObjectName(rc.Name, comp),
+		Namespace: rc.Namespace,
+		Labels:    MetadataLabelsWithComponent(rc, comp),
+	}
+}
+
+func podDisruptionBudget(rc *dcv1alpha1.RayCluster, comp Component) *policyv1beta1.PodDisruptionBudget {
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: PodDisruptionBudgetObjectMeta(rc, comp),
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: SelectorLabelsWithComponent(rc, comp),
+			},
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNewPodDisruptionBudgets(t *testing.T) {
+	t.Run("head", func(t *testing.T) {
+		rc := rayClusterFixture()
+		head, _ := NewPodDisruptionBudgets(rc)
+
+		maxUnavailable := intstr.FromInt(0)
+		expected := &policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-id-ray-head",
+				Namespace: "fake-ns",
+				Labels: map[string]string{
+					"app.kubernetes.io/name":       "ray",
+					"app.kubernetes.io/instance":   "test-id",
+					"app.kubernetes.io/component":  "head",
+					"app.kubernetes.io/version":    "fake-tag",
+					"app.kubernetes.io/managed-by": "distributed-compute-operator",
+				},
+			},
+			Spec: policyv1beta1.PodDisruptionBudgetSpec{
+				MaxUnavailable: &maxUnavailable,
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"app.kubernetes.io/name":      "ray",
+						"app.kubernetes.io/instance":  "test-id",
+						"app.kubernetes.io/component": "head",
+					},
+				},
+			},
+		}
+		assert.Equal(t, expected, head)
+	})
+
+	t.Run("worker_default", func(t *testing.T) {
+		rc := rayClusterFixture()
+		_, worker := NewPodDisruptionBudgets(rc)
+
+		maxUnavailable := intstr.FromInt(1)
+		assert.Equal(t, "test-id-ray-worker", worker.Name)
+		assert.Equal(t, &maxUnavailable, worker.Spec.MaxUnavailable)
+		assert.Nil(t, worker.Spec.MinAvailable)
+	})
+
+	t.Run("worker_min_available", func(t *testing.T) {
+		rc := rayClusterFixture()
+		minAvailable := intstr.FromString("50%")
+		rc.Spec.PodDisruptionBudget.MinAvailable = &minAvailable
+		_, worker := NewPodDisruptionBudgets(rc)
+
+		assert.Equal(t, &minAvailable, worker.Spec.MinAvailable)
+		assert.Nil(t, worker.Spec.MaxUnavailable)
+	})
+
+	t.Run("worker_max_unavailable", func(t *testing.T) {
+		rc := rayClusterFixture()
+		maxUnavailable := intstr.FromInt(2)
+		rc.Spec.PodDisruptionBudget.MaxUnavailable = &maxUnavailable
+		_, worker := NewPodDisruptionBudgets(rc)
+
+		assert.Equal(t, &maxUnavailable, worker.Spec.MaxUnavailable)
+		assert.Nil(t, worker.Spec.MinAvailable)
+	})
+}
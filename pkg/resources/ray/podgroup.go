@@ -0,0 +1,53 @@
+package ray
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+)
+
+// NewPodGroup generates a PodGroup that requires the head and the minimum
+// number of workers of a RayCluster to be scheduled at the same time.
+func NewPodGroup(rc *dcv1alpha1.RayCluster) (*unstructured.Unstructured, error) {
+	if !podgroup.Enabled(rc.Spec.Scheduling) {
+		return nil, fmt.Errorf("cannot build pod group without gang scheduling config")
+	}
+
+	return podgroup.NewPodGroup(&podgroup.PodGroupInfo{
+		Name:       PodGroupName(rc.Name),
+		Namespace:  rc.Namespace,
+		Labels:     MetadataLabels(rc),
+		MinMember:  1 + minWorkerReplicas(rc),
+		Scheduling: rc.Spec.Scheduling,
+	}), nil
+}
+
+// PodGroupReference returns a shallow PodGroup of the given flavor used to
+// identify existing objects.
+func PodGroupReference(rc *dcv1alpha1.RayCluster, flavor dcv1alpha1.PodGroupFlavor) *unstructured.Unstructured {
+	return podgroup.NewPodGroupReference(PodGroupName(rc.Name), rc.Namespace, flavor)
+}
+
+// PodGroupName returns the name of the pod group shared by all cluster pods.
+func PodGroupName(name string) string {
+	return InstanceObjectName(name, ComponentNone)
+}
+
+// minWorkerReplicas returns the lowest number of workers the cluster is
+// expected to run.
+func minWorkerReplicas(rc *dcv1alpha1.RayCluster) int32 {
+	if as := rc.Spec.Autoscaling; as != nil {
+		if as.MinReplicas != nil {
+			return *as.MinReplicas
+		}
+		return 1
+	}
+	if rc.Spec.Worker.Replicas != nil {
+		return *rc.Spec.Worker.Replicas
+	}
+
+	return 0
+}
@@ -0,0 +1,61 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewPodGroup(t *testing.T) {
+	gang := func(rc *dcv1alpha1.RayCluster) {
+		rc.Spec.Scheduling = &dcv1alpha1.SchedulingConfig{
+			Gang: &dcv1alpha1.GangSchedulingConfig{
+				Enabled: pointer.BoolPtr(true),
+			},
+		}
+	}
+
+	t.Run("worker_replicas", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.Worker.Replicas = pointer.Int32Ptr(4)
+		gang(rc)
+
+		pg, err := NewPodGroup(rc)
+		require.NoError(t, err)
+
+		assert.Equal(t, "test-id-ray", pg.GetName())
+		assert.Equal(t, "fake-ns", pg.GetNamespace())
+		assert.Equal(t, MetadataLabels(rc), pg.GetLabels())
+
+		minMember, _, err := unstructured.NestedInt64(pg.Object, "spec", "minMember")
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), minMember)
+	})
+
+	t.Run("autoscaling_min_replicas", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.Worker.Replicas = pointer.Int32Ptr(4)
+		rc.Spec.Autoscaling = &dcv1alpha1.Autoscaling{
+			MinReplicas: pointer.Int32Ptr(2),
+			MaxReplicas: 10,
+		}
+		gang(rc)
+
+		pg, err := NewPodGroup(rc)
+		require.NoError(t, err)
+
+		minMember, _, err := unstructured.NestedInt64(pg.Object, "spec", "minMember")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), minMember)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := NewPodGroup(rayClusterFixture())
+		assert.Error(t, err)
+	})
+}
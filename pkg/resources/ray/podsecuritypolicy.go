@@ -8,14 +8,36 @@ import (
 )
 
 var (
-	policyAPIGroups            = []string{"policy"}
-	podSecurityPolicyResources = []string{"podsecuritypolicies"}
-	useVerbs                   = []string{"use"}
+	policyAPIGroups                     = []string{"policy"}
+	podSecurityPolicyResources          = []string{"podsecuritypolicies"}
+	securityAPIGroups                   = []string{"security.openshift.io"}
+	securityContextConstraintsResources = []string{"securitycontextconstraints"}
+	useVerbs                            = []string{"use"}
 )
 
 // NewPodSecurityPolicyRBAC generates the role and role binding required to use a pod security policy.
 // The role is bound to the service account used by the ray cluster pods.
 func NewPodSecurityPolicyRBAC(rc *dcv1alpha1.RayCluster) (*rbacv1.Role, *rbacv1.RoleBinding) {
+	return podSecurityRBAC(rc, rbacv1.PolicyRule{
+		APIGroups:     policyAPIGroups,
+		Resources:     podSecurityPolicyResources,
+		Verbs:         useVerbs,
+		ResourceNames: []string{rc.Spec.PodSecurityPolicy},
+	})
+}
+
+// NewSecurityContextConstraintsRBAC generates the role and role binding required to use an OpenShift security
+// context constraints. The role is bound to the service account used by the ray cluster pods.
+func NewSecurityContextConstraintsRBAC(rc *dcv1alpha1.RayCluster) (*rbacv1.Role, *rbacv1.RoleBinding) {
+	return podSecurityRBAC(rc, rbacv1.PolicyRule{
+		APIGroups:     securityAPIGroups,
+		Resources:     securityContextConstraintsResources,
+		Verbs:         useVerbs,
+		ResourceNames: []string{rc.Spec.SecurityContextConstraints},
+	})
+}
+
+func podSecurityRBAC(rc *dcv1alpha1.RayCluster, rule rbacv1.PolicyRule) (*rbacv1.Role, *rbacv1.RoleBinding) {
 	name := InstanceObjectName(rc.Name, ComponentNone)
 
 	role := &rbacv1.Role{
@@ -24,14 +46,7 @@ func NewPodSecurityPolicyRBAC(rc *dcv1alpha1.RayCluster) (*rbacv1.Role, *rbacv1.
 			Namespace: rc.Namespace,
 			Labels:    MetadataLabels(rc),
 		},
-		Rules: []rbacv1.PolicyRule{
-			{
-				APIGroups:     policyAPIGroups,
-				Resources:     podSecurityPolicyResources,
-				Verbs:         useVerbs,
-				ResourceNames: []string{rc.Spec.PodSecurityPolicy},
-			},
-		},
+		Rules: []rbacv1.PolicyRule{rule},
 	}
 
 	binding := &rbacv1.RoleBinding{
@@ -30,6 +30,12 @@ func InstanceObjectName(instance string, comp Component) string {
 	return fmt.Sprintf("%s-%s-%s", instance, ApplicationName, comp)
 }
 
+// ClientServiceName returns the name of the service that exposes the client
+// server and dashboard ports of the head ray pod.
+func ClientServiceName(name string) string {
+	return InstanceObjectName(name, "client")
+}
+
 // HeadlessHeadServiceName returns the name of the headless service used to
 // register the head ray pod.
 func HeadlessHeadServiceName(name string) string {
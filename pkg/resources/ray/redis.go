@@ -0,0 +1,95 @@
+package ray
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const (
+	// RedisPasswordKey is the secret key that holds the redis password.
+	RedisPasswordKey = "password"
+
+	// RedisPasswordRotateAnnotation can be set on a RayCluster to request a
+	// new redis password. The password is regenerated whenever the value of
+	// the annotation changes and all cluster pods are restarted.
+	RedisPasswordRotateAnnotation = "distributed-compute.dominodatalab.com/rotate-redis-password"
+
+	// RedisPasswordChecksumAnnotation is added to pod templates so that
+	// cluster pods are restarted when the redis password is regenerated.
+	RedisPasswordChecksumAnnotation = "distributed-compute.dominodatalab.com/redis-password-checksum"
+
+	redisPasswordEnvVar = "RAY_REDIS_PASSWORD"
+	redisPasswordBytes  = 32
+)
+
+// RedisPasswordSecretName returns the name of the secret that holds the redis password.
+func RedisPasswordSecretName(name string) string {
+	return InstanceObjectName(name, "redis")
+}
+
+// RedisPasswordSecretObjectMeta returns the metadata of the redis password
+// secret. It can be used to look up or delete the secret.
+func RedisPasswordSecretObjectMeta(rc *dcv1alpha1.RayCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      RedisPasswordSecretName(rc.Name),
+		Namespace: rc.Namespace,
+		Labels:    MetadataLabels(rc),
+	}
+}
+
+// NewRedisPasswordSecret generates a secret that contains a new random redis
+// password. The rotation request that caused the password to be generated is
+// recorded on the secret.
+func NewRedisPasswordSecret(rc *dcv1alpha1.RayCluster) (*corev1.Secret, error) {
+	buf := make([]byte, redisPasswordBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("cannot generate redis password: %w", err)
+	}
+
+	om := RedisPasswordSecretObjectMeta(rc)
+	if token, ok := rc.Annotations[RedisPasswordRotateAnnotation]; ok {
+		om.Annotations = map[string]string{RedisPasswordRotateAnnotation: token}
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: om,
+		Type:       corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			RedisPasswordKey: []byte(hex.EncodeToString(buf)),
+		},
+	}, nil
+}
+
+// RedisPasswordRotationRequested returns true when the rotation annotation on
+// the cluster does not match the rotation that generated the secret.
+func RedisPasswordRotationRequested(rc *dcv1alpha1.RayCluster, secret *corev1.Secret) bool {
+	return rc.Annotations[RedisPasswordRotateAnnotation] != secret.Annotations[RedisPasswordRotateAnnotation]
+}
+
+// RedisPasswordChecksum returns a digest of the redis password held in the secret.
+func RedisPasswordChecksum(secret *corev1.Secret) string {
+	return fmt.Sprintf("%x", sha256.Sum256(secret.Data[RedisPasswordKey]))
+}
+
+// redisPasswordEnv exposes the redis password to ray processes so that it
+// can be referenced in command arguments.
+func redisPasswordEnv(rc *dcv1alpha1.RayCluster) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: redisPasswordEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: RedisPasswordSecretName(rc.Name),
+				},
+				Key: RedisPasswordKey,
+			},
+		},
+	}
+}
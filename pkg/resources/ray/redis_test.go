@@ -0,0 +1,44 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewRedisPasswordSecret(t *testing.T) {
+	rc := rayClusterFixture()
+
+	actual, err := NewRedisPasswordSecret(rc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-id-ray-redis", actual.Name)
+	assert.Equal(t, "fake-ns", actual.Namespace)
+	assert.Equal(t, MetadataLabels(rc), actual.Labels)
+	assert.Nil(t, actual.Annotations)
+	assert.Equal(t, corev1.SecretTypeOpaque, actual.Type)
+	assert.Len(t, actual.Data["password"], 64)
+
+	other, err := NewRedisPasswordSecret(rc)
+	require.NoError(t, err)
+	assert.NotEqual(t, actual.Data["password"], other.Data["password"])
+	assert.NotEqual(t, RedisPasswordChecksum(actual), RedisPasswordChecksum(other))
+}
+
+func TestRedisPasswordRotationRequested(t *testing.T) {
+	rc := rayClusterFixture()
+
+	secret, err := NewRedisPasswordSecret(rc)
+	require.NoError(t, err)
+	assert.False(t, RedisPasswordRotationRequested(rc, secret))
+
+	rc.Annotations = map[string]string{RedisPasswordRotateAnnotation: "2021-06-01"}
+	assert.True(t, RedisPasswordRotationRequested(rc, secret))
+
+	secret, err = NewRedisPasswordSecret(rc)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{RedisPasswordRotateAnnotation: "2021-06-01"}, secret.Annotations)
+	assert.False(t, RedisPasswordRotationRequested(rc, secret))
+}
@@ -0,0 +1,34 @@
+package ray
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/keda"
+)
+
+// NewScaledObject generates a KEDA ScaledObject that targets a RayCluster
+// resource using the triggers defined in the autoscaling config.
+func NewScaledObject(rc *dcv1alpha1.RayCluster) (*unstructured.Unstructured, error) {
+	autoscaling := rc.Spec.Autoscaling
+	if autoscaling == nil || autoscaling.KEDA == nil {
+		return nil, fmt.Errorf("cannot build scaled object without keda autoscaling config")
+	}
+
+	return keda.NewScaledObject(&keda.ScaledObjectInfo{
+		Name:        InstanceObjectName(rc.Name, ComponentNone),
+		Namespace:   rc.Namespace,
+		Labels:      MetadataLabels(rc),
+		Target:      rc.TypeMeta,
+		TargetName:  rc.Name,
+		Autoscaling: autoscaling,
+		Triggers:    autoscaling.KEDA.Triggers,
+	}), nil
+}
+
+// ScaledObjectReference returns a shallow ScaledObject used to identify existing objects.
+func ScaledObjectReference(rc *dcv1alpha1.RayCluster) *unstructured.Unstructured {
+	return keda.NewScaledObjectReference(InstanceObjectName(rc.Name, ComponentNone), rc.Namespace)
+}
@@ -0,0 +1,55 @@
+package ray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewScaledObject(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.Autoscaling = &dcv1alpha1.Autoscaling{
+			Backend:     dcv1alpha1.AutoscalingBackendKEDA,
+			MaxReplicas: 3,
+			KEDA: &dcv1alpha1.KEDAConfig{
+				Triggers: []dcv1alpha1.KEDATrigger{
+					{
+						Type:     "prometheus",
+						Metadata: map[string]string{"query": "sum(pending)"},
+					},
+				},
+			},
+		}
+
+		so, err := NewScaledObject(rc)
+		require.NoError(t, err)
+
+		assert.Equal(t, "test-id-ray", so.GetName())
+		assert.Equal(t, "fake-ns", so.GetNamespace())
+		assert.Equal(t, MetadataLabels(rc), so.GetLabels())
+
+		target, _, err := unstructured.NestedStringMap(so.Object, "spec", "scaleTargetRef")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"apiVersion": "distributed-compute.dominodatalab.com/v1test1",
+			"kind":       "RayCluster",
+			"name":       "test-id",
+		}, target)
+
+		triggers, _, err := unstructured.NestedSlice(so.Object, "spec", "triggers")
+		require.NoError(t, err)
+		assert.Len(t, triggers, 1)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		rc := rayClusterFixture()
+		_, err := NewScaledObject(rc)
+
+		assert.Error(t, err)
+	})
+}
@@ -8,11 +8,15 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/expose"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
-// NewClientService creates a ClusterIP service that points to the head
-// node that exposes the client server port, and dashboard port when enabled.
+// NewClientService creates a service that points to the head node that
+// exposes the client server port, and dashboard port when enabled. Dashboard
+// traffic is routed through the authenticating proxy when dashboard auth is
+// enabled. The service type is ClusterIP unless the cluster is exposed
+// through a load balancer or node port.
 func NewClientService(rc *dcv1alpha1.RayCluster) *corev1.Service {
 	ports := []corev1.ServicePort{
 		{
@@ -26,17 +30,19 @@ func NewClientService(rc *dcv1alpha1.RayCluster) *corev1.Service {
 		ports = append(ports, corev1.ServicePort{
 			Name:       "http-dashboard",
 			Port:       rc.Spec.DashboardPort,
-			TargetPort: intstr.FromInt(int(rc.Spec.DashboardPort)),
+			TargetPort: intstr.FromInt(int(DashboardTargetPort(rc))),
 		})
 	}
 
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      InstanceObjectName(rc.Name, "client"),
-			Namespace: rc.Namespace,
-			Labels:    MetadataLabelsWithComponent(rc, ComponentHead),
+			Name:        ClientServiceName(rc.Name),
+			Namespace:   rc.Namespace,
+			Labels:      MetadataLabelsWithComponent(rc, ComponentHead),
+			Annotations: expose.ServiceAnnotations(rc.Spec.Expose),
 		},
 		Spec: corev1.ServiceSpec{
+			Type:     expose.ServiceType(rc.Spec.Expose, ""),
 			Ports:    ports,
 			Selector: SelectorLabelsWithComponent(rc, ComponentHead),
 		},
@@ -44,7 +50,8 @@ func NewClientService(rc *dcv1alpha1.RayCluster) *corev1.Service {
 }
 
 // NewHeadlessHeadService creates a headless service that points to the head
-// node and exposes cluster communication ports.
+// node and exposes cluster communication ports, and the metrics port when
+// monitoring is enabled.
 func NewHeadlessHeadService(rc *dcv1alpha1.RayCluster) *corev1.Service {
 	ports := []corev1.ServicePort{
 		{
@@ -82,7 +89,8 @@ func NewHeadlessHeadService(rc *dcv1alpha1.RayCluster) *corev1.Service {
 }
 
 // NewHeadlessWorkerService creates a headless service that points to the
-// worker nodes and exposes cluster communication ports.
+// worker nodes and exposes cluster communication ports, and the metrics port
+// when monitoring is enabled.
 func NewHeadlessWorkerService(rc *dcv1alpha1.RayCluster) *corev1.Service {
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -119,5 +127,5 @@ func workerPorts(rc *dcv1alpha1.RayCluster) []corev1.ServicePort {
 		})
 	}
 
-	return ports
+	return append(ports, metricsServicePorts(rc)...)
 }
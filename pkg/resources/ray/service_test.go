@@ -8,6 +8,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
 
 func TestNewClientService(t *testing.T) {
@@ -55,6 +57,17 @@ func TestNewClientService(t *testing.T) {
 
 		assert.Equal(t, expected, svc)
 	})
+
+	t.Run("exposed_with_load_balancer", func(t *testing.T) {
+		rc.Spec.Expose = &dcv1alpha1.ExposeConfig{
+			Type:        dcv1alpha1.ExposeTypeLoadBalancer,
+			Annotations: map[string]string{"lb": "internal"},
+		}
+		svc := NewClientService(rc)
+
+		assert.Equal(t, corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+		assert.Equal(t, map[string]string{"lb": "internal"}, svc.Annotations)
+	})
 }
 
 func TestNewHeadlessHeadService(t *testing.T) {
@@ -11,6 +11,10 @@ import (
 	"k8s.io/utils/pointer"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashauth"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/secprofile"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
@@ -54,7 +58,22 @@ var (
 
 const sharedMemoryVolumeName = "dshm"
 
-func NewStatefulSet(rc *dcv1alpha1.RayCluster, comp Component) (*appsv1.StatefulSet, error) {
+// writableDirs are used by ray processes when the root filesystem is read-only.
+var writableDirs = []secprofile.WritableDir{
+	{Name: "ray-tmp", Path: "/tmp/ray"},
+}
+
+// NewStatefulSet generates a StatefulSet configured to manage ray cluster
+// nodes of the given component. The operator defaults supply the settings of
+// the image that clusters do not provide. Fields missing from partial defaults
+// are set to the built-in values.
+func NewStatefulSet(
+	rc *dcv1alpha1.RayCluster,
+	comp Component,
+	defaults dcv1alpha1.RayClusterDefaults,
+) (*appsv1.StatefulSet, error) {
+	defaults.SetDefaults()
+
 	p, err := newConfigProcessor(rc, comp)
 	if err != nil {
 		return nil, err
@@ -76,10 +95,12 @@ func NewStatefulSet(rc *dcv1alpha1.RayCluster, comp Component) (*appsv1.Stateful
 	ports := p.processPorts()
 	labels := p.processLabels()
 	serviceName := p.processServiceName()
-	envVars := append(defaultEnv, rc.Spec.EnvVars...)
+	envVars := append(append([]corev1.EnvVar{}, defaultEnv...), redisPasswordEnv(rc))
+	envVars = append(envVars, rc.Spec.EnvVars...)
 	volumes := append(defaultVolumes, nodeAttrs.Volumes...)
 	volumeMounts := append(defaultVolumeMounts, nodeAttrs.VolumeMounts...)
 	pvcTemplates := processPVCTemplates(nodeAttrs.VolumeClaimTemplates)
+	podLabels, podAnnotations := podgroup.PodMetadata(PodGroupName(rc.Name), rc.Spec.Scheduling, labels, nodeAttrs.Annotations)
 
 	sts := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -95,12 +116,14 @@ func NewStatefulSet(rc *dcv1alpha1.RayCluster, comp Component) (*appsv1.Stateful
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
-					Annotations: nodeAttrs.Annotations,
+					Labels:      podLabels,
+					Annotations: podAnnotations,
 				},
 
 				Spec: corev1.PodSpec{
 					ServiceAccountName: serviceAccountName,
+					SchedulerName:      podgroup.SchedulerName(rc.Spec.Scheduling),
+					PriorityClassName:  podgroup.PriorityClassName(rc.Spec.Scheduling),
 					NodeSelector:       nodeAttrs.NodeSelector,
 					Affinity:           nodeAttrs.Affinity,
 					Tolerations:        nodeAttrs.Tolerations,
@@ -145,9 +168,37 @@ func NewStatefulSet(rc *dcv1alpha1.RayCluster, comp Component) (*appsv1.Stateful
 		},
 	}
 
+	if err = applyPodOptions(rc, comp, &sts.Spec.Template.Spec, defaults); err != nil {
+		return nil, err
+	}
+
 	return sts, nil
 }
 
+// applyPodOptions adds optional features to the pod spec and hardens the
+// security context of every container, including the ones that were added.
+func applyPodOptions(
+	rc *dcv1alpha1.RayCluster,
+	comp Component,
+	spec *corev1.PodSpec,
+	defaults dcv1alpha1.RayClusterDefaults,
+) error {
+	if TLSEnabled(rc) {
+		addTLS(rc, spec)
+	}
+	if comp == ComponentHead && dashauth.Enabled(rc.Spec.DashboardAuth) {
+		if err := addDashboardAuth(rc, spec); err != nil {
+			return err
+		}
+	}
+	secprofile.Apply(rc.Spec.SecurityProfile, spec, secprofile.Options{
+		RunAsUser:    *defaults.RunAsUser,
+		WritableDirs: writableDirs,
+	})
+
+	return nil
+}
+
 type configProcessor interface {
 	replicas() int32
 	nodeAttributes() *dcv1alpha1.RayClusterNode
@@ -191,9 +242,14 @@ func (p *headProcessor) processArgs() []string {
 	}
 
 	if util.BoolPtrIsTrue(rc.Spec.EnableDashboard) {
+		// the dashboard is only reachable through the proxy when auth is enabled
+		dashHost := "0.0.0.0"
+		if dashauth.Enabled(rc.Spec.DashboardAuth) {
+			dashHost = "127.0.0.1"
+		}
 		dashArgs := []string{
 			"--include-dashboard=true",
-			"--dashboard-host=0.0.0.0",
+			fmt.Sprintf("--dashboard-host=%s", dashHost),
 			fmt.Sprintf("--dashboard-port=%d", rc.Spec.DashboardPort),
 		}
 		headArgs = append(headArgs, dashArgs...)
@@ -247,7 +303,7 @@ func (p *headProcessor) processPorts() []corev1.ContainerPort {
 		})
 	}
 
-	return ports
+	return append(ports, metricsContainerPorts(rc)...)
 }
 
 func (p *headProcessor) processLabels() map[string]string {
@@ -298,7 +354,7 @@ func (p *workerProcessor) processPorts() []corev1.ContainerPort {
 		})
 	}
 
-	return ports
+	return append(ports, metricsContainerPorts(p.rc)...)
 }
 
 func (p *workerProcessor) processLabels() map[string]string {
@@ -318,6 +374,7 @@ func processArgs(rc *dcv1alpha1.RayCluster) []string {
 		"--num-cpus=$(MY_CPU_REQUEST)",
 		fmt.Sprintf("--object-manager-port=%d", rc.Spec.ObjectManagerPort),
 		fmt.Sprintf("--node-manager-port=%d", rc.Spec.NodeManagerPort),
+		fmt.Sprintf("--redis-password=$(%s)", redisPasswordEnvVar),
 	}
 
 	if rc.Spec.WorkerPorts != nil {
@@ -328,6 +385,10 @@ func processArgs(rc *dcv1alpha1.RayCluster) []string {
 		args = append(args, fmt.Sprintf("--object-store-memory=%d", *rc.Spec.ObjectStoreMemoryBytes))
 	}
 
+	if monitoring.Enabled(rc.Spec.Monitoring) {
+		args = append(args, fmt.Sprintf("--metrics-export-port=%d", rc.Spec.Monitoring.Port))
+	}
+
 	return args
 }
 
@@ -18,7 +18,7 @@ import (
 func TestNewStatefulSet(t *testing.T) {
 	t.Run("invalid_component", func(t *testing.T) {
 		rc := rayClusterFixture()
-		_, err := NewStatefulSet(rc, Component("garbage"))
+		_, err := NewStatefulSet(rc, Component("garbage"), testDefaults.Ray)
 		assert.Error(t, err)
 	})
 
@@ -27,7 +27,7 @@ func TestNewStatefulSet(t *testing.T) {
 
 		t.Run("default_values", func(t *testing.T) {
 			rc := rayClusterFixture()
-			actual, err := NewStatefulSet(rc, ComponentHead)
+			actual, err := NewStatefulSet(rc, ComponentHead, testDefaults.Ray)
 			require.NoError(t, err)
 
 			expected := &appsv1.StatefulSet{
@@ -77,6 +77,7 @@ func TestNewStatefulSet(t *testing.T) {
 										"--num-cpus=$(MY_CPU_REQUEST)",
 										"--object-manager-port=2384",
 										"--node-manager-port=2385",
+										"--redis-password=$(RAY_REDIS_PASSWORD)",
 										"--worker-port-list=11000,11001",
 										"--head",
 										"--ray-client-server-port=10001",
@@ -101,6 +102,17 @@ func TestNewStatefulSet(t *testing.T) {
 												},
 											},
 										},
+										{
+											Name: "RAY_REDIS_PASSWORD",
+											ValueFrom: &corev1.EnvVarSource{
+												SecretKeyRef: &corev1.SecretKeySelector{
+													LocalObjectReference: corev1.LocalObjectReference{
+														Name: "test-id-ray-redis",
+													},
+													Key: "password",
+												},
+											},
+										},
 									},
 									Ports: []corev1.ContainerPort{
 										{
@@ -188,7 +200,7 @@ func TestNewStatefulSet(t *testing.T) {
 			rc.Spec.EnableDashboard = pointer.BoolPtr(true)
 			rc.Spec.DashboardPort = 8265
 
-			actual, err := NewStatefulSet(rc, ComponentHead)
+			actual, err := NewStatefulSet(rc, ComponentHead, testDefaults.Ray)
 			require.NoError(t, err)
 
 			expected := []string{
@@ -205,7 +217,7 @@ func TestNewStatefulSet(t *testing.T) {
 
 		t.Run("default_values", func(t *testing.T) {
 			rc := rayClusterFixture()
-			actual, err := NewStatefulSet(rc, ComponentWorker)
+			actual, err := NewStatefulSet(rc, ComponentWorker, testDefaults.Ray)
 			require.NoError(t, err)
 
 			expected := &appsv1.StatefulSet{
@@ -255,6 +267,7 @@ func TestNewStatefulSet(t *testing.T) {
 										"--num-cpus=$(MY_CPU_REQUEST)",
 										"--object-manager-port=2384",
 										"--node-manager-port=2385",
+										"--redis-password=$(RAY_REDIS_PASSWORD)",
 										"--worker-port-list=11000,11001",
 										"--address=test-id-ray-head-0.test-id-ray-head:6379",
 									},
@@ -275,6 +288,17 @@ func TestNewStatefulSet(t *testing.T) {
 												},
 											},
 										},
+										{
+											Name: "RAY_REDIS_PASSWORD",
+											ValueFrom: &corev1.EnvVarSource{
+												SecretKeyRef: &corev1.SecretKeySelector{
+													LocalObjectReference: corev1.LocalObjectReference{
+														Name: "test-id-ray-redis",
+													},
+													Key: "password",
+												},
+											},
+										},
 									},
 									Ports: []corev1.ContainerPort{
 										{
@@ -346,7 +370,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 		rc := rayClusterFixture()
 		rc.Spec.Image = &dcv1alpha1.OCIImageDefinition{}
 
-		_, err := NewStatefulSet(rc, comp)
+		_, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		assert.Error(t, err)
 	})
 
@@ -354,7 +378,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 		rc := rayClusterFixture()
 		rc.Spec.ObjectStoreMemoryBytes = pointer.Int64Ptr(100 * 1 << 20)
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		assert.Contains(t, actual.Spec.Template.Spec.Containers[0].Args, "--object-store-memory=104857600")
@@ -373,7 +397,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Labels = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		for _, labels := range []map[string]string{actual.Labels, actual.Spec.Template.Labels} {
@@ -397,7 +421,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Annotations = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Annotations)
@@ -425,7 +449,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.VolumeMounts = expectedVolMounts
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		assert.Subset(t, actual.Spec.Template.Spec.Volumes, expectedVols)
@@ -450,7 +474,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.VolumeClaimTemplates = input
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		expected := []corev1.PersistentVolumeClaim{
@@ -484,7 +508,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Resources = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Containers[0].Resources)
@@ -503,7 +527,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.NodeSelector = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.NodeSelector)
@@ -542,7 +566,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Affinity = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Affinity)
@@ -566,7 +590,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Tolerations = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Tolerations)
@@ -587,7 +611,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.InitContainers = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.InitContainers)
@@ -602,7 +626,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			},
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		assert.Subset(t, actual.Spec.Template.Spec.Containers[0].Env, rc.Spec.EnvVars)
@@ -614,17 +638,115 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			RunAsUser: pointer.Int64Ptr(0),
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		assert.Equal(t, rc.Spec.PodSecurityContext, actual.Spec.Template.Spec.SecurityContext)
 	})
 
+	t.Run("configured_defaults", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.SecurityProfile = dcv1alpha1.SecurityProfileRestricted
+		defaults := testDefaults.Ray
+		defaults.RunAsUser = pointer.Int64Ptr(2000)
+
+		actual, err := NewStatefulSet(rc, comp, defaults)
+		require.NoError(t, err)
+
+		assert.Equal(t, pointer.Int64Ptr(2000), actual.Spec.Template.Spec.Containers[0].SecurityContext.RunAsUser)
+	})
+
+	t.Run("partial_defaults", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.SecurityProfile = dcv1alpha1.SecurityProfileRestricted
+
+		actual, err := NewStatefulSet(rc, comp, dcv1alpha1.RayClusterDefaults{})
+		require.NoError(t, err)
+
+		assert.Equal(t, pointer.Int64Ptr(1000), actual.Spec.Template.Spec.Containers[0].SecurityContext.RunAsUser)
+	})
+
+	t.Run("security_profile_restricted", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.SecurityProfile = dcv1alpha1.SecurityProfileRestricted
+
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
+		require.NoError(t, err)
+
+		container := actual.Spec.Template.Spec.Containers[0]
+		expected := &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			Privileged:               pointer.BoolPtr(false),
+			RunAsUser:                pointer.Int64Ptr(1000),
+			RunAsNonRoot:             pointer.BoolPtr(true),
+			ReadOnlyRootFilesystem:   pointer.BoolPtr(true),
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		}
+		assert.Equal(t, expected, container.SecurityContext)
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+			Name:      "ray-tmp",
+			MountPath: "/tmp/ray",
+		})
+		assert.Len(t, actual.Spec.Template.Spec.Volumes, 2)
+	})
+
+	t.Run("scheduling", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.Scheduling = &dcv1alpha1.SchedulingConfig{
+			SchedulerName:     "custom-scheduler",
+			PriorityClassName: "high-priority",
+		}
+
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
+		require.NoError(t, err)
+
+		assert.Equal(t, "custom-scheduler", actual.Spec.Template.Spec.SchedulerName)
+		assert.Equal(t, "high-priority", actual.Spec.Template.Spec.PriorityClassName)
+		assert.NotContains(t, actual.Spec.Template.Labels, "pod-group.scheduling.sigs.k8s.io")
+	})
+
+	t.Run("gang_scheduling_scheduler_plugins", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.Scheduling = &dcv1alpha1.SchedulingConfig{
+			Gang: &dcv1alpha1.GangSchedulingConfig{
+				Enabled: pointer.BoolPtr(true),
+			},
+		}
+
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
+		require.NoError(t, err)
+
+		assert.Equal(t, "test-id-ray", actual.Spec.Template.Labels["pod-group.scheduling.sigs.k8s.io"])
+		assert.NotContains(t, actual.Labels, "pod-group.scheduling.sigs.k8s.io")
+		assert.NotContains(t, actual.Spec.Selector.MatchLabels, "pod-group.scheduling.sigs.k8s.io")
+	})
+
+	t.Run("gang_scheduling_volcano", func(t *testing.T) {
+		rc := rayClusterFixture()
+		rc.Spec.Scheduling = &dcv1alpha1.SchedulingConfig{
+			Gang: &dcv1alpha1.GangSchedulingConfig{
+				Enabled: pointer.BoolPtr(true),
+				Flavor:  dcv1alpha1.PodGroupFlavorVolcano,
+			},
+		}
+
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
+		require.NoError(t, err)
+
+		assert.Equal(t, "volcano", actual.Spec.Template.Spec.SchedulerName)
+		assert.Equal(t, "test-id-ray", actual.Spec.Template.Annotations["scheduling.k8s.io/group-name"])
+	})
+
 	t.Run("service_account_override", func(t *testing.T) {
 		rc := rayClusterFixture()
 		rc.Spec.ServiceAccountName = "user-managed-sa"
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Ray)
 		require.NoError(t, err)
 
 		assert.Equal(t, rc.Spec.ServiceAccountName, actual.Spec.Template.Spec.ServiceAccountName)
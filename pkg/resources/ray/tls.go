@@ -0,0 +1,283 @@
+package ray
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/certs"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/certmanager"
+)
+
+const (
+	// TLSChecksumAnnotation is added to pod templates so that cluster pods are
+	// restarted with new node certificates when the certificate authority is
+	// reissued. It also records the authority that signed a node certificate.
+	TLSChecksumAnnotation = "distributed-compute.dominodatalab.com/tls-checksum"
+
+	// TLSCACertKey is the secret key that holds the certificate of the issuer
+	// that signed the cluster certificate authority.
+	TLSCACertKey = "ca.crt"
+
+	tlsNodeIPsKey     = "node-ips"
+	tlsNodeVolumeName = "ray-tls"
+	tlsNodeMountPath  = "/etc/ray/tls"
+	tlsInitName       = "ray-tls"
+)
+
+// tlsInitScript waits until the node certificate issued by the operator
+// includes the pod IP. Ray verifies peers by IP address so the certificate is
+// reissued once new pods are assigned an address, and the kubelet updates the
+// mounted secret shortly after. The script only uses the shell and grep.
+const tlsInitScript = `if ! command -v grep >/dev/null 2>&1; then
+  echo "ray tls requires grep in the cluster image" >&2
+  exit 1
+fi
+until grep -qxF "${MY_POD_IP}" ` + tlsNodeMountPath + `/` + tlsNodeIPsKey + `; do
+  echo "waiting for a node certificate that includes ${MY_POD_IP}"
+  sleep 5
+done
+`
+
+// TLSEnabled returns true when ray gRPC channels should be encrypted.
+func TLSEnabled(rc *dcv1alpha1.RayCluster) bool {
+	tls := rc.Spec.TLS
+	return tls != nil && tls.Enabled != nil && *tls.Enabled
+}
+
+// TLSSecretName returns the name of the secret that holds the cluster
+// certificate authority.
+func TLSSecretName(name string) string {
+	return InstanceObjectName(name, "ca")
+}
+
+// TLSSecretObjectMeta returns the metadata of the certificate authority
+// secret. It can be used to look up or delete the secret.
+func TLSSecretObjectMeta(rc *dcv1alpha1.RayCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      TLSSecretName(rc.Name),
+		Namespace: rc.Namespace,
+		Labels:    MetadataLabels(rc),
+	}
+}
+
+// NewTLSSecret generates a secret that contains a new self-signed cluster
+// certificate authority.
+func NewTLSSecret(rc *dcv1alpha1.RayCluster, now time.Time) (*corev1.Secret, error) {
+	ca, err := certs.NewCA(TLSSecretName(rc.Name), rc.Spec.TLS.Duration.Duration, now)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate certificate authority: %w", err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: TLSSecretObjectMeta(rc),
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			TLSCACertKey:            ca.Certificate,
+			corev1.TLSCertKey:       ca.Certificate,
+			corev1.TLSPrivateKeyKey: ca.PrivateKey,
+		},
+	}, nil
+}
+
+// TLSRenewalTime returns the time at which the certificate authority held in
+// the secret should be reissued.
+func TLSRenewalTime(rc *dcv1alpha1.RayCluster, secret *corev1.Secret) (time.Time, error) {
+	notAfter, err := certs.NotAfter(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return notAfter.Add(-rc.Spec.TLS.RenewBefore.Duration), nil
+}
+
+// TLSChecksum returns a digest of the certificates held in the secret.
+func TLSChecksum(secret *corev1.Secret) string {
+	var data []byte
+	data = append(data, secret.Data[TLSCACertKey]...)
+	data = append(data, secret.Data[corev1.TLSCertKey]...)
+
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// NewCertificate generates a cert-manager certificate that issues the
+// cluster certificate authority into the secret used by cluster pods.
+func NewCertificate(rc *dcv1alpha1.RayCluster) *unstructured.Unstructured {
+	tls := rc.Spec.TLS
+
+	return certmanager.NewCertificate(&certmanager.CertificateInfo{
+		Name:        TLSSecretName(rc.Name),
+		Namespace:   rc.Namespace,
+		Labels:      MetadataLabels(rc),
+		SecretName:  TLSSecretName(rc.Name),
+		CommonName:  TLSSecretName(rc.Name),
+		IsCA:        true,
+		IssuerRef:   *tls.IssuerRef,
+		Duration:    tls.Duration.Duration,
+		RenewBefore: tls.RenewBefore.Duration,
+	})
+}
+
+// CertificateReference returns a shallow cert-manager certificate that can
+// be used to look up or delete the cluster certificate.
+func CertificateReference(rc *dcv1alpha1.RayCluster) *unstructured.Unstructured {
+	return certmanager.NewCertificateReference(TLSSecretName(rc.Name), rc.Namespace)
+}
+
+// TLSNodeSecretName returns the name of the secret that holds the node
+// certificate mounted by cluster pods.
+func TLSNodeSecretName(name string) string {
+	return InstanceObjectName(name, "tls")
+}
+
+// TLSNodeSecretObjectMeta returns the metadata of the node certificate
+// secret. It can be used to look up or delete the secret.
+func TLSNodeSecretObjectMeta(rc *dcv1alpha1.RayCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      TLSNodeSecretName(rc.Name),
+		Namespace: rc.Namespace,
+		Labels:    MetadataLabels(rc),
+	}
+}
+
+// NewTLSNodeSecret generates a secret that contains a node certificate signed
+// by the certificate authority held in the ca secret. The certificate is valid
+// for the cluster service names and the given pod IPs, which are also listed
+// in the secret so that pods can tell when they have been included.
+func NewTLSNodeSecret(rc *dcv1alpha1.RayCluster, ca *corev1.Secret, podIPs []string, now time.Time) (*corev1.Secret, error) {
+	ips := []net.IP{net.IPv4(127, 0, 0, 1)}
+	for _, podIP := range podIPs {
+		if ip := net.ParseIP(podIP); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+
+	authority := &certs.KeyPair{
+		Certificate: ca.Data[corev1.TLSCertKey],
+		PrivateKey:  ca.Data[corev1.TLSPrivateKeyKey],
+	}
+	kp, err := certs.NewSignedCertificate(authority, TLSNodeSecretName(rc.Name), append([]string{"localhost"}, tlsDNSNames(rc)...), ips, now)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate node certificate: %w", err)
+	}
+
+	// cert-manager stores the certificate of its issuer alongside the cluster
+	// certificate authority. both are trusted so that either can sign peers.
+	bundle := ca.Data[corev1.TLSCertKey]
+	if issuer := ca.Data[TLSCACertKey]; len(issuer) > 0 && !bytes.Equal(issuer, bundle) {
+		bundle = bytes.Join([][]byte{issuer, bundle}, []byte("\n"))
+	}
+
+	meta := TLSNodeSecretObjectMeta(rc)
+	meta.Annotations = map[string]string{TLSChecksumAnnotation: TLSChecksum(ca)}
+
+	return &corev1.Secret{
+		ObjectMeta: meta,
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			TLSCACertKey:            bundle,
+			corev1.TLSCertKey:       kp.Certificate,
+			corev1.TLSPrivateKeyKey: kp.PrivateKey,
+			tlsNodeIPsKey:           []byte(tlsNodeIPs(podIPs)),
+		},
+	}, nil
+}
+
+// TLSNodeSecretCurrent returns true when the node certificate was signed by
+// the certificate authority held in the ca secret and covers the given pod
+// IPs.
+func TLSNodeSecretCurrent(node, ca *corev1.Secret, podIPs []string) bool {
+	return node.Annotations[TLSChecksumAnnotation] == TLSChecksum(ca) &&
+		string(node.Data[tlsNodeIPsKey]) == tlsNodeIPs(podIPs)
+}
+
+// tlsNodeIPs returns the sorted pod IPs with one address per line.
+func tlsNodeIPs(podIPs []string) string {
+	var b strings.Builder
+	sorted := append([]string(nil), podIPs...)
+	sort.Strings(sorted)
+	for _, ip := range sorted {
+		b.WriteString(ip)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// addTLS configures every ray container to use the node certificate issued by
+// the operator. An init container holds the pod until the certificate includes
+// the pod IP. The cluster certificate authority is never mounted.
+func addTLS(rc *dcv1alpha1.RayCluster, spec *corev1.PodSpec) {
+	nodeMount := corev1.VolumeMount{
+		Name:      tlsNodeVolumeName,
+		MountPath: tlsNodeMountPath,
+		ReadOnly:  true,
+	}
+
+	initContainer := corev1.Container{
+		Name:            tlsInitName,
+		Image:           spec.Containers[0].Image,
+		ImagePullPolicy: spec.Containers[0].ImagePullPolicy,
+		Command:         []string{"/bin/sh", "-c", tlsInitScript},
+		Env: []corev1.EnvVar{
+			{
+				Name: "MY_POD_IP",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "status.podIP",
+					},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{nodeMount},
+	}
+	spec.InitContainers = append([]corev1.Container{initContainer}, spec.InitContainers...)
+
+	spec.Volumes = append(append([]corev1.Volume(nil), spec.Volumes...),
+		corev1.Volume{
+			Name: tlsNodeVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: TLSNodeSecretName(rc.Name),
+				},
+			},
+		},
+	)
+
+	container := &spec.Containers[0]
+	container.VolumeMounts = append(append([]corev1.VolumeMount(nil), container.VolumeMounts...), nodeMount)
+	container.Env = append(append([]corev1.EnvVar(nil), container.Env...),
+		corev1.EnvVar{Name: "RAY_USE_TLS", Value: "1"},
+		corev1.EnvVar{Name: "RAY_TLS_SERVER_CERT", Value: tlsNodeMountPath + "/" + corev1.TLSCertKey},
+		corev1.EnvVar{Name: "RAY_TLS_SERVER_KEY", Value: tlsNodeMountPath + "/" + corev1.TLSPrivateKeyKey},
+		corev1.EnvVar{Name: "RAY_TLS_CA_CERT", Value: tlsNodeMountPath + "/" + TLSCACertKey},
+	)
+}
+
+// tlsDNSNames returns the service names that clients use to reach cluster
+// nodes.
+func tlsDNSNames(rc *dcv1alpha1.RayCluster) []string {
+	var names []string
+	for _, svc := range []string{
+		ClientServiceName(rc.Name),
+		HeadlessHeadServiceName(rc.Name),
+		HeadlessWorkerServiceName(rc.Name),
+	} {
+		names = append(names,
+			svc,
+			fmt.Sprintf("%s.%s", svc, rc.Namespace),
+			fmt.Sprintf("%s.%s.svc", svc, rc.Namespace),
+		)
+	}
+
+	return names
+}
@@ -0,0 +1,206 @@
+package ray
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func tlsClusterFixture(issuer dcv1alpha1.RayTLSIssuer) *dcv1alpha1.RayCluster {
+	rc := rayClusterFixture()
+	rc.Spec.TLS = &dcv1alpha1.RayClusterTLS{
+		Enabled:     pointer.BoolPtr(true),
+		Issuer:      issuer,
+		Duration:    &metav1.Duration{Duration: 90 * 24 * time.Hour},
+		RenewBefore: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+	}
+
+	return rc
+}
+
+func TestTLSEnabled(t *testing.T) {
+	rc := rayClusterFixture()
+	assert.False(t, TLSEnabled(rc))
+
+	rc.Spec.TLS = &dcv1alpha1.RayClusterTLS{}
+	assert.False(t, TLSEnabled(rc))
+
+	rc.Spec.TLS.Enabled = pointer.BoolPtr(true)
+	assert.True(t, TLSEnabled(rc))
+}
+
+func TestNewTLSSecret(t *testing.T) {
+	rc := tlsClusterFixture(dcv1alpha1.RayTLSIssuerOperator)
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	secret, err := NewTLSSecret(rc, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-id-ray-ca", secret.Name)
+	assert.Equal(t, "fake-ns", secret.Namespace)
+	assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+	assert.Equal(t, secret.Data["ca.crt"], secret.Data["tls.crt"])
+	assert.NotEmpty(t, secret.Data["tls.key"])
+
+	renewal, err := TLSRenewalTime(rc, secret)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(60*24*time.Hour), renewal)
+
+	_, err = TLSRenewalTime(rc, &corev1.Secret{})
+	assert.Error(t, err)
+}
+
+func TestTLSChecksum(t *testing.T) {
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			"ca.crt":  []byte("ca"),
+			"tls.crt": []byte("cert"),
+			"tls.key": []byte("key"),
+		},
+	}
+	checksum := TLSChecksum(secret)
+	assert.Len(t, checksum, 64)
+
+	secret.Data["tls.key"] = []byte("rotated-key")
+	assert.Equal(t, checksum, TLSChecksum(secret), "private key should not affect checksum")
+
+	secret.Data["tls.crt"] = []byte("rotated-cert")
+	assert.NotEqual(t, checksum, TLSChecksum(secret))
+}
+
+func TestNewCertificate(t *testing.T) {
+	rc := tlsClusterFixture(dcv1alpha1.RayTLSIssuerCertManager)
+	rc.Spec.TLS.IssuerRef = &dcv1alpha1.CertificateIssuerRef{Name: "root-ca", Kind: "ClusterIssuer"}
+
+	actual := NewCertificate(rc)
+
+	assert.Equal(t, "test-id-ray-ca", actual.GetName())
+	assert.Equal(t, "fake-ns", actual.GetNamespace())
+	assert.Equal(t, "Certificate", actual.GetKind())
+
+	spec := actual.Object["spec"].(map[string]interface{})
+	assert.Equal(t, "test-id-ray-ca", spec["secretName"])
+	assert.Equal(t, true, spec["isCA"])
+	assert.Equal(t, map[string]interface{}{"name": "root-ca", "kind": "ClusterIssuer"}, spec["issuerRef"])
+	assert.Equal(t, "2160h0m0s", spec["duration"])
+	assert.Equal(t, "720h0m0s", spec["renewBefore"])
+}
+
+func TestNewTLSNodeSecret(t *testing.T) {
+	rc := tlsClusterFixture(dcv1alpha1.RayTLSIssuerOperator)
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	ca, err := NewTLSSecret(rc, now)
+	require.NoError(t, err)
+
+	secret, err := NewTLSNodeSecret(rc, ca, []string{"10.0.0.2", "10.0.0.1"}, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-id-ray-tls", secret.Name)
+	assert.Equal(t, "fake-ns", secret.Namespace)
+	assert.Equal(t, TLSChecksum(ca), secret.Annotations[TLSChecksumAnnotation])
+	assert.Equal(t, ca.Data["tls.crt"], secret.Data["ca.crt"])
+	assert.Equal(t, "10.0.0.1\n10.0.0.2\n", string(secret.Data["node-ips"]))
+	assert.NotEqual(t, ca.Data["tls.key"], secret.Data["tls.key"])
+
+	block, _ := pem.Decode(secret.Data["tls.crt"])
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	assert.False(t, cert.IsCA)
+	assert.Contains(t, cert.DNSNames, "localhost")
+	assert.Contains(t, cert.DNSNames, "test-id-ray-client.fake-ns.svc")
+	assert.Contains(t, cert.DNSNames, "test-id-ray-worker.fake-ns")
+	var ips []string
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	assert.Equal(t, []string{"127.0.0.1", "10.0.0.2", "10.0.0.1"}, ips)
+
+	caBlock, _ := pem.Decode(ca.Data["tls.crt"])
+	caCert, err := x509.ParseCertificate(caBlock.Bytes)
+	require.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+
+	t.Run("issuer_bundle", func(t *testing.T) {
+		issued := ca.DeepCopy()
+		issued.Data["ca.crt"] = []byte("issuer")
+
+		secret, err := NewTLSNodeSecret(rc, issued, nil, now)
+		require.NoError(t, err)
+		assert.Equal(t, append([]byte("issuer\n"), ca.Data["tls.crt"]...), secret.Data["ca.crt"])
+	})
+
+	t.Run("invalid_ca", func(t *testing.T) {
+		_, err := NewTLSNodeSecret(rc, &corev1.Secret{}, nil, now)
+		assert.Error(t, err)
+	})
+}
+
+func TestTLSNodeSecretCurrent(t *testing.T) {
+	rc := tlsClusterFixture(dcv1alpha1.RayTLSIssuerOperator)
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	ca, err := NewTLSSecret(rc, now)
+	require.NoError(t, err)
+	node, err := NewTLSNodeSecret(rc, ca, []string{"10.0.0.1", "10.0.0.2"}, now)
+	require.NoError(t, err)
+
+	assert.True(t, TLSNodeSecretCurrent(node, ca, []string{"10.0.0.2", "10.0.0.1"}))
+	assert.False(t, TLSNodeSecretCurrent(node, ca, []string{"10.0.0.1"}), "pod ips changed")
+
+	reissued, err := NewTLSSecret(rc, now)
+	require.NoError(t, err)
+	assert.False(t, TLSNodeSecretCurrent(node, reissued, []string{"10.0.0.1", "10.0.0.2"}), "certificate authority changed")
+}
+
+func TestStatefulSetTLS(t *testing.T) {
+	rc := tlsClusterFixture(dcv1alpha1.RayTLSIssuerOperator)
+	rc.Spec.Worker.InitContainers = []corev1.Container{{Name: "user-init"}}
+
+	sts, err := NewStatefulSet(rc, ComponentWorker, testDefaults.Ray)
+	require.NoError(t, err)
+	spec := sts.Spec.Template.Spec
+
+	require.Len(t, spec.InitContainers, 2)
+	initContainer := spec.InitContainers[0]
+	assert.Equal(t, "ray-tls", initContainer.Name)
+	assert.Equal(t, "docker.io/fake-reg/fake-repo:fake-tag", initContainer.Image)
+	assert.Equal(t, []string{"/bin/sh", "-c"}, initContainer.Command[:2])
+	assert.Contains(t, initContainer.Command[2], "/etc/ray/tls/node-ips")
+	assert.NotContains(t, initContainer.Command[2], "openssl")
+	assert.Equal(t, []corev1.VolumeMount{{Name: "ray-tls", MountPath: "/etc/ray/tls", ReadOnly: true}}, initContainer.VolumeMounts)
+	assert.Equal(t, "user-init", spec.InitContainers[1].Name)
+
+	assert.Contains(t, spec.Volumes, corev1.Volume{
+		Name: "ray-tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "test-id-ray-tls"},
+		},
+	})
+	for _, vol := range spec.Volumes {
+		if vol.Secret != nil {
+			assert.NotEqual(t, "test-id-ray-ca", vol.Secret.SecretName, "pods should not mount the certificate authority")
+		}
+	}
+
+	container := spec.Containers[0]
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "ray-tls", MountPath: "/etc/ray/tls", ReadOnly: true})
+	assert.Subset(t, container.Env, []corev1.EnvVar{
+		{Name: "RAY_USE_TLS", Value: "1"},
+		{Name: "RAY_TLS_SERVER_CERT", Value: "/etc/ray/tls/tls.crt"},
+		{Name: "RAY_TLS_SERVER_KEY", Value: "/etc/ray/tls/tls.key"},
+		{Name: "RAY_TLS_CA_CERT", Value: "/etc/ray/tls/ca.crt"},
+	})
+	assert.Equal(t, []corev1.Container{{Name: "user-init"}}, rc.Spec.Worker.InitContainers)
+}
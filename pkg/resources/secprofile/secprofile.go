@@ -0,0 +1,143 @@
+package secprofile
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+// WritableDir is a path that an application must be able to write to when
+// its root filesystem is mounted read-only.
+type WritableDir struct {
+	// Name of the empty dir volume mounted at the path.
+	Name string
+	// Path at which the volume is mounted.
+	Path string
+}
+
+// Options customize the hardening applied to a pod spec.
+type Options struct {
+	// RunAsUser is the non-root user id used by the restricted profile when
+	// neither the pod nor the container security context sets a user.
+	RunAsUser int64
+	// WritableDirs are backed by empty dir volumes in application containers
+	// when the restricted profile is used.
+	WritableDirs []WritableDir
+}
+
+// Apply hardens the security context of every container in the pod spec
+// according to the profile. Fields already set on a container or its pod
+// security context are preserved.
+func Apply(profile dcv1alpha1.SecurityProfile, spec *corev1.PodSpec, opts Options) {
+	if profile != dcv1alpha1.SecurityProfileBaseline && profile != dcv1alpha1.SecurityProfileRestricted {
+		return
+	}
+	restricted := profile == dcv1alpha1.SecurityProfileRestricted
+
+	podCtx := spec.SecurityContext
+	if podCtx == nil {
+		podCtx = &corev1.PodSecurityContext{}
+	}
+
+	spec.InitContainers = append([]corev1.Container(nil), spec.InitContainers...)
+	for idx := range spec.InitContainers {
+		spec.InitContainers[idx].SecurityContext = containerSecurityContext(
+			spec.InitContainers[idx].SecurityContext, podCtx, restricted, opts,
+		)
+	}
+
+	spec.Containers = append([]corev1.Container(nil), spec.Containers...)
+	for idx := range spec.Containers {
+		spec.Containers[idx].SecurityContext = containerSecurityContext(
+			spec.Containers[idx].SecurityContext, podCtx, restricted, opts,
+		)
+	}
+
+	if restricted {
+		addWritableDirs(spec, opts.WritableDirs)
+	}
+}
+
+func containerSecurityContext(
+	current *corev1.SecurityContext,
+	podCtx *corev1.PodSecurityContext,
+	restricted bool,
+	opts Options,
+) *corev1.SecurityContext {
+	sc := current.DeepCopy()
+	if sc == nil {
+		sc = &corev1.SecurityContext{}
+	}
+
+	if sc.Privileged == nil {
+		sc.Privileged = pointer.BoolPtr(false)
+	}
+	if sc.AllowPrivilegeEscalation == nil {
+		sc.AllowPrivilegeEscalation = pointer.BoolPtr(false)
+	}
+	if sc.Capabilities == nil {
+		sc.Capabilities = &corev1.Capabilities{}
+	}
+	if len(sc.Capabilities.Drop) == 0 {
+		sc.Capabilities.Drop = []corev1.Capability{"ALL"}
+	}
+	if sc.SeccompProfile == nil && podCtx.SeccompProfile == nil {
+		sc.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+
+	if !restricted {
+		return sc
+	}
+
+	if sc.RunAsNonRoot == nil && podCtx.RunAsNonRoot == nil {
+		sc.RunAsNonRoot = pointer.BoolPtr(true)
+	}
+	if sc.RunAsUser == nil && podCtx.RunAsUser == nil {
+		sc.RunAsUser = pointer.Int64Ptr(opts.RunAsUser)
+	}
+	if sc.ReadOnlyRootFilesystem == nil {
+		sc.ReadOnlyRootFilesystem = pointer.BoolPtr(true)
+	}
+
+	return sc
+}
+
+// addWritableDirs mounts an empty dir volume at every writable path in the
+// application containers unless a volume is already mounted there.
+func addWritableDirs(spec *corev1.PodSpec, dirs []WritableDir) {
+	for _, dir := range dirs {
+		added := false
+		for idx := range spec.Containers {
+			container := &spec.Containers[idx]
+			if hasMountPath(container.VolumeMounts, dir.Path) {
+				continue
+			}
+
+			container.VolumeMounts = append(append([]corev1.VolumeMount(nil), container.VolumeMounts...), corev1.VolumeMount{
+				Name:      dir.Name,
+				MountPath: dir.Path,
+			})
+			added = true
+		}
+
+		if added {
+			spec.Volumes = append(append([]corev1.Volume(nil), spec.Volumes...), corev1.Volume{
+				Name: dir.Name,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			})
+		}
+	}
+}
+
+func hasMountPath(mounts []corev1.VolumeMount, path string) bool {
+	for _, mount := range mounts {
+		if mount.MountPath == path {
+			return true
+		}
+	}
+
+	return false
+}
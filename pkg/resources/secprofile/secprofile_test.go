@@ -0,0 +1,115 @@
+package secprofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+var testOpts = Options{
+	RunAsUser: 1000,
+	WritableDirs: []WritableDir{
+		{Name: "scratch", Path: "/scratch"},
+	},
+}
+
+func TestApply(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		spec := podSpecFixture()
+		Apply(dcv1alpha1.SecurityProfileNone, spec, testOpts)
+
+		assert.Equal(t, podSpecFixture(), spec)
+	})
+
+	t.Run("baseline", func(t *testing.T) {
+		spec := podSpecFixture()
+		Apply(dcv1alpha1.SecurityProfileBaseline, spec, testOpts)
+
+		expected := &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			Privileged:               pointer.BoolPtr(false),
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		}
+		assert.Equal(t, expected, spec.InitContainers[0].SecurityContext)
+		assert.Equal(t, expected, spec.Containers[0].SecurityContext)
+		assert.Empty(t, spec.Volumes)
+	})
+
+	t.Run("restricted", func(t *testing.T) {
+		spec := podSpecFixture()
+		Apply(dcv1alpha1.SecurityProfileRestricted, spec, testOpts)
+
+		sc := spec.Containers[0].SecurityContext
+		assert.Equal(t, pointer.BoolPtr(true), sc.RunAsNonRoot)
+		assert.Equal(t, pointer.Int64Ptr(1000), sc.RunAsUser)
+		assert.Equal(t, pointer.BoolPtr(true), sc.ReadOnlyRootFilesystem)
+
+		assert.Equal(t, []corev1.VolumeMount{{Name: "scratch", MountPath: "/scratch"}}, spec.Containers[0].VolumeMounts)
+		assert.Empty(t, spec.InitContainers[0].VolumeMounts)
+		assert.Equal(t, []corev1.Volume{
+			{
+				Name: "scratch",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+		}, spec.Volumes)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		spec := podSpecFixture()
+		spec.SecurityContext = &corev1.PodSecurityContext{
+			RunAsUser: pointer.Int64Ptr(2000),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeLocalhost,
+			},
+		}
+		override := &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Add: []corev1.Capability{"NET_BIND_SERVICE"},
+			},
+			ReadOnlyRootFilesystem: pointer.BoolPtr(true),
+		}
+		spec.Containers[0].SecurityContext = override
+		spec.Containers[0].VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: "/scratch"}}
+
+		Apply(dcv1alpha1.SecurityProfileRestricted, spec, testOpts)
+
+		sc := spec.Containers[0].SecurityContext
+		assert.Nil(t, sc.RunAsUser)
+		assert.Nil(t, sc.SeccompProfile)
+		assert.Equal(t, &corev1.Capabilities{
+			Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+			Drop: []corev1.Capability{"ALL"},
+		}, sc.Capabilities)
+		assert.Empty(t, spec.Volumes)
+
+		assert.Nil(t, override.Capabilities.Drop, "provided security context should not be modified")
+	})
+
+	t.Run("does_not_modify_input", func(t *testing.T) {
+		initContainers := []corev1.Container{{Name: "init"}}
+		spec := podSpecFixture()
+		spec.InitContainers = initContainers
+
+		Apply(dcv1alpha1.SecurityProfileRestricted, spec, testOpts)
+
+		assert.Nil(t, initContainers[0].SecurityContext)
+	})
+}
+
+func podSpecFixture() *corev1.PodSpec {
+	return &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init"}},
+		Containers:     []corev1.Container{{Name: "main"}},
+	}
+}
@@ -0,0 +1,107 @@
+package spark
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+const (
+	// AuthSecretKey is the secret key that holds the shared authentication secret.
+	AuthSecretKey = "auth-secret"
+
+	// AuthChecksumAnnotation is added to pod templates so that cluster pods
+	// are restarted when the shared authentication secret is regenerated.
+	AuthChecksumAnnotation = "distributed-compute.dominodatalab.com/auth-checksum"
+
+	authSecretBytes = 32
+)
+
+// AuthEnabled returns true when spark processes must authenticate with a shared secret.
+func AuthEnabled(sc *dcv1alpha1.SparkCluster) bool {
+	sec := sc.Spec.Security
+	return sec != nil && sec.Authenticate != nil && *sec.Authenticate
+}
+
+// EncryptionEnabled returns true when RPC connections between spark processes should be encrypted.
+func EncryptionEnabled(sc *dcv1alpha1.SparkCluster) bool {
+	return AuthEnabled(sc) && sc.Spec.Security.Encryption != nil && *sc.Spec.Security.Encryption
+}
+
+// AuthSecretName returns the name of the secret that holds the shared authentication secret.
+func AuthSecretName(name string) string {
+	return InstanceObjectName(name, "auth")
+}
+
+// AuthSecretObjectMeta returns the metadata of the shared authentication
+// secret. It can be used to look up or delete the secret.
+func AuthSecretObjectMeta(sc *dcv1alpha1.SparkCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      AuthSecretName(sc.Name),
+		Namespace: sc.Namespace,
+		Labels:    MetadataLabels(sc),
+	}
+}
+
+// NewAuthSecret generates a secret that contains a new random shared authentication secret.
+func NewAuthSecret(sc *dcv1alpha1.SparkCluster) (*corev1.Secret, error) {
+	buf := make([]byte, authSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("cannot generate authentication secret: %w", err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: AuthSecretObjectMeta(sc),
+		Type:       corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			AuthSecretKey: []byte(hex.EncodeToString(buf)),
+		},
+	}, nil
+}
+
+// AuthChecksum returns a digest of the shared authentication secret.
+func AuthChecksum(secret *corev1.Secret) string {
+	return fmt.Sprintf("%x", sha256.Sum256(secret.Data[AuthSecretKey]))
+}
+
+// authEnvVars configures spark processes to use the shared authentication
+// secret. The bitnami image translates these variables into the
+// "spark.authenticate", "spark.authenticate.secret" and
+// "spark.network.crypto.enabled" properties.
+func authEnvVars(sc *dcv1alpha1.SparkCluster) []corev1.EnvVar {
+	if !AuthEnabled(sc) {
+		return nil
+	}
+
+	envVars := []corev1.EnvVar{
+		{
+			Name:  "SPARK_RPC_AUTHENTICATION_ENABLED",
+			Value: "yes",
+		},
+		{
+			Name: "SPARK_RPC_AUTHENTICATION_SECRET",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: AuthSecretName(sc.Name),
+					},
+					Key: AuthSecretKey,
+				},
+			},
+		},
+	}
+	if EncryptionEnabled(sc) {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "SPARK_RPC_ENCRYPTION_ENABLED",
+			Value: "yes",
+		})
+	}
+
+	return envVars
+}
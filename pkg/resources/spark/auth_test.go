@@ -0,0 +1,46 @@
+package spark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestAuthEnabled(t *testing.T) {
+	sc := sparkClusterFixture()
+	assert.False(t, AuthEnabled(sc))
+	assert.False(t, EncryptionEnabled(sc))
+
+	sc.Spec.Security = &dcv1alpha1.SparkClusterSecurity{
+		Encryption: pointer.BoolPtr(true),
+	}
+	assert.False(t, AuthEnabled(sc))
+	assert.False(t, EncryptionEnabled(sc), "encryption requires authentication")
+
+	sc.Spec.Security.Authenticate = pointer.BoolPtr(true)
+	assert.True(t, AuthEnabled(sc))
+	assert.True(t, EncryptionEnabled(sc))
+}
+
+func TestNewAuthSecret(t *testing.T) {
+	sc := sparkClusterFixture()
+
+	actual, err := NewAuthSecret(sc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-id-spark-auth", actual.Name)
+	assert.Equal(t, "fake-ns", actual.Namespace)
+	assert.Equal(t, MetadataLabels(sc), actual.Labels)
+	assert.Equal(t, corev1.SecretTypeOpaque, actual.Type)
+	assert.Len(t, actual.Data[AuthSecretKey], 64)
+
+	other, err := NewAuthSecret(sc)
+	require.NoError(t, err)
+	assert.NotEqual(t, actual.Data[AuthSecretKey], other.Data[AuthSecretKey])
+	assert.NotEqual(t, AuthChecksum(actual), AuthChecksum(other))
+}
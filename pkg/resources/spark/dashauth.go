@@ -0,0 +1,55 @@
+package spark
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashauth"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+)
+
+// statusRoute is served without authentication so that autoscalers can read
+// the master status through the master service.
+const statusRoute = "^/json/$"
+
+// metricsRoute is served without authentication so that Prometheus can scrape
+// the master metrics through the proxy.
+const metricsRoute = "^/metrics/"
+
+// uiPortName names the master web UI container port when the proxy serves the
+// "http" port in its place. Services, monitors and network policies address
+// the "http" port by name so that metrics are scraped through the proxy on the
+// master and directly from the web UI on workers.
+const uiPortName = "http-ui"
+
+// DashboardTargetPort returns the master pod port that receives dashboard
+// traffic. This is the authenticating proxy port when dashboard auth is
+// enabled.
+func DashboardTargetPort(sc *dcv1alpha1.SparkCluster) int32 {
+	if dashauth.Enabled(sc.Spec.DashboardAuth) {
+		return sc.Spec.DashboardAuth.Port
+	}
+
+	return sc.Spec.DashboardPort
+}
+
+// addDashboardAuth appends the authenticating proxy sidecar to the master pod.
+func addDashboardAuth(sc *dcv1alpha1.SparkCluster, spec *corev1.PodSpec) error {
+	routes := []string{statusRoute}
+	if monitoring.Enabled(sc.Spec.Monitoring) {
+		routes = append(routes, metricsRoute)
+	}
+
+	proxy, err := dashauth.NewContainer(dashauth.ProxyInfo{
+		Config:         sc.Spec.DashboardAuth,
+		UpstreamPort:   sc.Spec.DashboardPort,
+		SkipAuthRoutes: routes,
+		PortName:       "http",
+	})
+	if err != nil {
+		return err
+	}
+	spec.Containers = append(spec.Containers, proxy)
+
+	return nil
+}
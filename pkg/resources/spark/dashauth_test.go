@@ -0,0 +1,79 @@
+package spark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func dashboardAuthClusterFixture() *dcv1alpha1.SparkCluster {
+	sc := sparkClusterFixture()
+	sc.Spec.EnableDashboard = pointer.BoolPtr(true)
+	sc.Spec.DashboardAuth = &dcv1alpha1.DashboardAuthConfig{
+		Enabled: pointer.BoolPtr(true),
+		Image: &dcv1alpha1.OCIImageDefinition{
+			Registry:   "quay.io",
+			Repository: "oauth2-proxy/oauth2-proxy",
+			Tag:        "v7.1.3",
+		},
+		Port:            4180,
+		IssuerURL:       "https://accounts.example.com",
+		ClientSecretRef: &corev1.LocalObjectReference{Name: "oidc-client"},
+	}
+
+	return sc
+}
+
+func TestDashboardTargetPort(t *testing.T) {
+	sc := sparkClusterFixture()
+	assert.Equal(t, sc.Spec.DashboardPort, DashboardTargetPort(sc))
+
+	sc = dashboardAuthClusterFixture()
+	assert.Equal(t, int32(4180), DashboardTargetPort(sc))
+}
+
+func TestStatefulSetDashboardAuth(t *testing.T) {
+	sc := dashboardAuthClusterFixture()
+
+	master, err := NewStatefulSet(sc, ComponentMaster, testDefaults.Spark)
+	require.NoError(t, err)
+
+	containers := master.Spec.Template.Spec.Containers
+	require.Len(t, containers, 2)
+	assert.Equal(t, "dashboard-auth", containers[1].Name)
+	assert.Subset(t, containers[1].Args, []string{
+		"--upstream=http://127.0.0.1:8265/",
+		"--skip-auth-route=^/json/$",
+	})
+
+	worker, err := NewStatefulSet(sc, ComponentWorker, testDefaults.Spark)
+	require.NoError(t, err)
+	assert.Len(t, worker.Spec.Template.Spec.Containers, 1)
+}
+
+func TestDashboardAuthMetricsScrape(t *testing.T) {
+	sc := dashboardAuthClusterFixture()
+	sc.Spec.Monitoring = monitoringClusterFixture().Spec.Monitoring
+
+	master, err := NewStatefulSet(sc, ComponentMaster, testDefaults.Spark)
+	require.NoError(t, err)
+
+	containers := master.Spec.Template.Spec.Containers
+	require.Len(t, containers, 2)
+	assert.Equal(t, "http-ui", containers[0].Ports[0].Name)
+	assert.Equal(t, "http", containers[1].Ports[0].Name)
+	assert.Equal(t, int32(4180), containers[1].Ports[0].ContainerPort)
+	assert.Contains(t, containers[1].Args, "--skip-auth-route=^/metrics/")
+
+	worker, err := NewStatefulSet(sc, ComponentWorker, testDefaults.Spark)
+	require.NoError(t, err)
+	assert.Equal(t, "http", worker.Spec.Template.Spec.Containers[0].Ports[0].Name)
+
+	svc := NewMasterService(sc)
+	assert.Equal(t, "http", svc.Spec.Ports[1].TargetPort.StrVal)
+}
@@ -0,0 +1,95 @@
+package spark
+
+import (
+	"fmt"
+
+	istio "istio.io/client-go/pkg/apis/networking/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/expose"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
+)
+
+// NewIngress generates an ingress that routes external traffic to the master
+// web UI. The master cluster port does not speak HTTP and is only exposed
+// through load balancer and node port services.
+func NewIngress(sc *dcv1alpha1.SparkCluster) *networkingv1.Ingress {
+	return expose.NewIngress(exposeInfo(sc))
+}
+
+// NewGateway generates an Istio gateway that accepts external traffic for the
+// master web UI.
+func NewGateway(sc *dcv1alpha1.SparkCluster) *istio.Gateway {
+	return expose.NewGateway(exposeInfo(sc))
+}
+
+// NewVirtualService generates an Istio virtual service that routes gateway
+// traffic to the master web UI.
+func NewVirtualService(sc *dcv1alpha1.SparkCluster) *istio.VirtualService {
+	return expose.NewVirtualService(exposeInfo(sc))
+}
+
+// ExposeObjectMeta returns the metadata shared by all of the objects used to
+// route external traffic into the cluster.
+func ExposeObjectMeta(sc *dcv1alpha1.SparkCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      InstanceObjectName(sc.Name, ComponentNone),
+		Namespace: sc.Namespace,
+	}
+}
+
+// ExposedURLs returns the external dashboard and client addresses of the
+// cluster. The address assigned to the ingress or load balancer service is
+// used when an endpoint does not have a host name. Node port services are
+// reached on the given node address and the allocated node ports.
+func ExposedURLs(sc *dcv1alpha1.SparkCluster, address string, nodePorts map[int32]int32) (dashboard, client string) {
+	cfg := sc.Spec.Expose
+	if cfg == nil {
+		return "", ""
+	}
+	dashboardEnabled := util.BoolPtrIsTrue(sc.Spec.EnableDashboard)
+
+	switch cfg.Type {
+	case dcv1alpha1.ExposeTypeIngress, dcv1alpha1.ExposeTypeIstioGateway:
+		if cfg.Dashboard != nil && dashboardEnabled {
+			dashboard = expose.HTTPURL(cfg, cfg.Dashboard, address)
+		}
+	case dcv1alpha1.ExposeTypeLoadBalancer, dcv1alpha1.ExposeTypeNodePort:
+		if address == "" {
+			return "", ""
+		}
+		if port := expose.ExternalPort(cfg, sc.Spec.DashboardPort, nodePorts); dashboardEnabled && port != 0 {
+			dashboard = fmt.Sprintf("http://%s:%d", address, port)
+		}
+		if port := expose.ExternalPort(cfg, sc.Spec.ClusterPort, nodePorts); port != 0 {
+			client = fmt.Sprintf("spark://%s:%d", address, port)
+		}
+	}
+
+	return dashboard, client
+}
+
+func exposeInfo(sc *dcv1alpha1.SparkCluster) *expose.Info {
+	cfg := sc.Spec.Expose
+
+	var routes []expose.Route
+	if cfg.Dashboard != nil && util.BoolPtrIsTrue(sc.Spec.EnableDashboard) {
+		routes = append(routes, expose.Route{
+			Name:     "dashboard",
+			Endpoint: cfg.Dashboard,
+			Service:  HeadServiceName(sc.Name),
+			Port:     sc.Spec.DashboardPort,
+		})
+	}
+
+	meta := ExposeObjectMeta(sc)
+	return &expose.Info{
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+		Labels:    MetadataLabels(sc),
+		Config:    cfg,
+		Routes:    routes,
+	}
+}
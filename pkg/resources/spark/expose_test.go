@@ -0,0 +1,86 @@
+package spark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewIngress(t *testing.T) {
+	sc := sparkClusterFixture()
+	sc.Spec.EnableDashboard = pointer.BoolPtr(true)
+	sc.Spec.Expose = &dcv1alpha1.ExposeConfig{
+		Type:      dcv1alpha1.ExposeTypeIngress,
+		Dashboard: &dcv1alpha1.ExposeEndpoint{Host: "spark.example.com", PathPrefix: "/ui"},
+	}
+
+	actual := NewIngress(sc)
+
+	assert.Equal(t, "test-id-spark", actual.Name)
+	assert.Equal(t, "fake-ns", actual.Namespace)
+	assert.Equal(t, MetadataLabels(sc), actual.Labels)
+	if assert.Len(t, actual.Spec.Rules, 1) {
+		path := actual.Spec.Rules[0].HTTP.Paths[0]
+		assert.Equal(t, "/ui", path.Path)
+		assert.Equal(t, "test-id-spark-master", path.Backend.Service.Name)
+		assert.Equal(t, int32(8265), path.Backend.Service.Port.Number)
+	}
+}
+
+func TestNewGatewayAndVirtualService(t *testing.T) {
+	sc := sparkClusterFixture()
+	sc.Spec.EnableDashboard = pointer.BoolPtr(true)
+	sc.Spec.Expose = &dcv1alpha1.ExposeConfig{
+		Type:      dcv1alpha1.ExposeTypeIstioGateway,
+		Dashboard: &dcv1alpha1.ExposeEndpoint{Host: "spark.example.com"},
+	}
+
+	gateway := NewGateway(sc)
+	vs := NewVirtualService(sc)
+
+	assert.Equal(t, "test-id-spark", gateway.Name)
+	assert.Equal(t, []string{"spark.example.com"}, gateway.Spec.Servers[0].Hosts)
+	assert.Equal(t, []string{gateway.Name}, vs.Spec.Gateways)
+	if assert.Len(t, vs.Spec.Http, 1) {
+		assert.Equal(t, "test-id-spark-master.fake-ns.svc.cluster.local", vs.Spec.Http[0].Route[0].Destination.Host)
+	}
+}
+
+func TestExposedURLs(t *testing.T) {
+	sc := sparkClusterFixture()
+	sc.Spec.EnableDashboard = pointer.BoolPtr(true)
+
+	t.Run("gateway", func(t *testing.T) {
+		sc.Spec.Expose = &dcv1alpha1.ExposeConfig{
+			Type:      dcv1alpha1.ExposeTypeIstioGateway,
+			Dashboard: &dcv1alpha1.ExposeEndpoint{Host: "spark.example.com"},
+		}
+
+		dashboard, client := ExposedURLs(sc, "", nil)
+		assert.Equal(t, "http://spark.example.com/", dashboard)
+		assert.Empty(t, client)
+	})
+
+	t.Run("load_balancer", func(t *testing.T) {
+		sc.Spec.Expose = &dcv1alpha1.ExposeConfig{Type: dcv1alpha1.ExposeTypeLoadBalancer}
+
+		dashboard, client := ExposedURLs(sc, "10.0.0.1", nil)
+		assert.Equal(t, "http://10.0.0.1:8265", dashboard)
+		assert.Equal(t, "spark://10.0.0.1:7077", client)
+	})
+
+	t.Run("node_port", func(t *testing.T) {
+		sc.Spec.Expose = &dcv1alpha1.ExposeConfig{Type: dcv1alpha1.ExposeTypeNodePort}
+
+		dashboard, client := ExposedURLs(sc, "10.0.0.1", map[int32]int32{8265: 30265, 7077: 30077})
+		assert.Equal(t, "http://10.0.0.1:30265", dashboard)
+		assert.Equal(t, "spark://10.0.0.1:30077", client)
+
+		dashboard, client = ExposedURLs(sc, "", map[int32]int32{8265: 30265, 7077: 30077})
+		assert.Empty(t, dashboard)
+		assert.Empty(t, client)
+	})
+}
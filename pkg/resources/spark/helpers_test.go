@@ -8,6 +8,9 @@ import (
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
 
+// testDefaults are the built-in operator defaults used by resource builders.
+var testDefaults = dcv1alpha1.NewClusterDefaults()
+
 // sparkClusterFixture should be used for all spark unit testing.
 func sparkClusterFixture() *dcv1alpha1.SparkCluster {
 	return &dcv1alpha1.SparkCluster{
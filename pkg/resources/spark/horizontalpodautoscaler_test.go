@@ -124,6 +124,23 @@ func TestNewHorizontalPodAutoscaler(t *testing.T) {
 		assert.Equal(t, expected, hpa.Spec.Behavior)
 	})
 
+	t.Run("scale_up_behavior", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.Autoscaling = &dcv1alpha1.Autoscaling{
+			ScaleUpStabilizationWindowSeconds: pointer.Int32Ptr(30),
+		}
+
+		hpa, err := NewHorizontalPodAutoscaler(rc)
+		require.NoError(t, err)
+
+		expected := &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{
+			ScaleUp: &autoscalingv2beta2.HPAScalingRules{
+				StabilizationWindowSeconds: pointer.Int32Ptr(30),
+			},
+		}
+		assert.Equal(t, expected, hpa.Spec.Behavior)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		rc := sparkClusterFixture()
 		_, err := NewHorizontalPodAutoscaler(rc)
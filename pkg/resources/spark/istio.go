@@ -0,0 +1,90 @@
+package spark
+
+import (
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashauth"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/istio"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+)
+
+// NewAuthorizationPolicy generates an Istio authorization policy that mirrors
+// the cluster network policies. Cluster nodes can reach one another on all
+// ports while the master cluster and dashboard ports are only reachable by
+// authorized principals and CIDRs. Prometheus is granted access to the web UI
+// port when monitoring is enabled, and workloads in the poller namespace are
+// granted access to the master status endpoint when it is not blank.
+func NewAuthorizationPolicy(sc *dcv1alpha1.SparkCluster, pollerNamespace string) *securityv1beta1.AuthorizationPolicy {
+	serviceAccount := InstanceObjectName(sc.Name, ComponentNone)
+	if sc.Spec.ServiceAccountName != "" {
+		serviceAccount = sc.Spec.ServiceAccountName
+	}
+
+	var namespaces []string
+	principals := sc.Spec.IstioConfig.AuthorizedPrincipals
+	if len(principals) == 0 {
+		namespaces = []string{sc.Namespace}
+	}
+
+	ports := []istio.AuthorizedPort{
+		{
+			Port:       sc.Spec.ClusterPort,
+			Principals: principals,
+			Namespaces: namespaces,
+			IPBlocks:   sc.Spec.NetworkPolicy.ClientServerCIDRs,
+		},
+	}
+	if sc.Spec.EnableDashboard != nil && *sc.Spec.EnableDashboard {
+		ports = append(ports, istio.AuthorizedPort{
+			Port:       DashboardTargetPort(sc),
+			Principals: principals,
+			Namespaces: namespaces,
+			IPBlocks:   sc.Spec.NetworkPolicy.DashboardCIDRs,
+		})
+	}
+	if cfg := sc.Spec.Monitoring; monitoring.Enabled(cfg) {
+		ports = append(ports, istio.MetricsPort(sc.Spec.DashboardPort, cfg.IstioPrincipals, sc.Namespace))
+		// the master is scraped through the authenticating proxy
+		if dashauth.Enabled(sc.Spec.DashboardAuth) {
+			ports = append(ports, istio.MetricsPort(DashboardTargetPort(sc), cfg.IstioPrincipals, sc.Namespace))
+		}
+	}
+	if pollerNamespace != "" {
+		ports = append(ports, istio.AuthorizedPort{
+			Port:       DashboardTargetPort(sc),
+			Namespaces: []string{pollerNamespace},
+		})
+	}
+
+	return istio.NewAuthorizationPolicy(&istio.AuthzPolicyInfo{
+		Name:      InstanceObjectName(sc.Name, ComponentNone),
+		Namespace: sc.Namespace,
+		Labels:    MetadataLabels(sc),
+		Selector:  SelectorLabels(sc),
+		Principal: istio.ServiceAccountPrincipal(sc.Namespace, serviceAccount),
+		Ports:     ports,
+	})
+}
+
+// SidecarExcludedPorts returns the worker, executor and driver ports that must
+// bypass sidecar interception so that client-mode drivers and executors can
+// connect to one another directly. No ports are excluded unless the driver
+// network policy is enabled.
+func SidecarExcludedPorts(sc *dcv1alpha1.SparkCluster) []int32 {
+	if !DriverNetworkPolicyEnabled(sc) {
+		return nil
+	}
+
+	driver := sc.Spec.NetworkPolicy.Driver
+	retries := portMaxRetries(driver)
+
+	ports := []int32{driver.WorkerPort}
+	for _, start := range []int32{driver.BlockManagerPort, driver.DriverPort, driver.DriverBlockManagerPort} {
+		for p := start; p <= start+retries; p++ {
+			ports = append(ports, p)
+		}
+	}
+
+	return ports
+}
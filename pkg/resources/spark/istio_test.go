@@ -0,0 +1,142 @@
+package spark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	"k8s.io/utils/pointer"
+)
+
+func TestNewAuthorizationPolicy(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		actual := NewAuthorizationPolicy(sc, "")
+
+		assert.Equal(t, "test-id-spark", actual.Name)
+		assert.Equal(t, "fake-ns", actual.Namespace)
+		assert.Equal(t, map[string]string{
+			"app.kubernetes.io/name":     "spark",
+			"app.kubernetes.io/instance": "test-id",
+		}, actual.Spec.Selector.MatchLabels)
+
+		expected := []*securityv1beta1.Rule{
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Principals: []string{"*/ns/fake-ns/sa/test-id-spark"}}},
+				},
+			},
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Namespaces: []string{"fake-ns"}}},
+				},
+				To: []*securityv1beta1.Rule_To{
+					{Operation: &securityv1beta1.Operation{Ports: []string{"7077"}}},
+				},
+			},
+		}
+		assert.Equal(t, expected, actual.Spec.Rules)
+	})
+
+	t.Run("authorized_principals_and_dashboard", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		sc.Spec.ServiceAccountName = "custom"
+		sc.Spec.EnableDashboard = pointer.BoolPtr(true)
+		sc.Spec.IstioConfig.AuthorizedPrincipals = []string{"cluster.local/ns/apps/sa/notebook"}
+		sc.Spec.NetworkPolicy.DashboardCIDRs = []string{"10.0.0.0/8"}
+		actual := NewAuthorizationPolicy(sc, "")
+
+		expected := []*securityv1beta1.Rule{
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Principals: []string{"*/ns/fake-ns/sa/custom"}}},
+				},
+			},
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Principals: []string{"cluster.local/ns/apps/sa/notebook"}}},
+				},
+				To: []*securityv1beta1.Rule_To{
+					{Operation: &securityv1beta1.Operation{Ports: []string{"7077"}}},
+				},
+			},
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Principals: []string{"cluster.local/ns/apps/sa/notebook"}}},
+					{Source: &securityv1beta1.Source{IpBlocks: []string{"10.0.0.0/8"}}},
+				},
+				To: []*securityv1beta1.Rule_To{
+					{Operation: &securityv1beta1.Operation{Ports: []string{"8265"}}},
+				},
+			},
+		}
+		assert.Equal(t, expected, actual.Spec.Rules)
+	})
+
+	t.Run("monitoring_and_poller", func(t *testing.T) {
+		sc := monitoringClusterFixture()
+		actual := NewAuthorizationPolicy(sc, "keda")
+
+		expected := []*securityv1beta1.Rule{
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Namespaces: []string{"fake-ns"}}},
+				},
+				To: []*securityv1beta1.Rule_To{
+					{Operation: &securityv1beta1.Operation{Ports: []string{"8265"}}},
+				},
+			},
+			{
+				From: []*securityv1beta1.Rule_From{
+					{Source: &securityv1beta1.Source{Namespaces: []string{"keda"}}},
+				},
+				To: []*securityv1beta1.Rule_To{
+					{Operation: &securityv1beta1.Operation{Ports: []string{"8265"}}},
+				},
+			},
+		}
+		assert.Len(t, actual.Spec.Rules, 4)
+		assert.Equal(t, expected, actual.Spec.Rules[2:])
+	})
+
+	t.Run("monitoring_principals", func(t *testing.T) {
+		sc := monitoringClusterFixture()
+		sc.Spec.Monitoring.IstioPrincipals = []string{"cluster.local/ns/monitoring/sa/prometheus"}
+		actual := NewAuthorizationPolicy(sc, "")
+
+		expected := &securityv1beta1.Rule{
+			From: []*securityv1beta1.Rule_From{
+				{Source: &securityv1beta1.Source{Principals: []string{"cluster.local/ns/monitoring/sa/prometheus"}}},
+			},
+			To: []*securityv1beta1.Rule_To{
+				{Operation: &securityv1beta1.Operation{Ports: []string{"8265"}}},
+			},
+		}
+		assert.Len(t, actual.Spec.Rules, 3)
+		assert.Equal(t, expected, actual.Spec.Rules[2])
+	})
+
+	t.Run("monitoring_dashboard_auth", func(t *testing.T) {
+		sc := dashboardAuthClusterFixture()
+		sc.Spec.Monitoring = monitoringClusterFixture().Spec.Monitoring
+		actual := NewAuthorizationPolicy(sc, "")
+
+		expected := &securityv1beta1.Rule{
+			From: []*securityv1beta1.Rule_From{
+				{Source: &securityv1beta1.Source{Namespaces: []string{"fake-ns"}}},
+			},
+			To: []*securityv1beta1.Rule_To{
+				{Operation: &securityv1beta1.Operation{Ports: []string{"4180"}}},
+			},
+		}
+		assert.Len(t, actual.Spec.Rules, 5)
+		assert.Equal(t, expected, actual.Spec.Rules[4])
+	})
+}
+
+func TestSidecarExcludedPorts(t *testing.T) {
+	assert.Nil(t, SidecarExcludedPorts(sparkClusterFixture()))
+
+	sc := driverClusterFixture()
+	assert.Equal(t, []int32{7078, 40200, 40201, 40000, 40001, 40100, 40101}, SidecarExcludedPorts(sc))
+}
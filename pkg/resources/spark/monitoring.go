@@ -0,0 +1,93 @@
+package spark
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/monitoring"
+)
+
+// MetricsPath is the path on the web UI port where the master and workers
+// serve Prometheus metrics.
+const MetricsPath = "/metrics/prometheus"
+
+// metricsConf enables the PrometheusServlet sink on the master and worker
+// web UIs. The master also serves application metrics, which must use a
+// separate path.
+var metricsConf = []string{
+	"spark.metrics.conf.*.sink.prometheusServlet.class=org.apache.spark.metrics.sink.PrometheusServlet",
+	"spark.metrics.conf.*.sink.prometheusServlet.path=" + MetricsPath,
+	"spark.metrics.conf.applications.sink.prometheusServlet.path=/metrics/applications/prometheus",
+}
+
+// NewMonitor generates a ServiceMonitor or PodMonitor that scrapes the
+// metrics served by the master and every worker.
+func NewMonitor(sc *dcv1alpha1.SparkCluster) *unstructured.Unstructured {
+	// pod monitors scrape the web UI container port directly
+	port := monitoring.PortName
+	if sc.Spec.Monitoring.Kind == dcv1alpha1.MonitorKindPodMonitor {
+		port = "http"
+	}
+
+	return monitoring.NewMonitor(&monitoring.MonitorInfo{
+		Name:      MonitorName(sc.Name),
+		Namespace: sc.Namespace,
+		Labels:    MetadataLabels(sc),
+		Selector:  SelectorLabels(sc),
+		Port:      port,
+		Path:      MetricsPath,
+		Config:    sc.Spec.Monitoring,
+	})
+}
+
+// MonitorName returns the name of the cluster scrape object.
+func MonitorName(name string) string {
+	return InstanceObjectName(name, Component("metrics"))
+}
+
+// MonitorReference returns a shallow scrape object of the given kind that can
+// be used to delete an existing object.
+func MonitorReference(sc *dcv1alpha1.SparkCluster, kind dcv1alpha1.MonitorKind) *unstructured.Unstructured {
+	return monitoring.NewMonitorReference(MonitorName(sc.Name), sc.Namespace, kind)
+}
+
+// monitoringEnvVars passes the metrics configuration to the master and worker
+// daemons as java system properties. Users that provide their own
+// SPARK_DAEMON_JAVA_OPTS must include these properties themselves.
+func monitoringEnvVars(sc *dcv1alpha1.SparkCluster) []corev1.EnvVar {
+	if !monitoring.Enabled(sc.Spec.Monitoring) {
+		return nil
+	}
+
+	opts := make([]string, len(metricsConf))
+	for idx, conf := range metricsConf {
+		opts[idx] = "-D" + conf
+	}
+
+	return []corev1.EnvVar{
+		{
+			Name:  "SPARK_DAEMON_JAVA_OPTS",
+			Value: strings.Join(opts, " "),
+		},
+	}
+}
+
+// metricsServicePorts publishes the web UI port under the metrics port name.
+func metricsServicePorts(sc *dcv1alpha1.SparkCluster) []corev1.ServicePort {
+	if !monitoring.Enabled(sc.Spec.Monitoring) {
+		return nil
+	}
+
+	return []corev1.ServicePort{
+		{
+			Name:       monitoring.PortName,
+			Port:       sc.Spec.DashboardPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromString("http"),
+		},
+	}
+}
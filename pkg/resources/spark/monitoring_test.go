@@ -0,0 +1,100 @@
+package spark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func monitoringClusterFixture() *dcv1alpha1.SparkCluster {
+	sc := sparkClusterFixture()
+	sc.Spec.Monitoring = &dcv1alpha1.MonitoringConfig{
+		Enabled:   pointer.BoolPtr(true),
+		Kind:      dcv1alpha1.MonitorKindServiceMonitor,
+		PodLabels: map[string]string{"app": "prometheus"},
+	}
+
+	return sc
+}
+
+func TestNewMonitor(t *testing.T) {
+	testcases := []struct {
+		kind         dcv1alpha1.MonitorKind
+		endpointsKey string
+		port         string
+	}{
+		{dcv1alpha1.MonitorKindServiceMonitor, "endpoints", "http-metrics"},
+		{dcv1alpha1.MonitorKindPodMonitor, "podMetricsEndpoints", "http"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(string(tc.kind), func(t *testing.T) {
+			sc := monitoringClusterFixture()
+			sc.Spec.Monitoring.Kind = tc.kind
+
+			actual := NewMonitor(sc)
+			assert.Equal(t, string(tc.kind), actual.GetKind())
+			assert.Equal(t, "test-id-spark-metrics", actual.GetName())
+
+			endpoints, _, err := unstructured.NestedSlice(actual.Object, "spec", tc.endpointsKey)
+			require.NoError(t, err)
+			assert.Equal(t, []interface{}{
+				map[string]interface{}{"port": tc.port, "path": "/metrics/prometheus"},
+			}, endpoints)
+		})
+	}
+}
+
+func TestMonitoringEnvVars(t *testing.T) {
+	sc := monitoringClusterFixture()
+
+	sts, err := NewStatefulSet(sc, ComponentMaster, testDefaults.Spark)
+	require.NoError(t, err)
+
+	env := sts.Spec.Template.Spec.Containers[0].Env
+	require.NotEmpty(t, env)
+	assert.Equal(t, "SPARK_DAEMON_JAVA_OPTS", env[len(env)-1].Name)
+	assert.Contains(t, env[len(env)-1].Value,
+		"-Dspark.metrics.conf.*.sink.prometheusServlet.class=org.apache.spark.metrics.sink.PrometheusServlet")
+
+	sc.Spec.Monitoring.Enabled = pointer.BoolPtr(false)
+	assert.Empty(t, monitoringEnvVars(sc))
+}
+
+func TestMonitoringServicePorts(t *testing.T) {
+	sc := monitoringClusterFixture()
+
+	assert.Equal(t, []corev1.ServicePort{
+		{
+			Name:       "http-metrics",
+			Port:       8265,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromString("http"),
+		},
+	}, NewHeadlessService(sc).Spec.Ports)
+
+	sc.Spec.Monitoring = nil
+	assert.Empty(t, NewHeadlessService(sc).Spec.Ports)
+}
+
+func TestNewMonitoringNetworkPolicy(t *testing.T) {
+	sc := monitoringClusterFixture()
+	actual := NewMonitoringNetworkPolicy(sc)
+
+	port := intstr.FromString("http")
+	assert.Equal(t, "test-id-spark-metrics", actual.Name)
+	assert.Equal(t, SelectorLabels(sc), actual.Spec.PodSelector.MatchLabels)
+	require.Len(t, actual.Spec.Ingress, 1)
+	assert.Equal(t, &port, actual.Spec.Ingress[0].Ports[0].Port)
+	assert.Equal(t, map[string]string{"app": "prometheus"}, actual.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+
+	sc.Spec.Monitoring.PodLabels = nil
+	assert.Empty(t, NewMonitoringNetworkPolicy(sc).Spec.Ingress, "monitoring pods must be selected explicitly")
+}
@@ -8,16 +8,24 @@ import (
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/resources"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/expose"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/netpol"
 )
 
 const (
 	descriptionCluster   = "Allows all ingress traffic between cluster nodes"
 	descriptionClient    = "Allows client ingress traffic to head client server port"
 	descriptionDashboard = "Allows client ingress traffic to head dashboard port"
+	descriptionStatus    = "Allows autoscaler ingress traffic to head dashboard port"
+	descriptionEgress    = "Restricts egress traffic from cluster nodes"
+	descriptionExecutor  = "Allows client-mode driver ingress traffic to worker and executor ports"
+	descriptionDriver    = "Allows executor ingress traffic to client-mode driver ports"
+	descriptionMetrics   = "Allows monitoring ingress traffic to node metrics ports"
 )
 
 // NewClusterNetworkPolicy generates a network policy that allows all nodes
-// within a single cluster to communicate on all ports.
+// within a single cluster to communicate on all ports. Any additional ingress
+// rules are applied to all cluster nodes.
 func NewClusterNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkPolicy {
 	labelSelector := metav1.LabelSelector{
 		MatchLabels: SelectorLabels(sc),
@@ -34,7 +42,7 @@ func NewClusterNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkP
 		},
 		Spec: networkingv1.NetworkPolicySpec{
 			PodSelector: labelSelector,
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
+			Ingress: append([]networkingv1.NetworkPolicyIngressRule{
 				{
 					From: []networkingv1.NetworkPolicyPeer{
 						{
@@ -42,7 +50,7 @@ func NewClusterNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkP
 						},
 					},
 				},
-			},
+			}, sc.Spec.NetworkPolicy.IngressRules...),
 			PolicyTypes: []networkingv1.PolicyType{
 				networkingv1.PolicyTypeIngress,
 			},
@@ -52,12 +60,17 @@ func NewClusterNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkP
 
 // NewHeadClientNetworkPolicy generates a network policy that allows client
 // access to any pods that have been appointed with the configured client
-// server labels.
+// server labels, namespace labels and CIDRs, and to the exposure peers when
+// the client port is exposed.
 func NewHeadClientNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkPolicy {
 	return headNetworkPolicy(
 		sc,
 		sc.Spec.ClusterPort,
-		sc.Spec.NetworkPolicy.ClientServerLabels,
+		append(netpol.Peers(
+			sc.Spec.NetworkPolicy.ClientServerLabels,
+			sc.Spec.NetworkPolicy.ClientServerNamespaceLabels,
+			sc.Spec.NetworkPolicy.ClientServerCIDRs,
+		), expose.ClientPeers(sc.Spec.Expose)...),
 		"client",
 		descriptionClient,
 	)
@@ -65,18 +78,96 @@ func NewHeadClientNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.Netwo
 
 // NewHeadDashboardNetworkPolicy generates a network policy that allows
 // dashboard access to any pods that have been appointed with configured
-// dashboard labels.
+// dashboard labels, namespace labels and CIDRs, and to the exposure peers
+// when the dashboard is exposed.
 func NewHeadDashboardNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkPolicy {
 	return headNetworkPolicy(
 		sc,
-		sc.Spec.DashboardPort,
-		sc.Spec.NetworkPolicy.DashboardLabels,
+		DashboardTargetPort(sc),
+		append(netpol.Peers(
+			sc.Spec.NetworkPolicy.DashboardLabels,
+			sc.Spec.NetworkPolicy.DashboardNamespaceLabels,
+			sc.Spec.NetworkPolicy.DashboardCIDRs,
+		), expose.DashboardPeers(sc.Spec.Expose)...),
 		"dashboard",
 		descriptionDashboard,
 	)
 }
 
-func headNetworkPolicy(sc *dcv1alpha1.SparkCluster, p int32, l map[string]string, c Component, desc string) *networkingv1.NetworkPolicy {
+// MasterStatusPollerNamespace returns the namespace of the autoscaler that
+// polls the master JSON status endpoint. This is the operator namespace for
+// the "spark-master" backend and the KEDA namespace for the default KEDA
+// trigger. An empty string is returned when the endpoint is not polled.
+func MasterStatusPollerNamespace(sc *dcv1alpha1.SparkCluster, operatorNamespace, kedaNamespace string) string {
+	as := sc.Spec.Autoscaling
+	if as == nil {
+		return ""
+	}
+
+	switch {
+	case as.Backend == dcv1alpha1.AutoscalingBackendSparkMaster:
+		return operatorNamespace
+	case as.Backend == dcv1alpha1.AutoscalingBackendKEDA && MasterMetricsTriggerEnabled(sc):
+		return kedaNamespace
+	default:
+		return ""
+	}
+}
+
+// NewMasterStatusNetworkPolicy generates a network policy that allows all pods
+// in the given namespace to reach the head dashboard port, which serves the
+// master JSON status endpoint polled by autoscalers. The namespace is selected
+// by the name label that the API server sets on Kubernetes 1.21+.
+func NewMasterStatusNetworkPolicy(sc *dcv1alpha1.SparkCluster, namespace string) *networkingv1.NetworkPolicy {
+	return headNetworkPolicy(sc, DashboardTargetPort(sc), netpol.NamespacePeers(namespace), "status", descriptionStatus)
+}
+
+// NewMonitoringNetworkPolicy generates a network policy that allows the
+// configured monitoring pods to scrape the web UI port of all cluster nodes,
+// which serves the metrics endpoint. The port is addressed by name so that
+// the master is scraped through the authenticating proxy when dashboard auth
+// is enabled. Nothing is admitted unless monitoring pods are selected.
+func NewMonitoringNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkPolicy {
+	var ingress []networkingv1.NetworkPolicyIngressRule
+	if cfg := sc.Spec.Monitoring; cfg != nil && (len(cfg.PodLabels) > 0 || len(cfg.NamespaceLabels) > 0) {
+		proto := corev1.ProtocolTCP
+		port := intstr.FromString("http")
+		ingress = []networkingv1.NetworkPolicyIngressRule{
+			{
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &proto, Port: &port}},
+				From:  netpol.Peers(cfg.PodLabels, cfg.NamespaceLabels, nil),
+			},
+		}
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      InstanceObjectName(sc.Name, Component("metrics")),
+			Namespace: sc.Namespace,
+			Labels:    MetadataLabels(sc),
+			Annotations: map[string]string{
+				resources.DescriptionAnnotationKey: descriptionMetrics,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: SelectorLabels(sc),
+			},
+			Ingress: ingress,
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+		},
+	}
+}
+
+func headNetworkPolicy(
+	sc *dcv1alpha1.SparkCluster,
+	p int32,
+	from []networkingv1.NetworkPolicyPeer,
+	c Component,
+	desc string,
+) *networkingv1.NetworkPolicy {
 	proto := corev1.ProtocolTCP
 	targetPort := intstr.FromInt(int(p))
 
@@ -101,10 +192,138 @@ func headNetworkPolicy(sc *dcv1alpha1.SparkCluster, p int32, l map[string]string
 							Port:     &targetPort,
 						},
 					},
+					From: from,
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+		},
+	}
+}
+
+// NewEgressNetworkPolicy generates a network policy that restricts outbound
+// traffic from all cluster nodes to the configured egress destinations.
+func NewEgressNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkPolicy {
+	labelSelector := metav1.LabelSelector{
+		MatchLabels: SelectorLabels(sc),
+	}
+
+	var rules []networkingv1.NetworkPolicyEgressRule
+	if cfg := sc.Spec.NetworkPolicy.Egress; cfg != nil {
+		rules = netpol.EgressRules(cfg, labelSelector)
+	}
+	if DriverNetworkPolicyEnabled(sc) {
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+			Ports: driverPorts(sc.Spec.NetworkPolicy.Driver),
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: sc.Spec.NetworkPolicy.Driver.DriverLabels,
+					},
+				},
+			},
+		})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      InstanceObjectName(sc.Name, Component("egress")),
+			Namespace: sc.Namespace,
+			Labels:    MetadataLabels(sc),
+			Annotations: map[string]string{
+				resources.DescriptionAnnotationKey: descriptionEgress,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: labelSelector,
+			Egress:      rules,
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeEgress,
+			},
+		},
+	}
+}
+
+// DriverNetworkPolicyEnabled returns true when connectivity between
+// client-mode drivers and cluster workers has been requested. Driver labels
+// are required because an empty pod selector would isolate every pod in the
+// cluster namespace.
+func DriverNetworkPolicyEnabled(sc *dcv1alpha1.SparkCluster) bool {
+	driver := sc.Spec.NetworkPolicy.Driver
+	return driver != nil && driver.Enabled != nil && *driver.Enabled && len(driver.DriverLabels) > 0
+}
+
+// NewExecutorNetworkPolicy generates a network policy that allows client-mode
+// drivers to reach the worker port and the executor block manager ports on
+// all worker pods.
+func NewExecutorNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkPolicy {
+	driver := sc.Spec.NetworkPolicy.Driver
+	proto := corev1.ProtocolTCP
+	workerPort := intstr.FromInt(int(driver.WorkerPort))
+
+	ports := []networkingv1.NetworkPolicyPort{
+		{
+			Protocol: &proto,
+			Port:     &workerPort,
+		},
+	}
+	ports = append(ports, netpol.PortRange(driver.BlockManagerPort, portMaxRetries(driver))...)
+
+	om := ExecutorNetworkPolicyObjectMeta(sc)
+	om.Labels = MetadataLabelsWithComponent(sc, ComponentWorker)
+	om.Annotations = map[string]string{
+		resources.DescriptionAnnotationKey: descriptionExecutor,
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: om,
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: SelectorLabelsWithComponent(sc, ComponentWorker),
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: ports,
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: sc.Spec.NetworkPolicy.Driver.DriverLabels,
+							},
+						},
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+		},
+	}
+}
+
+// NewDriverNetworkPolicy generates a network policy that allows executors
+// running on worker pods to connect back to the driver and driver block
+// manager ports of client-mode driver pods.
+func NewDriverNetworkPolicy(sc *dcv1alpha1.SparkCluster) *networkingv1.NetworkPolicy {
+	om := DriverNetworkPolicyObjectMeta(sc)
+	om.Labels = MetadataLabels(sc)
+	om.Annotations = map[string]string{
+		resources.DescriptionAnnotationKey: descriptionDriver,
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: om,
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: sc.Spec.NetworkPolicy.Driver.DriverLabels,
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: driverPorts(sc.Spec.NetworkPolicy.Driver),
 					From: []networkingv1.NetworkPolicyPeer{
 						{
 							PodSelector: &metav1.LabelSelector{
-								MatchLabels: l,
+								MatchLabels: SelectorLabelsWithComponent(sc, ComponentWorker),
 							},
 						},
 					},
@@ -116,3 +335,38 @@ func headNetworkPolicy(sc *dcv1alpha1.SparkCluster, p int32, l map[string]string
 		},
 	}
 }
+
+// ExecutorNetworkPolicyObjectMeta returns the object metadata used to identify
+// the executor network policy.
+func ExecutorNetworkPolicyObjectMeta(sc *dcv1alpha1.SparkCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      InstanceObjectName(sc.Name, Component("executor")),
+		Namespace: sc.Namespace,
+	}
+}
+
+// DriverNetworkPolicyObjectMeta returns the object metadata used to identify
+// the driver network policy.
+func DriverNetworkPolicyObjectMeta(sc *dcv1alpha1.SparkCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      InstanceObjectName(sc.Name, Component("driver")),
+		Namespace: sc.Namespace,
+	}
+}
+
+func driverPorts(driver *dcv1alpha1.SparkDriverNetworkPolicy) []networkingv1.NetworkPolicyPort {
+	retries := portMaxRetries(driver)
+
+	return append(
+		netpol.PortRange(driver.DriverPort, retries),
+		netpol.PortRange(driver.DriverBlockManagerPort, retries)...,
+	)
+}
+
+func portMaxRetries(driver *dcv1alpha1.SparkDriverNetworkPolicy) int32 {
+	if driver.PortMaxRetries == nil {
+		return 0
+	}
+
+	return *driver.PortMaxRetries
+}
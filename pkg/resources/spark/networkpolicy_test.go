@@ -8,6 +8,7 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 
 	"github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
@@ -142,3 +143,390 @@ func getNetworkPolicy(tcpProto v1.Protocol, clusterPort intstr.IntOrString, name
 		},
 	}
 }
+
+func TestNewClusterNetworkPolicyIngressRules(t *testing.T) {
+	rc := sparkClusterFixture()
+	rule := networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{
+			{
+				IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"},
+			},
+		},
+	}
+	rc.Spec.NetworkPolicy.IngressRules = []networkingv1.NetworkPolicyIngressRule{rule}
+
+	netpol := NewClusterNetworkPolicy(rc)
+	assert.Len(t, netpol.Spec.Ingress, 2)
+	assert.Equal(t, rule, netpol.Spec.Ingress[1])
+}
+
+func TestNewHeadClientNetworkPolicyExternalPeers(t *testing.T) {
+	rc := sparkClusterFixture()
+	rc.Spec.NetworkPolicy = v1alpha1.SparkClusterNetworkPolicy{
+		ClientServerLabels:          map[string]string{"server-client": "true"},
+		ClientServerNamespaceLabels: map[string]string{"team": "ml"},
+		ClientServerCIDRs:           []string{"192.168.0.0/16"},
+	}
+	netpol := NewHeadClientNetworkPolicy(rc)
+
+	expected := []networkingv1.NetworkPolicyPeer{
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"server-client": "true"},
+			},
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "ml"},
+			},
+		},
+		{
+			IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.0/16"},
+		},
+	}
+	assert.Equal(t, expected, netpol.Spec.Ingress[0].From)
+}
+
+func TestNewHeadDashboardNetworkPolicyExposurePeers(t *testing.T) {
+	rc := sparkClusterFixture()
+	rc.Spec.NetworkPolicy = v1alpha1.SparkClusterNetworkPolicy{
+		DashboardLabels: map[string]string{"dashboard-client": "true"},
+	}
+	rc.Spec.Expose = &v1alpha1.ExposeConfig{
+		Type:            v1alpha1.ExposeTypeIngress,
+		Dashboard:       &v1alpha1.ExposeEndpoint{Host: "spark.example.com"},
+		NamespaceLabels: map[string]string{"ingress": "true"},
+	}
+	netpol := NewHeadDashboardNetworkPolicy(rc)
+
+	expected := []networkingv1.NetworkPolicyPeer{
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"dashboard-client": "true"},
+			},
+		},
+		{
+			PodSelector: &metav1.LabelSelector{},
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"ingress": "true"},
+			},
+		},
+	}
+	assert.Equal(t, expected, netpol.Spec.Ingress[0].From)
+
+	client := NewHeadClientNetworkPolicy(rc).Spec.Ingress[0].From
+	assert.NotContains(t, client, expected[1], "client policy should not admit peers when the client port is not exposed")
+}
+
+func TestNewEgressNetworkPolicy(t *testing.T) {
+	rc := sparkClusterFixture()
+	rc.Spec.NetworkPolicy.Egress = &v1alpha1.NetworkPolicyEgress{
+		Enabled:  pointer.BoolPtr(true),
+		AllowDNS: pointer.BoolPtr(false),
+		CIDRs:    []string{"52.216.0.0/15"},
+	}
+	netpol := NewEgressNetworkPolicy(rc)
+
+	expected := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-id-spark-egress",
+			Namespace: "fake-ns",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "spark",
+				"app.kubernetes.io/instance":   "test-id",
+				"app.kubernetes.io/version":    "fake-tag",
+				"app.kubernetes.io/managed-by": "distributed-compute-operator",
+			},
+			Annotations: map[string]string{
+				"distributed-compute.dominodatalab.com/description": "Restricts egress traffic from cluster nodes",
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":     "spark",
+					"app.kubernetes.io/instance": "test-id",
+				},
+			},
+			Egress: []networkingv1.NetworkPolicyEgressRule{
+				{
+					To: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"app.kubernetes.io/name":     "spark",
+									"app.kubernetes.io/instance": "test-id",
+								},
+							},
+						},
+					},
+				},
+				{
+					To: []networkingv1.NetworkPolicyPeer{
+						{
+							IPBlock: &networkingv1.IPBlock{CIDR: "52.216.0.0/15"},
+						},
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				"Egress",
+			},
+		},
+	}
+	assert.Equal(t, expected, netpol)
+}
+
+func driverClusterFixture() *v1alpha1.SparkCluster {
+	sc := sparkClusterFixture()
+	sc.Spec.NetworkPolicy = v1alpha1.SparkClusterNetworkPolicy{
+		ClientServerLabels: map[string]string{"spark-client": "true"},
+		Driver: &v1alpha1.SparkDriverNetworkPolicy{
+			Enabled:                pointer.BoolPtr(true),
+			DriverLabels:           map[string]string{"spark-driver": "true"},
+			WorkerPort:             7078,
+			DriverPort:             40000,
+			DriverBlockManagerPort: 40100,
+			BlockManagerPort:       40200,
+			PortMaxRetries:         pointer.Int32Ptr(1),
+		},
+	}
+
+	return sc
+}
+
+func tcpPorts(ports ...int) []networkingv1.NetworkPolicyPort {
+	tcpProto := v1.ProtocolTCP
+
+	var npp []networkingv1.NetworkPolicyPort
+	for _, p := range ports {
+		port := intstr.FromInt(p)
+		npp = append(npp, networkingv1.NetworkPolicyPort{Protocol: &tcpProto, Port: &port})
+	}
+
+	return npp
+}
+
+func TestDriverNetworkPolicyEnabled(t *testing.T) {
+	sc := sparkClusterFixture()
+	assert.False(t, DriverNetworkPolicyEnabled(sc))
+
+	sc.Spec.NetworkPolicy.Driver = &v1alpha1.SparkDriverNetworkPolicy{}
+	assert.False(t, DriverNetworkPolicyEnabled(sc))
+
+	sc.Spec.NetworkPolicy.Driver.Enabled = pointer.BoolPtr(true)
+	assert.False(t, DriverNetworkPolicyEnabled(sc))
+
+	sc.Spec.NetworkPolicy.Driver.DriverLabels = map[string]string{"spark-driver": "true"}
+	assert.True(t, DriverNetworkPolicyEnabled(sc))
+}
+
+func TestDriverNetworkPolicyClientServerLabels(t *testing.T) {
+	sc := driverClusterFixture()
+	sc.Spec.NetworkPolicy.Driver.DriverLabels = nil
+	sc.Spec.NetworkPolicy.Egress = &v1alpha1.NetworkPolicyEgress{
+		Enabled:  pointer.BoolPtr(true),
+		AllowDNS: pointer.BoolPtr(false),
+	}
+
+	assert.False(t, DriverNetworkPolicyEnabled(sc), "client server labels must not select driver pods")
+	assert.Len(t, NewEgressNetworkPolicy(sc).Spec.Egress, 1)
+}
+
+func TestNewExecutorNetworkPolicy(t *testing.T) {
+	sc := driverClusterFixture()
+	netpol := NewExecutorNetworkPolicy(sc)
+
+	expected := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-id-spark-executor",
+			Namespace: "fake-ns",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "spark",
+				"app.kubernetes.io/instance":   "test-id",
+				"app.kubernetes.io/component":  "worker",
+				"app.kubernetes.io/version":    "fake-tag",
+				"app.kubernetes.io/managed-by": "distributed-compute-operator",
+			},
+			Annotations: map[string]string{
+				"distributed-compute.dominodatalab.com/description": "Allows client-mode driver ingress traffic to worker and executor ports",
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":      "spark",
+					"app.kubernetes.io/instance":  "test-id",
+					"app.kubernetes.io/component": "worker",
+				},
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: tcpPorts(7078, 40200, 40201),
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"spark-driver": "true"},
+							},
+						},
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				"Ingress",
+			},
+		},
+	}
+	assert.Equal(t, expected, netpol)
+}
+
+func TestNewDriverNetworkPolicy(t *testing.T) {
+	sc := driverClusterFixture()
+	netpol := NewDriverNetworkPolicy(sc)
+
+	expected := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-id-spark-driver",
+			Namespace: "fake-ns",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "spark",
+				"app.kubernetes.io/instance":   "test-id",
+				"app.kubernetes.io/version":    "fake-tag",
+				"app.kubernetes.io/managed-by": "distributed-compute-operator",
+			},
+			Annotations: map[string]string{
+				"distributed-compute.dominodatalab.com/description": "Allows executor ingress traffic to client-mode driver ports",
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"spark-driver": "true"},
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: tcpPorts(40000, 40001, 40100, 40101),
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"app.kubernetes.io/name":      "spark",
+									"app.kubernetes.io/instance":  "test-id",
+									"app.kubernetes.io/component": "worker",
+								},
+							},
+						},
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				"Ingress",
+			},
+		},
+	}
+	assert.Equal(t, expected, netpol)
+}
+
+func TestNewEgressNetworkPolicyDriver(t *testing.T) {
+	sc := driverClusterFixture()
+	sc.Spec.NetworkPolicy.Egress = &v1alpha1.NetworkPolicyEgress{
+		Enabled:  pointer.BoolPtr(true),
+		AllowDNS: pointer.BoolPtr(false),
+	}
+	netpol := NewEgressNetworkPolicy(sc)
+
+	expected := networkingv1.NetworkPolicyEgressRule{
+		Ports: tcpPorts(40000, 40001, 40100, 40101),
+		To: []networkingv1.NetworkPolicyPeer{
+			{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"spark-driver": "true"},
+				},
+			},
+		},
+	}
+	assert.Len(t, netpol.Spec.Egress, 2)
+	assert.Equal(t, expected, netpol.Spec.Egress[1])
+}
+
+func TestMasterStatusPollerNamespace(t *testing.T) {
+	testcases := []struct {
+		name        string
+		autoscaling *v1alpha1.Autoscaling
+		expected    string
+	}{
+		{
+			name:     "no_autoscaling",
+			expected: "",
+		},
+		{
+			name:        "hpa",
+			autoscaling: &v1alpha1.Autoscaling{Backend: v1alpha1.AutoscalingBackendHPA},
+			expected:    "",
+		},
+		{
+			name:        "spark_master",
+			autoscaling: &v1alpha1.Autoscaling{Backend: v1alpha1.AutoscalingBackendSparkMaster},
+			expected:    "dco",
+		},
+		{
+			name:        "keda_default_trigger",
+			autoscaling: &v1alpha1.Autoscaling{Backend: v1alpha1.AutoscalingBackendKEDA},
+			expected:    "keda",
+		},
+		{
+			name: "keda_custom_triggers",
+			autoscaling: &v1alpha1.Autoscaling{
+				Backend: v1alpha1.AutoscalingBackendKEDA,
+				KEDA: &v1alpha1.KEDAConfig{
+					Triggers: []v1alpha1.KEDATrigger{{Type: "cpu"}},
+				},
+			},
+			expected: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc := sparkClusterFixture()
+			sc.Spec.Autoscaling = tc.autoscaling
+
+			assert.Equal(t, tc.expected, MasterStatusPollerNamespace(sc, "dco", "keda"))
+		})
+	}
+}
+
+func TestNewMasterStatusNetworkPolicy(t *testing.T) {
+	sc := sparkClusterFixture()
+	netpol := NewMasterStatusNetworkPolicy(sc, "keda")
+
+	assert.Equal(t, "test-id-spark-status", netpol.Name)
+	assert.Equal(t, "fake-ns", netpol.Namespace)
+	assert.Equal(t, "Allows autoscaler ingress traffic to head dashboard port",
+		netpol.Annotations["distributed-compute.dominodatalab.com/description"])
+	assert.Equal(t, map[string]string{
+		"app.kubernetes.io/name":      "spark",
+		"app.kubernetes.io/instance":  "test-id",
+		"app.kubernetes.io/component": "master",
+	}, netpol.Spec.PodSelector.MatchLabels)
+
+	tcpProto := v1.ProtocolTCP
+	dashboardPort := intstr.FromInt(8265)
+	expected := []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Protocol: &tcpProto,
+					Port:     &dashboardPort,
+				},
+			},
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"kubernetes.io/metadata.name": "keda",
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, netpol.Spec.Ingress)
+}
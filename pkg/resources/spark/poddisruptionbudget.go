@@ -0,0 +1,53 @@
+package spark
+
+import (
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+// NewPodDisruptionBudgets generates disruption budgets for the head and worker
+// pods of a SparkCluster. The head budget does not allow any voluntary evictions
+// while the worker budget uses the limits provided in the spec.
+func NewPodDisruptionBudgets(sc *dcv1alpha1.SparkCluster) (head, worker *policyv1beta1.PodDisruptionBudget) {
+	headMaxUnavailable := intstr.FromInt(0)
+	head = podDisruptionBudget(sc, ComponentMaster)
+	head.Spec.MaxUnavailable = &headMaxUnavailable
+
+	worker = podDisruptionBudget(sc, ComponentWorker)
+	cfg := sc.Spec.PodDisruptionBudget
+	switch {
+	case cfg.MinAvailable != nil:
+		worker.Spec.MinAvailable = cfg.MinAvailable
+	case cfg.MaxUnavailable != nil:
+		worker.Spec.MaxUnavailable = cfg.MaxUnavailable
+	default:
+		workerMaxUnavailable := intstr.FromInt(1)
+		worker.Spec.MaxUnavailable = &workerMaxUnavailable
+	}
+
+	return head, worker
+}
+
+// PodDisruptionBudgetObjectMeta returns the ObjectMeta used to identify the
+// disruption budget for a component.
+func PodDisruptionBudgetObjectMeta(sc *dcv1alpha1.SparkCluster, comp Component) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      InstanceObjectName(sc.Name, comp),
+		Namespace: sc.Namespace,
+		Labels:    MetadataLabelsWithComponent(sc, comp),
+	}
+}
+
+func podDisruptionBudget(sc *dcv1alpha1.SparkCluster, comp Component) *policyv1beta1.PodDisruptionBudget {
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: PodDisruptionBudgetObjectMeta(sc, comp),
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: SelectorLabelsWithComponent(sc, comp),
+			},
+		},
+	}
+}
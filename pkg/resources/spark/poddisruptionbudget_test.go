@@ -0,0 +1,73 @@
+package spark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNewPodDisruptionBudgets(t *testing.T) {
+	t.Run("head", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		head, _ := NewPodDisruptionBudgets(sc)
+
+		maxUnavailable := intstr.FromInt(0)
+		expected := &policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-id-spark-master",
+				Namespace: "fake-ns",
+				Labels: map[string]string{
+					"app.kubernetes.io/name":       "spark",
+					"app.kubernetes.io/instance":   "test-id",
+					"app.kubernetes.io/component":  "master",
+					"app.kubernetes.io/version":    "fake-tag",
+					"app.kubernetes.io/managed-by": "distributed-compute-operator",
+				},
+			},
+			Spec: policyv1beta1.PodDisruptionBudgetSpec{
+				MaxUnavailable: &maxUnavailable,
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"app.kubernetes.io/name":      "spark",
+						"app.kubernetes.io/instance":  "test-id",
+						"app.kubernetes.io/component": "master",
+					},
+				},
+			},
+		}
+		assert.Equal(t, expected, head)
+	})
+
+	t.Run("worker_default", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		_, worker := NewPodDisruptionBudgets(sc)
+
+		maxUnavailable := intstr.FromInt(1)
+		assert.Equal(t, "test-id-spark-worker", worker.Name)
+		assert.Equal(t, &maxUnavailable, worker.Spec.MaxUnavailable)
+		assert.Nil(t, worker.Spec.MinAvailable)
+	})
+
+	t.Run("worker_min_available", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		minAvailable := intstr.FromString("50%")
+		sc.Spec.PodDisruptionBudget.MinAvailable = &minAvailable
+		_, worker := NewPodDisruptionBudgets(sc)
+
+		assert.Equal(t, &minAvailable, worker.Spec.MinAvailable)
+		assert.Nil(t, worker.Spec.MaxUnavailable)
+	})
+
+	t.Run("worker_max_unavailable", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		maxUnavailable := intstr.FromInt(2)
+		sc.Spec.PodDisruptionBudget.MaxUnavailable = &maxUnavailable
+		_, worker := NewPodDisruptionBudgets(sc)
+
+		assert.Equal(t, &maxUnavailable, worker.Spec.MaxUnavailable)
+		assert.Nil(t, worker.Spec.MinAvailable)
+	})
+}
@@ -0,0 +1,53 @@
+package spark
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+)
+
+// NewPodGroup generates a PodGroup that requires the head and the minimum
+// number of workers of a SparkCluster to be scheduled at the same time.
+func NewPodGroup(sc *dcv1alpha1.SparkCluster) (*unstructured.Unstructured, error) {
+	if !podgroup.Enabled(sc.Spec.Scheduling) {
+		return nil, fmt.Errorf("cannot build pod group without gang scheduling config")
+	}
+
+	return podgroup.NewPodGroup(&podgroup.PodGroupInfo{
+		Name:       PodGroupName(sc.Name),
+		Namespace:  sc.Namespace,
+		Labels:     MetadataLabels(sc),
+		MinMember:  1 + minWorkerReplicas(sc),
+		Scheduling: sc.Spec.Scheduling,
+	}), nil
+}
+
+// PodGroupReference returns a shallow PodGroup of the given flavor used to
+// identify existing objects.
+func PodGroupReference(sc *dcv1alpha1.SparkCluster, flavor dcv1alpha1.PodGroupFlavor) *unstructured.Unstructured {
+	return podgroup.NewPodGroupReference(PodGroupName(sc.Name), sc.Namespace, flavor)
+}
+
+// PodGroupName returns the name of the pod group shared by all cluster pods.
+func PodGroupName(name string) string {
+	return InstanceObjectName(name, ComponentNone)
+}
+
+// minWorkerReplicas returns the lowest number of workers the cluster is
+// expected to run.
+func minWorkerReplicas(sc *dcv1alpha1.SparkCluster) int32 {
+	if as := sc.Spec.Autoscaling; as != nil {
+		if as.MinReplicas != nil {
+			return *as.MinReplicas
+		}
+		return 1
+	}
+	if sc.Spec.Worker.Replicas != nil {
+		return *sc.Spec.Worker.Replicas
+	}
+
+	return 0
+}
@@ -8,14 +8,36 @@ import (
 )
 
 var (
-	policyAPIGroups            = []string{"policy"}
-	podSecurityPolicyResources = []string{"podsecuritypolicies"}
-	useVerbs                   = []string{"use"}
+	policyAPIGroups                     = []string{"policy"}
+	podSecurityPolicyResources          = []string{"podsecuritypolicies"}
+	securityAPIGroups                   = []string{"security.openshift.io"}
+	securityContextConstraintsResources = []string{"securitycontextconstraints"}
+	useVerbs                            = []string{"use"}
 )
 
 // NewPodSecurityPolicyRBAC generates the role and role binding required to use a pod security policy.
 // The role is bound to the service account used by the spark cluster pods.
 func NewPodSecurityPolicyRBAC(sc *dcv1alpha1.SparkCluster) (*rbacv1.Role, *rbacv1.RoleBinding) {
+	return podSecurityRBAC(sc, rbacv1.PolicyRule{
+		APIGroups:     policyAPIGroups,
+		Resources:     podSecurityPolicyResources,
+		Verbs:         useVerbs,
+		ResourceNames: []string{sc.Spec.PodSecurityPolicy},
+	})
+}
+
+// NewSecurityContextConstraintsRBAC generates the role and role binding required to use an OpenShift security
+// context constraints. The role is bound to the service account used by the spark cluster pods.
+func NewSecurityContextConstraintsRBAC(sc *dcv1alpha1.SparkCluster) (*rbacv1.Role, *rbacv1.RoleBinding) {
+	return podSecurityRBAC(sc, rbacv1.PolicyRule{
+		APIGroups:     securityAPIGroups,
+		Resources:     securityContextConstraintsResources,
+		Verbs:         useVerbs,
+		ResourceNames: []string{sc.Spec.SecurityContextConstraints},
+	})
+}
+
+func podSecurityRBAC(sc *dcv1alpha1.SparkCluster, rule rbacv1.PolicyRule) (*rbacv1.Role, *rbacv1.RoleBinding) {
 	name := InstanceObjectName(sc.Name, ComponentNone)
 
 	role := &rbacv1.Role{
@@ -24,14 +46,7 @@ func NewPodSecurityPolicyRBAC(sc *dcv1alpha1.SparkCluster) (*rbacv1.Role, *rbacv
 			Namespace: sc.Namespace,
 			Labels:    MetadataLabels(sc),
 		},
-		Rules: []rbacv1.PolicyRule{
-			{
-				APIGroups:     policyAPIGroups,
-				Resources:     podSecurityPolicyResources,
-				Verbs:         useVerbs,
-				ResourceNames: []string{sc.Spec.PodSecurityPolicy},
-			},
-		},
+		Rules: []rbacv1.PolicyRule{rule},
 	}
 
 	binding := &rbacv1.RoleBinding{
@@ -65,3 +65,24 @@ func TestNewPodSecurityPolicyRBAC(t *testing.T) {
 		assert.Equal(t, expected, roleBinding)
 	})
 }
+
+func TestNewSecurityContextConstraintsRBAC(t *testing.T) {
+	rc := sparkClusterFixture()
+	rc.Spec.SecurityContextConstraints = "test-scc"
+	role, roleBinding := NewSecurityContextConstraintsRBAC(rc)
+
+	assert.Equal(t, "test-id-spark", role.Name)
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{"security.openshift.io"},
+			Resources:     []string{"securitycontextconstraints"},
+			Verbs:         []string{"use"},
+			ResourceNames: []string{"test-scc"},
+		},
+	}, role.Rules)
+	assert.Equal(t, rbacv1.RoleRef{
+		APIGroup: "rbac.authorization.k8s.io",
+		Kind:     "Role",
+		Name:     "test-id-spark",
+	}, roleBinding.RoleRef)
+}
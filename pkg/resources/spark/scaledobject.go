@@ -0,0 +1,62 @@
+package spark
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/keda"
+)
+
+// NewScaledObject generates a KEDA ScaledObject that targets a SparkCluster
+// resource.
+//
+// When no triggers are provided, a "metrics-api" trigger is generated that
+// polls the master JSON endpoint and scales workers based on the number of
+// active applications. This requires the dashboard to be enabled.
+func NewScaledObject(sc *dcv1alpha1.SparkCluster) (*unstructured.Unstructured, error) {
+	autoscaling := sc.Spec.Autoscaling
+	if autoscaling == nil {
+		return nil, fmt.Errorf("cannot build scaled object without autoscaling config")
+	}
+
+	triggers := []dcv1alpha1.KEDATrigger{masterMetricsTrigger(sc)}
+	if !MasterMetricsTriggerEnabled(sc) {
+		triggers = autoscaling.KEDA.Triggers
+	}
+
+	return keda.NewScaledObject(&keda.ScaledObjectInfo{
+		Name:        InstanceObjectName(sc.Name, ComponentNone),
+		Namespace:   sc.Namespace,
+		Labels:      MetadataLabels(sc),
+		Target:      sc.TypeMeta,
+		TargetName:  sc.Name,
+		Autoscaling: autoscaling,
+		Triggers:    triggers,
+	}), nil
+}
+
+// ScaledObjectReference returns a shallow ScaledObject used to identify existing objects.
+func ScaledObjectReference(sc *dcv1alpha1.SparkCluster) *unstructured.Unstructured {
+	return keda.NewScaledObjectReference(InstanceObjectName(sc.Name, ComponentNone), sc.Namespace)
+}
+
+// MasterMetricsTriggerEnabled returns true when the scaled object uses the
+// default trigger that polls the master JSON endpoint.
+func MasterMetricsTriggerEnabled(sc *dcv1alpha1.SparkCluster) bool {
+	as := sc.Spec.Autoscaling
+	return as == nil || as.KEDA == nil || len(as.KEDA.Triggers) == 0
+}
+
+func masterMetricsTrigger(sc *dcv1alpha1.SparkCluster) dcv1alpha1.KEDATrigger {
+	return dcv1alpha1.KEDATrigger{
+		Type: "metrics-api",
+		Name: "spark-master-active-apps",
+		Metadata: map[string]string{
+			"url":           MasterStatusURL(sc),
+			"valueLocation": "activeapps.#",
+			"targetValue":   "1",
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package spark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+)
+
+func TestNewScaledObject(t *testing.T) {
+	t.Run("default_trigger", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		sc.Spec.Autoscaling = &dcv1alpha1.Autoscaling{
+			Backend:     dcv1alpha1.AutoscalingBackendKEDA,
+			MaxReplicas: 3,
+		}
+
+		so, err := NewScaledObject(sc)
+		require.NoError(t, err)
+
+		assert.Equal(t, "test-id-spark", so.GetName())
+
+		triggers, _, err := unstructured.NestedSlice(so.Object, "spec", "triggers")
+		require.NoError(t, err)
+
+		expected := []interface{}{
+			map[string]interface{}{
+				"type": "metrics-api",
+				"name": "spark-master-active-apps",
+				"metadata": map[string]interface{}{
+					"url":           "http://test-id-spark-master.fake-ns:8265/json/",
+					"valueLocation": "activeapps.#",
+					"targetValue":   "1",
+				},
+			},
+		}
+		assert.Equal(t, expected, triggers)
+	})
+
+	t.Run("custom_triggers", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		sc.Spec.Autoscaling = &dcv1alpha1.Autoscaling{
+			Backend:     dcv1alpha1.AutoscalingBackendKEDA,
+			MaxReplicas: 3,
+			KEDA: &dcv1alpha1.KEDAConfig{
+				Triggers: []dcv1alpha1.KEDATrigger{
+					{
+						Type:     "prometheus",
+						Metadata: map[string]string{"query": "sum(pending)"},
+					},
+				},
+			},
+		}
+
+		so, err := NewScaledObject(sc)
+		require.NoError(t, err)
+
+		triggers, _, err := unstructured.NestedSlice(so.Object, "spec", "triggers")
+		require.NoError(t, err)
+		require.Len(t, triggers, 1)
+		assert.Equal(t, "prometheus", triggers[0].(map[string]interface{})["type"])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		sc := sparkClusterFixture()
+		_, err := NewScaledObject(sc)
+
+		assert.Error(t, err)
+	})
+}
@@ -6,11 +6,15 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/expose"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
-// NewMasterService creates a ClusterIP service that points to the head node.
-// Dashboard port is exposed when enabled.
+// NewMasterService creates a service that points to the head node. Dashboard
+// port is exposed when enabled and routed through the authenticating proxy
+// when dashboard auth is enabled, since the proxy serves the "http" port of the
+// master pod. The service type is ClusterIP unless the cluster is exposed
+// through a load balancer or node port.
 func NewMasterService(sc *dcv1alpha1.SparkCluster) *corev1.Service {
 	ports := []corev1.ServicePort{
 		{
@@ -36,12 +40,13 @@ func NewMasterService(sc *dcv1alpha1.SparkCluster) *corev1.Service {
 
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      HeadServiceName(sc.Name),
-			Namespace: sc.Namespace,
-			Labels:    MetadataLabelsWithComponent(sc, ComponentMaster),
+			Name:        HeadServiceName(sc.Name),
+			Namespace:   sc.Namespace,
+			Labels:      MetadataLabelsWithComponent(sc, ComponentMaster),
+			Annotations: expose.ServiceAnnotations(sc.Spec.Expose),
 		},
 		Spec: corev1.ServiceSpec{
-			Type:     corev1.ServiceTypeClusterIP,
+			Type:     expose.ServiceType(sc.Spec.Expose, corev1.ServiceTypeClusterIP),
 			Ports:    ports,
 			Selector: SelectorLabelsWithComponent(sc, ComponentMaster),
 		},
@@ -49,6 +54,7 @@ func NewMasterService(sc *dcv1alpha1.SparkCluster) *corev1.Service {
 }
 
 // NewHeadlessService creates a headless service that points to worker nodes
+// and exposes the metrics port when monitoring is enabled.
 func NewHeadlessService(sc *dcv1alpha1.SparkCluster) *corev1.Service {
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -58,6 +64,7 @@ func NewHeadlessService(sc *dcv1alpha1.SparkCluster) *corev1.Service {
 		},
 		Spec: corev1.ServiceSpec{
 			ClusterIP: corev1.ClusterIPNone,
+			Ports:     metricsServicePorts(sc),
 			Selector:  SelectorLabels(sc),
 		},
 	}
@@ -9,6 +9,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
+
+	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
 )
 
 func TestNewHeadService(t *testing.T) {
@@ -58,6 +60,17 @@ func TestNewHeadService(t *testing.T) {
 
 		assert.Equal(t, expected, svc)
 	})
+
+	t.Run("exposed_with_node_port", func(t *testing.T) {
+		rc.Spec.Expose = &dcv1alpha1.ExposeConfig{
+			Type:        dcv1alpha1.ExposeTypeNodePort,
+			Annotations: map[string]string{"awesome": "true"},
+		}
+		svc := NewMasterService(rc)
+
+		assert.Equal(t, corev1.ServiceTypeNodePort, svc.Spec.Type)
+		assert.Equal(t, map[string]string{"awesome": "true"}, svc.Annotations)
+	})
 }
 
 func TestNewHeadlessService(t *testing.T) {
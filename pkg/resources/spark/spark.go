@@ -39,6 +39,12 @@ func HeadlessServiceName(name string) string {
 	return InstanceObjectName(name, ComponentWorker)
 }
 
+// MasterStatusURL returns the address of the JSON status endpoint served by
+// the spark master web UI.
+func MasterStatusURL(sc *dcv1alpha1.SparkCluster) string {
+	return fmt.Sprintf("http://%s.%s:%d/json/", HeadServiceName(sc.Name), sc.Namespace, sc.Spec.DashboardPort)
+}
+
 // MetadataLabels returns standard metadata for spark resources.
 func MetadataLabels(sc *dcv1alpha1.SparkCluster) map[string]string {
 	return resources.MetadataLabels(ApplicationName, sc.Name, sc.Spec.Image.Tag)
@@ -12,12 +12,31 @@ import (
 	"k8s.io/utils/pointer"
 
 	dcv1alpha1 "github.com/dominodatalab/distributed-compute-operator/api/v1alpha1"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/dashauth"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/podgroup"
+	"github.com/dominodatalab/distributed-compute-operator/pkg/resources/secprofile"
 	"github.com/dominodatalab/distributed-compute-operator/pkg/util"
 )
 
+// writableDirs are used by spark processes when the root filesystem is read-only.
+var writableDirs = []secprofile.WritableDir{
+	{Name: "spark-work", Path: "/opt/bitnami/spark/work"},
+	{Name: "spark-logs", Path: "/opt/bitnami/spark/logs"},
+	{Name: "spark-tmp", Path: "/opt/bitnami/spark/tmp"},
+	{Name: "spark-local", Path: "/tmp"},
+}
+
 // NewStatefulSet generates a Deployment configured to manage Spark cluster nodes.
 // The configuration is based the provided spec and the desired Component workload.
-func NewStatefulSet(sc *dcv1alpha1.SparkCluster, comp Component) (*appsv1.StatefulSet, error) {
+// The operator defaults supply the settings of the image that clusters do not provide;
+// fields missing from partial defaults are set to the built-in values.
+func NewStatefulSet(
+	sc *dcv1alpha1.SparkCluster,
+	comp Component,
+	defaults dcv1alpha1.SparkClusterDefaults,
+) (*appsv1.StatefulSet, error) {
+	defaults.SetDefaults()
+
 	var replicas int32
 	var nodeAttrs dcv1alpha1.SparkClusterNode
 
@@ -37,9 +56,11 @@ func NewStatefulSet(sc *dcv1alpha1.SparkCluster, comp Component) (*appsv1.Statef
 		return nil, err
 	}
 
-	ports := processPorts(sc)
+	ports := processPorts(sc, comp)
 	labels := processLabels(sc, comp, nodeAttrs.Labels)
-	envVars := append(componentEnvVars(sc, comp), sc.Spec.EnvVars...)
+	envVars := append(componentEnvVars(sc, comp), authEnvVars(sc)...)
+	envVars = append(envVars, monitoringEnvVars(sc)...)
+	envVars = append(envVars, sc.Spec.EnvVars...)
 	volumes := nodeAttrs.Volumes
 	volumeMounts := nodeAttrs.VolumeMounts
 
@@ -58,14 +79,14 @@ func NewStatefulSet(sc *dcv1alpha1.SparkCluster, comp Component) (*appsv1.Statef
 			annotations[k] = v
 		}
 	}
-	//TODO: Chart defaults a specific security context if enabled. Always setting for now
+	podLabels, podAnnotations := podgroup.PodMetadata(PodGroupName(sc.Name), sc.Spec.Scheduling, labels, annotations)
+
+	// the default user and group match the non-root user baked into the image
 	context := sc.Spec.PodSecurityContext
 	if context == nil {
-		const DefaultUser = 1001
-		const DefaultFSGroup = 1001
 		context = &corev1.PodSecurityContext{
-			RunAsUser: pointer.Int64Ptr(DefaultUser),
-			FSGroup:   pointer.Int64Ptr(DefaultFSGroup),
+			RunAsUser: pointer.Int64Ptr(*defaults.RunAsUser),
+			FSGroup:   pointer.Int64Ptr(*defaults.FSGroup),
 		}
 	}
 
@@ -79,6 +100,9 @@ func NewStatefulSet(sc *dcv1alpha1.SparkCluster, comp Component) (*appsv1.Statef
 		envVars,
 		volumeMounts,
 		volumes)
+	if err = applyPodOptions(sc, comp, &podSpec, defaults); err != nil {
+		return nil, err
+	}
 
 	statefulSet := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -94,8 +118,8 @@ func NewStatefulSet(sc *dcv1alpha1.SparkCluster, comp Component) (*appsv1.Statef
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
-					Annotations: annotations,
+					Labels:      podLabels,
+					Annotations: podAnnotations,
 				},
 				Spec: podSpec,
 			},
@@ -110,6 +134,27 @@ func NewStatefulSet(sc *dcv1alpha1.SparkCluster, comp Component) (*appsv1.Statef
 	return statefulSet, nil
 }
 
+// applyPodOptions adds optional features to the pod spec and hardens the
+// security context of every container, including the ones that were added.
+func applyPodOptions(
+	sc *dcv1alpha1.SparkCluster,
+	comp Component,
+	spec *corev1.PodSpec,
+	defaults dcv1alpha1.SparkClusterDefaults,
+) error {
+	if comp == ComponentMaster && dashauth.Enabled(sc.Spec.DashboardAuth) {
+		if err := addDashboardAuth(sc, spec); err != nil {
+			return err
+		}
+	}
+	secprofile.Apply(sc.Spec.SecurityProfile, spec, secprofile.Options{
+		RunAsUser:    *defaults.RunAsUser,
+		WritableDirs: writableDirs,
+	})
+
+	return nil
+}
+
 func getPodSpec(sc *dcv1alpha1.SparkCluster,
 	comp Component,
 	serviceAccountName string,
@@ -122,6 +167,8 @@ func getPodSpec(sc *dcv1alpha1.SparkCluster,
 	volumes []corev1.Volume) corev1.PodSpec {
 	return corev1.PodSpec{
 		ServiceAccountName: serviceAccountName,
+		SchedulerName:      podgroup.SchedulerName(sc.Spec.Scheduling),
+		PriorityClassName:  podgroup.PriorityClassName(sc.Spec.Scheduling),
 		NodeSelector:       nodeAttrs.NodeSelector,
 		Affinity:           nodeAttrs.Affinity,
 		Tolerations:        nodeAttrs.Tolerations,
@@ -218,14 +265,25 @@ func componentEnvVars(sc *dcv1alpha1.SparkCluster, comp Component) []corev1.EnvV
 				Value: "worker",
 			},
 		}
+		if DriverNetworkPolicyEnabled(sc) {
+			envVar = append(envVar, corev1.EnvVar{
+				Name:  "SPARK_WORKER_PORT",
+				Value: strconv.Itoa(int(sc.Spec.NetworkPolicy.Driver.WorkerPort)),
+			})
+		}
 	}
 	return envVar
 }
 
-func processPorts(sc *dcv1alpha1.SparkCluster) []corev1.ContainerPort {
+func processPorts(sc *dcv1alpha1.SparkCluster, comp Component) []corev1.ContainerPort {
+	uiPort := "http"
+	if comp == ComponentMaster && dashauth.Enabled(sc.Spec.DashboardAuth) {
+		uiPort = uiPortName
+	}
+
 	ports := []corev1.ContainerPort{
 		{
-			Name:          "http",
+			Name:          uiPort,
 			Protocol:      corev1.ProtocolTCP,
 			ContainerPort: sc.Spec.DashboardPort,
 		},
@@ -18,7 +18,7 @@ import (
 func TestNewStatefulSet(t *testing.T) {
 	t.Run("invalid_component", func(t *testing.T) {
 		rc := sparkClusterFixture()
-		_, err := NewStatefulSet(rc, Component("garbage"))
+		_, err := NewStatefulSet(rc, Component("garbage"), testDefaults.Spark)
 		assert.Error(t, err)
 	})
 
@@ -27,7 +27,7 @@ func TestNewStatefulSet(t *testing.T) {
 
 		t.Run("default_values", func(t *testing.T) {
 			rc := sparkClusterFixture()
-			actual, err := NewStatefulSet(rc, ComponentMaster)
+			actual, err := NewStatefulSet(rc, ComponentMaster, testDefaults.Spark)
 			require.NoError(t, err)
 
 			expected := &appsv1.StatefulSet{
@@ -133,7 +133,7 @@ func TestNewStatefulSet(t *testing.T) {
 
 		t.Run("default_values", func(t *testing.T) {
 			rc := sparkClusterFixture()
-			actual, err := NewStatefulSet(rc, ComponentWorker)
+			actual, err := NewStatefulSet(rc, ComponentWorker, testDefaults.Spark)
 			require.NoError(t, err)
 
 			expected := &appsv1.StatefulSet{
@@ -232,6 +232,23 @@ func TestNewStatefulSet(t *testing.T) {
 			}
 			assert.Equal(t, expected, actual, "worker statefulset not correctly generated")
 		})
+
+		t.Run("driver_network_policy", func(t *testing.T) {
+			rc := sparkClusterFixture()
+			rc.Spec.NetworkPolicy.Driver = &dcv1alpha1.SparkDriverNetworkPolicy{
+				Enabled:      pointer.BoolPtr(true),
+				DriverLabels: map[string]string{"spark-driver": "true"},
+				WorkerPort:   7078,
+			}
+
+			actual, err := NewStatefulSet(rc, ComponentWorker, testDefaults.Spark)
+			require.NoError(t, err)
+
+			assert.Contains(t, actual.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+				Name:  "SPARK_WORKER_PORT",
+				Value: "7078",
+			})
+		})
 	})
 }
 
@@ -242,7 +259,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 		rc := sparkClusterFixture()
 		rc.Spec.Image = &dcv1alpha1.OCIImageDefinition{}
 
-		_, err := NewStatefulSet(rc, comp)
+		_, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		assert.Error(t, err)
 	})
 
@@ -259,7 +276,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Labels = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		for _, labels := range []map[string]string{actual.Labels, actual.Spec.Template.Labels} {
@@ -283,7 +300,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Annotations = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Annotations)
@@ -311,7 +328,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.VolumeMounts = expectedVolMounts
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		assert.Subset(t, actual.Spec.Template.Spec.Volumes, expectedVols)
@@ -338,7 +355,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Resources = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Containers[0].Resources)
@@ -357,7 +374,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.NodeSelector = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.NodeSelector)
@@ -396,7 +413,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Affinity = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Affinity)
@@ -420,7 +437,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.Tolerations = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.Tolerations)
@@ -441,7 +458,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			rc.Spec.Worker.InitContainers = expected
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.Template.Spec.InitContainers)
@@ -456,7 +473,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			},
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		assert.Subset(t, actual.Spec.Template.Spec.Containers[0].Env, rc.Spec.EnvVars)
@@ -468,17 +485,159 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			RunAsUser: pointer.Int64Ptr(0),
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		assert.Equal(t, rc.Spec.PodSecurityContext, actual.Spec.Template.Spec.SecurityContext)
 	})
 
+	t.Run("configured_defaults", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		defaults := testDefaults.Spark
+		defaults.RunAsUser = pointer.Int64Ptr(2000)
+		defaults.FSGroup = pointer.Int64Ptr(3000)
+
+		actual, err := NewStatefulSet(rc, comp, defaults)
+		require.NoError(t, err)
+
+		expected := &corev1.PodSecurityContext{
+			RunAsUser: pointer.Int64Ptr(2000),
+			FSGroup:   pointer.Int64Ptr(3000),
+		}
+		assert.Equal(t, expected, actual.Spec.Template.Spec.SecurityContext)
+	})
+
+	t.Run("partial_defaults", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.SecurityProfile = dcv1alpha1.SecurityProfileRestricted
+
+		actual, err := NewStatefulSet(rc, comp, dcv1alpha1.SparkClusterDefaults{})
+		require.NoError(t, err)
+
+		expected := &corev1.PodSecurityContext{
+			RunAsUser: pointer.Int64Ptr(1001),
+			FSGroup:   pointer.Int64Ptr(1001),
+		}
+		assert.Equal(t, expected, actual.Spec.Template.Spec.SecurityContext)
+	})
+
+	t.Run("security_profile_restricted", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.SecurityProfile = dcv1alpha1.SecurityProfileRestricted
+
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
+		require.NoError(t, err)
+
+		container := actual.Spec.Template.Spec.Containers[0]
+		expected := &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			Privileged:               pointer.BoolPtr(false),
+			RunAsUser:                nil,
+			RunAsNonRoot:             pointer.BoolPtr(true),
+			ReadOnlyRootFilesystem:   pointer.BoolPtr(true),
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		}
+		assert.Equal(t, expected, container.SecurityContext)
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+			Name:      "spark-work",
+			MountPath: "/opt/bitnami/spark/work",
+		})
+		assert.Len(t, actual.Spec.Template.Spec.Volumes, 4)
+	})
+
+	t.Run("shared_secret_security", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.Security = &dcv1alpha1.SparkClusterSecurity{
+			Authenticate: pointer.BoolPtr(true),
+			Encryption:   pointer.BoolPtr(true),
+		}
+		rc.Spec.EnvVars = []corev1.EnvVar{{Name: "foo", Value: "bar"}}
+
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
+		require.NoError(t, err)
+
+		env := actual.Spec.Template.Spec.Containers[0].Env
+		assert.Subset(t, env, []corev1.EnvVar{
+			{
+				Name:  "SPARK_RPC_AUTHENTICATION_ENABLED",
+				Value: "yes",
+			},
+			{
+				Name: "SPARK_RPC_AUTHENTICATION_SECRET",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "test-id-spark-auth",
+						},
+						Key: "auth-secret",
+					},
+				},
+			},
+			{
+				Name:  "SPARK_RPC_ENCRYPTION_ENABLED",
+				Value: "yes",
+			},
+		})
+		assert.Equal(t, corev1.EnvVar{Name: "foo", Value: "bar"}, env[len(env)-1], "user env vars should take precedence")
+	})
+
+	t.Run("scheduling", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.Scheduling = &dcv1alpha1.SchedulingConfig{
+			SchedulerName:     "custom-scheduler",
+			PriorityClassName: "high-priority",
+		}
+
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
+		require.NoError(t, err)
+
+		assert.Equal(t, "custom-scheduler", actual.Spec.Template.Spec.SchedulerName)
+		assert.Equal(t, "high-priority", actual.Spec.Template.Spec.PriorityClassName)
+		assert.NotContains(t, actual.Spec.Template.Labels, "pod-group.scheduling.sigs.k8s.io")
+	})
+
+	t.Run("gang_scheduling_scheduler_plugins", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.Scheduling = &dcv1alpha1.SchedulingConfig{
+			Gang: &dcv1alpha1.GangSchedulingConfig{
+				Enabled: pointer.BoolPtr(true),
+			},
+		}
+
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
+		require.NoError(t, err)
+
+		assert.Equal(t, "test-id-spark", actual.Spec.Template.Labels["pod-group.scheduling.sigs.k8s.io"])
+		assert.NotContains(t, actual.Labels, "pod-group.scheduling.sigs.k8s.io")
+		assert.NotContains(t, actual.Spec.Selector.MatchLabels, "pod-group.scheduling.sigs.k8s.io")
+	})
+
+	t.Run("gang_scheduling_volcano", func(t *testing.T) {
+		rc := sparkClusterFixture()
+		rc.Spec.Scheduling = &dcv1alpha1.SchedulingConfig{
+			Gang: &dcv1alpha1.GangSchedulingConfig{
+				Enabled: pointer.BoolPtr(true),
+				Flavor:  dcv1alpha1.PodGroupFlavorVolcano,
+			},
+		}
+
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
+		require.NoError(t, err)
+
+		assert.Equal(t, "volcano", actual.Spec.Template.Spec.SchedulerName)
+		assert.Equal(t, "test-id-spark", actual.Spec.Template.Annotations["scheduling.k8s.io/group-name"])
+	})
+
 	t.Run("service_account_override", func(t *testing.T) {
 		rc := sparkClusterFixture()
 		rc.Spec.ServiceAccountName = "user-managed-sa"
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		assert.Equal(t, rc.Spec.ServiceAccountName, actual.Spec.Template.Spec.ServiceAccountName)
@@ -526,7 +685,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 			},
 		}
 
-		actual, err := NewStatefulSet(rc, comp)
+		actual, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.NoError(t, err)
 
 		assert.Equal(t, expected, actual.Spec.VolumeClaimTemplates)
@@ -550,7 +709,7 @@ func testCommonFeatures(t *testing.T, comp Component) {
 		case ComponentMaster:
 			rc.Spec.Master.SparkClusterNode.AdditionalStorage = additionalStorage
 		}
-		_, err := NewStatefulSet(rc, comp)
+		_, err := NewStatefulSet(rc, comp, testDefaults.Spark)
 		require.Error(t, err)
 	})
 }
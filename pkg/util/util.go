@@ -1,6 +1,7 @@
 package util
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -25,6 +26,10 @@ func MergeStringMaps(src, dst map[string]string) map[string]string {
 	return dst
 }
 
+// ErrInvalidImage is returned when an OCIImageDefinition cannot be parsed
+// into an image reference.
+var ErrInvalidImage = errors.New("invalid OCIImageDefinition")
+
 // ParseImageDefinition generates a fully-qualified image reference to an OCI image.
 // An error will be returned when the image definition is invalid.
 func ParseImageDefinition(def *v1alpha1.OCIImageDefinition) (string, error) {
@@ -39,7 +44,7 @@ func ParseImageDefinition(def *v1alpha1.OCIImageDefinition) (string, error) {
 
 	named, err := reference.ParseNormalizedNamed(ref)
 	if err != nil {
-		return "", fmt.Errorf("invalid OCIImageDefinition: %w", err)
+		return "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
 	}
 	named = reference.TagNameOnly(named)
 
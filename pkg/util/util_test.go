@@ -104,7 +104,7 @@ func TestParseImageDefinition(t *testing.T) {
 		actual, err := ParseImageDefinition(tc.input)
 
 		if tc.invalid {
-			assert.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidImage)
 			return
 		}
 